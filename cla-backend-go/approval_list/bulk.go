@@ -0,0 +1,164 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// bulk request decisions
+const (
+	BulkDecisionApprove = "approve"
+	BulkDecisionReject  = "reject"
+
+	// BulkStatusFailed is the result status of a request which was not processed
+	BulkStatusFailed = "failed"
+)
+
+// BulkProcessCclaWhitelistRequests approves or rejects the pending requests of the company and project - approved
+// contributors are added to the approval list with a single signature update and each contributor receives one email
+func (s service) BulkProcessCclaWhitelistRequests(authUser *auth.User, companyModel *models.Company, projectModel *models.Project, input *models.ApprovalListRequestBulkInput) (*models.ApprovalListRequestBulkResult, error) {
+	if input.Decision != BulkDecisionApprove && input.Decision != BulkDecisionReject {
+		return nil, fmt.Errorf("invalid decision: %s - expecting %s or %s", input.Decision, BulkDecisionApprove, BulkDecisionReject)
+	}
+
+	sig, err := s.getCclaSignature(companyModel.CompanyID, projectModel.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !isCLAManager(sig, authUser.UserName) {
+		log.Warnf("BulkProcessCclaWhitelistRequests - user: %s is not a CLA Manager of signature: %s for company: %s, project: %s",
+			authUser.UserName, sig.SignatureID, companyModel.CompanyID, projectModel.ProjectID)
+		return nil, ErrNotCLAManager
+	}
+
+	result := &models.ApprovalListRequestBulkResult{Decision: input.Decision}
+	var pending []*CLARequestModel
+	processed := map[string]bool{}
+	for _, requestID := range input.RequestIDs {
+		requestID = strings.TrimSpace(requestID)
+		if requestID == "" || processed[requestID] {
+			continue
+		}
+		processed[requestID] = true
+
+		requestModel, msg := s.getPendingRequest(requestID, companyModel.CompanyID, projectModel.ProjectID)
+		if requestModel == nil {
+			result.Results = append(result.Results, bulkItemResult(requestID, BulkStatusFailed, msg))
+			continue
+		}
+		pending = append(pending, requestModel)
+	}
+
+	// Add all the approved contributors to the approval list at once - nothing is approved if the update fails
+	if input.Decision == BulkDecisionApprove && len(pending) > 0 {
+		_, updateErr := s.signatureService.UpdateApprovalListForRequests(authUser, projectModel, companyModel, BuildBulkApprovalList(pending))
+		if updateErr != nil {
+			log.Warnf("BulkProcessCclaWhitelistRequests - unable to update the approval list of company: %s, project: %s, error: %+v",
+				companyModel.CompanyID, projectModel.ProjectID, updateErr)
+			for _, requestModel := range pending {
+				result.Results = append(result.Results, bulkItemResult(requestModel.RequestID, BulkStatusFailed, "unable to update the approval list"))
+			}
+			return result, nil
+		}
+		result.ApprovalListUpdated = true
+	}
+
+	// Update the request statuses and collect the requests of each contributor for a single email
+	var recipients []string
+	recipientRequests := map[string][]*CLARequestModel{}
+	for _, requestModel := range pending {
+		var statusErr error
+		status := StatusApproved
+		if input.Decision == BulkDecisionApprove {
+			statusErr = s.repo.ApproveCclaWhitelistRequest(requestModel.RequestID)
+		} else {
+			status = StatusRejected
+			statusErr = s.repo.RejectCclaWhitelistRequest(requestModel.RequestID)
+		}
+		if statusErr != nil {
+			result.Results = append(result.Results, bulkItemResult(requestModel.RequestID, BulkStatusFailed, "unable to update the request status"))
+			continue
+		}
+		result.Results = append(result.Results, bulkItemResult(requestModel.RequestID, status, ""))
+
+		if len(requestModel.UserEmails) == 0 {
+			log.Warnf("BulkProcessCclaWhitelistRequests - unable to send email - email missing for request: %s", requestModel.RequestID)
+			continue
+		}
+		key := strings.ToLower(requestModel.UserEmails[0])
+		if _, ok := recipientRequests[key]; !ok {
+			recipients = append(recipients, key)
+		}
+		recipientRequests[key] = append(recipientRequests[key], requestModel)
+	}
+
+	for _, key := range recipients {
+		requestModel := recipientRequests[key][0]
+		if input.Decision == BulkDecisionApprove {
+			s.sendRequestApprovedEmailToRecipient(companyModel, projectModel, requestModel.UserName, requestModel.UserEmails[0], input.Message)
+		} else {
+			s.sendRequestRejectedEmailToRecipient(companyModel, projectModel, sig, requestModel.UserName, requestModel.UserEmails[0], input.Message)
+		}
+	}
+
+	return result, nil
+}
+
+// BuildBulkApprovalList returns the approval list update adding the contributors of the requests
+func BuildBulkApprovalList(requests []*CLARequestModel) *models.ApprovalList {
+	approvalList := &models.ApprovalList{}
+	for _, requestModel := range requests {
+		if len(requestModel.UserEmails) > 0 {
+			approvalList.AddEmailApprovalList = append(approvalList.AddEmailApprovalList, requestModel.UserEmails[0])
+		}
+		if requestModel.UserGithubUsername != "" {
+			approvalList.AddGithubUsernameApprovalList = append(approvalList.AddGithubUsernameApprovalList, requestModel.UserGithubUsername)
+		}
+	}
+	return approvalList
+}
+
+// getPendingRequest returns the request when it is pending for the company and project, otherwise returns nil and
+// the reason the request can not be processed
+func (s service) getPendingRequest(requestID, companyID, projectID string) (*CLARequestModel, string) {
+	requestModel, err := s.repo.GetCclaWhitelistRequest(requestID)
+	if err != nil {
+		return nil, "unable to lookup the request"
+	}
+	if requestModel == nil || requestModel.RequestID == "" {
+		return nil, "request not found"
+	}
+	if requestModel.CompanyID != companyID || requestModel.ProjectID != projectID {
+		return nil, "request does not belong to the company and CLA Group"
+	}
+	if requestModel.RequestStatus != StatusPending {
+		return nil, fmt.Sprintf("request is %s", requestModel.RequestStatus)
+	}
+	return requestModel, ""
+}
+
+// isCLAManager returns true if the user is one of the CLA Managers of the signature
+func isCLAManager(sig *models.Signature, lfUsername string) bool {
+	for _, manager := range sig.SignatureACL {
+		if manager.LfUsername == lfUsername {
+			return true
+		}
+	}
+	return false
+}
+
+// bulkItemResult builds the result of one request of a bulk decision
+func bulkItemResult(requestID, status, message string) *models.ApprovalListRequestBulkItemResult {
+	return &models.ApprovalListRequestBulkItemResult{
+		RequestID: requestID,
+		Status:    status,
+		Message:   message,
+	}
+}
@@ -4,6 +4,8 @@
 package approval_list
 
 import (
+	"fmt"
+
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations"
@@ -20,7 +22,7 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 
 	api.CompanyAddCclaWhitelistRequestHandler = company.AddCclaWhitelistRequestHandlerFunc(
 		func(params company.AddCclaWhitelistRequestParams) middleware.Responder {
-			requestID, err := service.AddCclaWhitelistRequest(params.CompanyID, params.ProjectID, params.Body)
+			requestID, evaluation, err := service.AddCclaWhitelistRequest(params.CompanyID, params.ProjectID, params.Body)
 			if err != nil {
 				return company.NewAddCclaWhitelistRequestBadRequest().WithPayload(errorResponse(err))
 			}
@@ -33,6 +35,22 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 				EventData: &events.CCLAApprovalListRequestCreatedEventData{RequestID: requestID},
 			})
 
+			if evaluation != nil && evaluation.Approved {
+				eventsService.LogEvent(&events.LogEventArgs{
+					EventType: events.CCLAApprovalListRequestApproved,
+					ProjectID: params.ProjectID,
+					CompanyID: params.CompanyID,
+					UserID:    params.Body.ContributorID,
+					EventData: &events.CCLAApprovalListRequestApprovedEventData{
+						RequestID:        requestID,
+						AutoApproved:     true,
+						RulesEvaluated:   evaluation.RulesEvaluated,
+						MatchedRuleType:  evaluation.MatchedRuleType,
+						MatchedRuleValue: evaluation.MatchedRuleValue,
+					},
+				})
+			}
+
 			return company.NewAddCclaWhitelistRequestOK()
 		})
 
@@ -72,11 +90,55 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 			return company.NewRejectCclaWhitelistRequestOK()
 		})
 
+	api.CompanyGetCclaWhitelistAutoApprovalRulesHandler = company.GetCclaWhitelistAutoApprovalRulesHandlerFunc(
+		func(params company.GetCclaWhitelistAutoApprovalRulesParams, claUser *user.CLAUser) middleware.Responder {
+			result, err := service.GetAutoApprovalRules(claUser.LFUsername, params.CompanyID, params.ProjectID)
+			if err != nil {
+				switch err {
+				case ErrCclaSignatureNotFound:
+					return company.NewGetCclaWhitelistAutoApprovalRulesNotFound().WithPayload(errorResponse(err))
+				case ErrNotCLAManager:
+					return company.NewGetCclaWhitelistAutoApprovalRulesForbidden().WithPayload(errorResponse(err))
+				}
+				return company.NewGetCclaWhitelistAutoApprovalRulesBadRequest().WithPayload(errorResponse(err))
+			}
+
+			return company.NewGetCclaWhitelistAutoApprovalRulesOK().WithPayload(result)
+		})
+
+	api.CompanyUpdateCclaWhitelistAutoApprovalRulesHandler = company.UpdateCclaWhitelistAutoApprovalRulesHandlerFunc(
+		func(params company.UpdateCclaWhitelistAutoApprovalRulesParams, claUser *user.CLAUser) middleware.Responder {
+			result, err := service.UpdateAutoApprovalRules(claUser.LFUsername, params.CompanyID, params.ProjectID, &params.Body)
+			if err != nil {
+				switch err {
+				case ErrCclaSignatureNotFound:
+					return company.NewUpdateCclaWhitelistAutoApprovalRulesNotFound().WithPayload(errorResponse(err))
+				case ErrNotCLAManager:
+					return company.NewUpdateCclaWhitelistAutoApprovalRulesForbidden().WithPayload(errorResponse(err))
+				}
+				return company.NewUpdateCclaWhitelistAutoApprovalRulesBadRequest().WithPayload(errorResponse(err))
+			}
+
+			var rules []string
+			for _, rule := range result.Rules {
+				rules = append(rules, fmt.Sprintf("%s:%s", rule.RuleType, rule.Value))
+			}
+			eventsService.LogEvent(&events.LogEventArgs{
+				EventType: events.CCLAApprovalListAutoApprovalRulesUpdated,
+				ProjectID: params.ProjectID,
+				CompanyID: params.CompanyID,
+				UserID:    claUser.UserID,
+				EventData: &events.CCLAApprovalListAutoApprovalRulesUpdatedEventData{Rules: rules},
+			})
+
+			return company.NewUpdateCclaWhitelistAutoApprovalRulesOK().WithPayload(result)
+		})
+
 	api.CompanyListCclaWhitelistRequestsHandler = company.ListCclaWhitelistRequestsHandlerFunc(
 		func(params company.ListCclaWhitelistRequestsParams, claUser *user.CLAUser) middleware.Responder {
-			log.Debugf("Invoking ListCclaWhitelistRequest with Company ID: %+v, Project ID: %+v, Status: %+v",
-				params.CompanyID, params.ProjectID, params.Status)
-			result, err := service.ListCclaWhitelistRequest(params.CompanyID, params.ProjectID, params.Status)
+			log.Debugf("Invoking ListCclaWhitelistRequest with Company ID: %+v, Project ID: %+v, Status: %+v, Older Than: %+v",
+				params.CompanyID, params.ProjectID, params.Status, params.OlderThan)
+			result, err := service.ListCclaWhitelistRequest(params.CompanyID, params.ProjectID, params.Status, params.OlderThan)
 			if err != nil {
 				return company.NewListCclaWhitelistRequestsBadRequest().WithPayload(errorResponse(err))
 			}
@@ -86,9 +148,9 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 
 	api.CompanyListCclaWhitelistRequestsByCompanyAndProjectHandler = company.ListCclaWhitelistRequestsByCompanyAndProjectHandlerFunc(
 		func(params company.ListCclaWhitelistRequestsByCompanyAndProjectParams, claUser *user.CLAUser) middleware.Responder {
-			log.Debugf("Invoking ListCclaWhitelistRequestByCompanyProjectUser with Company ID: %+v, Project ID: %+v, Status: %+v",
-				params.CompanyID, params.ProjectID, params.Status)
-			result, err := service.ListCclaWhitelistRequestByCompanyProjectUser(params.CompanyID, &params.ProjectID, params.Status, nil)
+			log.Debugf("Invoking ListCclaWhitelistRequestByCompanyProjectUser with Company ID: %+v, Project ID: %+v, Status: %+v, Older Than: %+v",
+				params.CompanyID, params.ProjectID, params.Status, params.OlderThan)
+			result, err := service.ListCclaWhitelistRequestByCompanyProjectUser(params.CompanyID, &params.ProjectID, params.Status, nil, params.OlderThan)
 			if err != nil {
 				return company.NewListCclaWhitelistRequestsByCompanyAndProjectBadRequest().WithPayload(errorResponse(err))
 			}
@@ -100,7 +162,7 @@ func Configure(api *operations.ClaAPI, service IService, sessionStore *dynastore
 		func(params company.ListCclaWhitelistRequestsByCompanyAndProjectAndUserParams, claUser *user.CLAUser) middleware.Responder {
 			log.Debugf("Invoking ListCclaWhitelistRequestByCompanyProjectUser with Company ID: %+v, Project ID: %+v, Status: %+v, User: %+v",
 				params.CompanyID, params.ProjectID, params.Status, claUser.LFUsername)
-			result, err := service.ListCclaWhitelistRequestByCompanyProjectUser(params.CompanyID, &params.ProjectID, params.Status, &claUser.LFUsername)
+			result, err := service.ListCclaWhitelistRequestByCompanyProjectUser(params.CompanyID, &params.ProjectID, params.Status, &claUser.LFUsername, nil)
 			if err != nil {
 				return company.NewListCclaWhitelistRequestsByCompanyAndProjectAndUserBadRequest().WithPayload(errorResponse(err))
 			}
@@ -18,5 +18,6 @@ type CLARequestModel struct {
 	UserGithubUsername string   `dynamodbav:"user_github_username"`
 	DateCreated        string   `dynamodbav:"date_created"`
 	DateModified       string   `dynamodbav:"date_modified"`
+	DateReminderSent   string   `dynamodbav:"date_reminder_sent"`
 	Version            string   `dynamodbav:"version"`
 }
@@ -25,6 +25,12 @@ const (
 	Version = "v1"
 	// StatusPending is status of CclaWhitelistRequest
 	StatusPending = "pending"
+	// StatusApproved is the status of an approved CclaWhitelistRequest
+	StatusApproved = "approved"
+	// StatusRejected is the status of a rejected CclaWhitelistRequest
+	StatusRejected = "rejected"
+	// StatusExpired is the status of a CclaWhitelistRequest which stayed pending past the expiry timeout
+	StatusExpired = "expired"
 )
 
 // IRepository interface defines the functions for the whitelist service
@@ -33,7 +39,10 @@ type IRepository interface {
 	GetCclaWhitelistRequest(requestID string) (*CLARequestModel, error)
 	ApproveCclaWhitelistRequest(requestID string) error
 	RejectCclaWhitelistRequest(requestID string) error
-	ListCclaWhitelistRequest(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error)
+	ListCclaWhitelistRequest(companyID string, projectID, status, userID, createdBefore *string) (*models.CclaWhitelistRequestList, error)
+	ListPendingCclaWhitelistRequests() ([]models.CclaWhitelistRequest, error)
+	ExpireCclaWhitelistRequest(requestID string) error
+	UpdateCclaWhitelistRequestReminderSent(requestID string) error
 }
 
 type repository struct {
@@ -64,6 +73,7 @@ type CclaWhitelistRequest struct {
 	UserGithubUsername string   `dynamodbav:"user_github_username"`
 	DateCreated        string   `dynamodbav:"date_created"`
 	DateModified       string   `dynamodbav:"date_modified"`
+	DateReminderSent   string   `dynamodbav:"date_reminder_sent"`
 	Version            string   `dynamodbav:"version"`
 }
 
@@ -104,7 +114,7 @@ func (repo repository) AddCclaWhitelistRequest(company *models.Company, project
 	}
 
 	// Load the new record - should be able to find it quickly
-	record, readErr := repo.ListCclaWhitelistRequest(company.CompanyID, &project.ProjectID, nil, &user.UserID)
+	record, readErr := repo.ListCclaWhitelistRequest(company.CompanyID, &project.ProjectID, nil, &user.UserID, nil)
 	if readErr != nil || record == nil || record.List == nil {
 		log.Warnf("AddCclaWhitelistRequest - unable to read newly created invite record, error: %v", readErr)
 		return status, err
@@ -155,7 +165,7 @@ func (repo repository) ApproveCclaWhitelistRequest(requestID string) error {
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":s": {
-				S: aws.String("approved"),
+				S: aws.String(StatusApproved),
 			},
 			":m": {
 				S: aws.String(currentTime()),
@@ -189,7 +199,7 @@ func (repo repository) RejectCclaWhitelistRequest(requestID string) error {
 		},
 		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
 			":s": {
-				S: aws.String("rejected"),
+				S: aws.String(StatusRejected),
 			},
 			":m": {
 				S: aws.String(currentTime()),
@@ -209,8 +219,114 @@ func (repo repository) RejectCclaWhitelistRequest(requestID string) error {
 	return nil
 }
 
-// ListCclaWhitelistRequest list the requests for the specified query parameters
-func (repo repository) ListCclaWhitelistRequest(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error) {
+// ExpireCclaWhitelistRequest sets the status of the specified request to expired
+func (repo repository) ExpireCclaWhitelistRequest(requestID string) error {
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {
+				S: aws.String(requestID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#S": aws.String("request_status"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":s": {
+				S: aws.String(StatusExpired),
+			},
+			":m": {
+				S: aws.String(currentTime()),
+			},
+		},
+		UpdateExpression: aws.String("SET #S = :s, #M = :m"),
+		TableName:        aws.String(fmt.Sprintf("cla-%s-ccla-whitelist-requests", repo.stage)),
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.Warnf("ExpireCclaWhitelistRequest - unable to update approval request with expired status, error: %v",
+			err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateCclaWhitelistRequestReminderSent records the date the CLA Managers were last reminded of the specified request
+func (repo repository) UpdateCclaWhitelistRequestReminderSent(requestID string) error {
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"request_id": {
+				S: aws.String(requestID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#R": aws.String("date_reminder_sent"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r": {
+				S: aws.String(currentTime()),
+			},
+		},
+		UpdateExpression: aws.String("SET #R = :r"),
+		TableName:        aws.String(fmt.Sprintf("cla-%s-ccla-whitelist-requests", repo.stage)),
+	}
+
+	_, err := repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.Warnf("UpdateCclaWhitelistRequestReminderSent - unable to update approval request reminder date, error: %v",
+			err)
+		return err
+	}
+
+	return nil
+}
+
+// ListPendingCclaWhitelistRequests returns the pending requests of all the companies and projects
+func (repo repository) ListPendingCclaWhitelistRequests() ([]models.CclaWhitelistRequest, error) {
+	tableName := fmt.Sprintf("cla-%s-ccla-whitelist-requests", repo.stage)
+
+	filter := expression.Name("request_status").Equal(expression.Value(StatusPending))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(tableName),
+	}
+
+	var requests []models.CclaWhitelistRequest
+	for {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.Warnf("list pending requests error while scanning, error: %+v", scanErr)
+			return nil, scanErr
+		}
+
+		list, buildErr := buildCclaWhitelistRequestsModels(results.Items)
+		if buildErr != nil {
+			log.Warnf("unmarshall requests error while decoding the response, error: %+v", buildErr)
+			return nil, buildErr
+		}
+		requests = append(requests, list...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return requests, nil
+}
+
+// ListCclaWhitelistRequest list the requests for the specified query parameters - createdBefore is an RFC3339 date/time
+func (repo repository) ListCclaWhitelistRequest(companyID string, projectID, status, userID, createdBefore *string) (*models.CclaWhitelistRequestList, error) {
 	if projectID == nil {
 		return nil, errors.New("project ID can not be nil for ListCclaWhitelistRequest")
 	}
@@ -243,6 +359,13 @@ func (repo repository) ListCclaWhitelistRequest(companyID string, projectID, sta
 		userFilterExpression := expression.Name("user_id").Equal(expression.Value(userID))
 		filter = addConditionToFilter(filter, userFilterExpression, &filterAdded)
 	}
+
+	// Add the created before filter if provided - the dates are stored in the same RFC3339 UTC format, so they sort
+	// lexically
+	if createdBefore != nil {
+		createdFilterExpression := expression.Name("date_created").LessThan(expression.Value(*createdBefore))
+		filter = addConditionToFilter(filter, createdFilterExpression, &filterAdded)
+	}
 	if filterAdded {
 		builder = builder.WithFilter(filter)
 	}
@@ -270,7 +393,7 @@ func (repo repository) ListCclaWhitelistRequest(companyID string, projectID, sta
 		return nil, queryErr
 	}
 
-	list, err := buildCclaWhitelistRequestsModels(queryOutput)
+	list, err := buildCclaWhitelistRequestsModels(queryOutput.Items)
 	if err != nil {
 		log.Warnf("unmarshall requests error while decoding the response, error: %+v", err)
 		return nil, err
@@ -296,17 +419,18 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("user_github_username"),
 		expression.Name("date_created"),
 		expression.Name("date_modified"),
+		expression.Name("date_reminder_sent"),
 		expression.Name("version"),
 	)
 }
 
 // buildCclaWhitelistRequestsModels builds the request models
-func buildCclaWhitelistRequestsModels(results *dynamodb.QueryOutput) ([]models.CclaWhitelistRequest, error) {
+func buildCclaWhitelistRequestsModels(items []map[string]*dynamodb.AttributeValue) ([]models.CclaWhitelistRequest, error) {
 	requests := make([]models.CclaWhitelistRequest, 0)
 
 	var itemRequests []CclaWhitelistRequest
 
-	err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &itemRequests)
+	err := dynamodbattribute.UnmarshalListOfMaps(items, &itemRequests)
 	if err != nil {
 		log.Warnf("error unmarshalling CCLA Authorization Request from database, error: %v",
 			err)
@@ -318,6 +442,7 @@ func buildCclaWhitelistRequestsModels(results *dynamodb.QueryOutput) ([]models.C
 			CompanyName:        r.CompanyName,
 			DateCreated:        r.DateCreated,
 			DateModified:       r.DateModified,
+			DateReminderSent:   r.DateReminderSent,
 			ProjectID:          r.ProjectID,
 			ProjectName:        r.ProjectName,
 			RequestID:          r.RequestID,
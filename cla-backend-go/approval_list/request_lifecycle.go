@@ -0,0 +1,227 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_list
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// default ages of the pending requests at which the CLA Managers are reminded and the requests expire
+const (
+	DefaultRequestReminderAge = 7 * 24 * time.Hour
+	DefaultRequestExpiryAge   = 30 * 24 * time.Hour
+)
+
+// requestGroupKey identifies the pending requests of a company and project
+type requestGroupKey struct {
+	companyID string
+	projectID string
+}
+
+// ProcessPendingCclaWhitelistRequests expires the pending requests older than the expiry age and notifies their
+// contributors, then sends each CLA Manager a digest of the remaining requests older than the reminder age - a
+// request is included again once the reminder age has passed since its previous reminder. An expiry age of zero
+// disables the expiry.
+func (s service) ProcessPendingCclaWhitelistRequests(reminderAge, expiryAge time.Duration) error {
+	requests, err := s.repo.ListPendingCclaWhitelistRequests()
+	if err != nil {
+		log.Warnf("ProcessPendingCclaWhitelistRequests - unable to list the pending requests, error: %+v", err)
+		return err
+	}
+
+	expired, digests := SelectPendingRequests(requests, time.Now().UTC(), reminderAge, expiryAge)
+	for _, request := range expired {
+		s.expireCclaWhitelistRequest(request, expiryAge)
+	}
+	for _, digest := range digests {
+		s.sendPendingRequestsDigest(digest)
+	}
+
+	log.Debugf("ProcessPendingCclaWhitelistRequests - processed %d pending requests, expired %d requests, sent reminders for %d company projects",
+		len(requests), len(expired), len(digests))
+	return nil
+}
+
+// SelectPendingRequests returns the pending requests which have expired and the digests of the requests due for a
+// reminder - each digest holds the requests of one company and project, in the order the requests were listed.
+// Requests with an invalid creation date are skipped.
+func SelectPendingRequests(requests []models.CclaWhitelistRequest, now time.Time, reminderAge, expiryAge time.Duration) ([]models.CclaWhitelistRequest, [][]models.CclaWhitelistRequest) {
+	var expired []models.CclaWhitelistRequest
+	var groupKeys []requestGroupKey
+	groups := map[requestGroupKey][]models.CclaWhitelistRequest{}
+	for _, request := range requests {
+		created, parseErr := utils.ParseDateTime(request.DateCreated)
+		if parseErr != nil {
+			log.Warnf("SelectPendingRequests - unable to parse the creation date: %s of request: %s - skipping",
+				request.DateCreated, request.RequestID)
+			continue
+		}
+
+		if expiryAge > 0 && now.Sub(created) >= expiryAge {
+			expired = append(expired, request)
+			continue
+		}
+
+		if now.Sub(created) < reminderAge {
+			continue
+		}
+		if request.DateReminderSent != "" {
+			lastReminder, reminderErr := utils.ParseDateTime(request.DateReminderSent)
+			if reminderErr == nil && now.Sub(lastReminder) < reminderAge {
+				continue
+			}
+		}
+
+		key := requestGroupKey{companyID: request.CompanyID, projectID: request.ProjectID}
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], request)
+	}
+
+	var digests [][]models.CclaWhitelistRequest
+	for _, key := range groupKeys {
+		digests = append(digests, groups[key])
+	}
+	return expired, digests
+}
+
+// expireCclaWhitelistRequest moves the request to the expired status and notifies the contributor
+func (s service) expireCclaWhitelistRequest(request models.CclaWhitelistRequest, expiryAge time.Duration) {
+	err := s.repo.ExpireCclaWhitelistRequest(request.RequestID)
+	if err != nil {
+		log.Warnf("expireCclaWhitelistRequest - unable to expire request: %s, error: %+v", request.RequestID, err)
+		return
+	}
+
+	if len(request.UserEmails) == 0 {
+		log.Warnf("expireCclaWhitelistRequest - unable to send expired email - email missing for request: %s", request.RequestID)
+		return
+	}
+
+	companyModel, err := s.companyRepo.GetCompany(request.CompanyID)
+	if err != nil {
+		log.Warnf("expireCclaWhitelistRequest - unable to lookup company by id: %s, error: %+v", request.CompanyID, err)
+		return
+	}
+	projectModel, err := s.projectRepo.GetCLAGroupByID(request.ProjectID, DontLoadRepoDetails)
+	if err != nil {
+		log.Warnf("expireCclaWhitelistRequest - unable to lookup project by id: %s, error: %+v", request.ProjectID, err)
+		return
+	}
+
+	s.sendRequestExpiredEmailToRecipient(companyModel, projectModel, request.UserName, request.UserEmails[0], expiryAge)
+}
+
+// sendPendingRequestsDigest sends the digest of the pending requests of a company and project to the CLA Managers
+// and records the reminder date of the requests
+func (s service) sendPendingRequestsDigest(requests []models.CclaWhitelistRequest) {
+	key := requestGroupKey{companyID: requests[0].CompanyID, projectID: requests[0].ProjectID}
+	companyModel, err := s.companyRepo.GetCompany(key.companyID)
+	if err != nil {
+		log.Warnf("sendPendingRequestsDigest - unable to lookup company by id: %s, error: %+v", key.companyID, err)
+		return
+	}
+	projectModel, err := s.projectRepo.GetCLAGroupByID(key.projectID, DontLoadRepoDetails)
+	if err != nil {
+		log.Warnf("sendPendingRequestsDigest - unable to lookup project by id: %s, error: %+v", key.projectID, err)
+		return
+	}
+	sig, err := s.getCclaSignature(key.companyID, key.projectID)
+	if err != nil {
+		log.Warnf("sendPendingRequestsDigest - unable to lookup signature by company id: %s project id: %s, error: %+v",
+			key.companyID, key.projectID, err)
+		return
+	}
+
+	for _, manager := range sig.SignatureACL {
+		var whichEmail = manager.LfEmail
+		if whichEmail == "" && len(manager.Emails) > 0 {
+			whichEmail = manager.Emails[0]
+		}
+		if whichEmail == "" {
+			log.Warnf("unable to send email to manager: %+v - no email on file...", manager)
+			continue
+		}
+		s.sendPendingRequestsDigestToRecipient(companyModel, projectModel, manager.Username, whichEmail, requests)
+	}
+
+	for _, request := range requests {
+		if updateErr := s.repo.UpdateCclaWhitelistRequestReminderSent(request.RequestID); updateErr != nil {
+			log.Warnf("sendPendingRequestsDigest - unable to record the reminder date of request: %s, error: %+v",
+				request.RequestID, updateErr)
+		}
+	}
+}
+
+// sendPendingRequestsDigestToRecipient generates and sends the pending requests digest email to the specified recipient
+func (s service) sendPendingRequestsDigestToRecipient(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, requests []models.CclaWhitelistRequest) {
+	companyName := companyModel.CompanyName
+	projectName := projectModel.ProjectName
+
+	var requestList string
+	for _, request := range requests {
+		var email string
+		if len(request.UserEmails) > 0 {
+			email = request.UserEmails[0]
+		}
+		requestList += fmt.Sprintf("<li>%s (%s) - requested on %s</li>", request.UserName, email, request.DateCreated)
+	}
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: Pending Requests to Authorize Contributors for %s", projectName)
+	recipients := []string{recipientAddress}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>The following contributors requested to be added to the Allow List as authorized contributors from %s to the
+project %s and are still waiting for a response. You are receiving this message as a CLA Manager from %s for %s.</p>
+<ul>%s</ul>
+<p>Please <a href="https://%s#/company/%s" target="_blank">log into the EasyCLA Corporate Console</a> to approve or
+reject these requests.</p>
+%s
+%s`,
+		recipientName, projectName, companyName, projectName, companyName, projectName,
+		requestList, s.corpConsoleURL, companyModel.CompanyID,
+		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
+
+// sendRequestExpiredEmailToRecipient generates and sends the request expired email to the specified recipient
+func (s service) sendRequestExpiredEmailToRecipient(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, expiryAge time.Duration) {
+	companyName := companyModel.CompanyName
+	projectName := projectModel.ProjectName
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: Contributor Access Request Expired for %s", projectName)
+	recipients := []string{recipientAddress}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>Your request to be added to the Allow List as an authorized contributor from %s to the project %s was not
+reviewed by a CLA Manager within %d days and has expired.</p>
+<p>You may submit a new request, or reach out to the CLA Managers of %s directly.</p>
+%s
+%s`,
+		recipientName, projectName, companyName, projectName, int(expiryAge.Hours()/24), companyName,
+		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
@@ -6,8 +6,14 @@ package approval_list
 import (
 	"errors"
 	"fmt"
+	"html"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/LF-Engineering/lfx-kit/auth"
+
+	"github.com/communitybridge/easycla/cla-backend-go/github"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 
@@ -23,6 +29,8 @@ import (
 // errors
 var (
 	ErrCclaWhitelistRequestAlreadyExists = errors.New("CCLA whiltelist request already exist")
+	ErrCclaSignatureNotFound             = errors.New("signed and approved CCLA signature not found")
+	ErrNotCLAManager                     = errors.New("user is not a CLA Manager of the CCLA signature")
 )
 
 // constants
@@ -32,70 +40,76 @@ const (
 
 // IService interface defines the service methods/functions
 type IService interface {
-	AddCclaWhitelistRequest(companyID string, projectID string, args models.CclaWhitelistRequestInput) (string, error)
+	AddCclaWhitelistRequest(companyID string, projectID string, args models.CclaWhitelistRequestInput) (string, *signatures.AutoApprovalEvaluation, error)
 	ApproveCclaWhitelistRequest(companyID, projectID, requestID string) error
 	RejectCclaWhitelistRequest(companyID, projectID, requestID string) error
-	ListCclaWhitelistRequest(companyID string, projectID, status *string) (*models.CclaWhitelistRequestList, error)
-	ListCclaWhitelistRequestByCompanyProjectUser(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error)
+	ListCclaWhitelistRequest(companyID string, projectID, status *string, olderThan *int64) (*models.CclaWhitelistRequestList, error)
+	ListCclaWhitelistRequestByCompanyProjectUser(companyID string, projectID, status, userID *string, olderThan *int64) (*models.CclaWhitelistRequestList, error)
+	ProcessPendingCclaWhitelistRequests(reminderAge, expiryAge time.Duration) error
+	BulkProcessCclaWhitelistRequests(authUser *auth.User, companyModel *models.Company, projectModel *models.Project, input *models.ApprovalListRequestBulkInput) (*models.ApprovalListRequestBulkResult, error)
+	GetAutoApprovalRules(lfUsername, companyID, projectID string) (*models.AutoApprovalRuleList, error)
+	UpdateAutoApprovalRules(lfUsername, companyID, projectID string, rules *models.AutoApprovalRuleList) (*models.AutoApprovalRuleList, error)
 }
 
 type service struct {
-	repo           IRepository
-	userRepo       users.UserRepository
-	companyRepo    company.IRepository
-	projectRepo    project.ProjectRepository
-	signatureRepo  signatures.SignatureRepository
-	corpConsoleURL string
-	httpClient     *http.Client
+	repo             IRepository
+	userRepo         users.UserRepository
+	companyRepo      company.IRepository
+	projectRepo      project.ProjectRepository
+	signatureRepo    signatures.SignatureRepository
+	signatureService signatures.SignatureService
+	corpConsoleURL   string
+	httpClient       *http.Client
 }
 
 // NewService creates a new whitelist service
-func NewService(repo IRepository, userRepo users.UserRepository, companyRepo company.IRepository, projectRepo project.ProjectRepository, signatureRepo signatures.SignatureRepository, corpConsoleURL string, httpClient *http.Client) IService {
+func NewService(repo IRepository, userRepo users.UserRepository, companyRepo company.IRepository, projectRepo project.ProjectRepository, signatureRepo signatures.SignatureRepository, signatureService signatures.SignatureService, corpConsoleURL string, httpClient *http.Client) IService {
 	return service{
-		repo:           repo,
-		userRepo:       userRepo,
-		companyRepo:    companyRepo,
-		projectRepo:    projectRepo,
-		signatureRepo:  signatureRepo,
-		corpConsoleURL: corpConsoleURL,
-		httpClient:     httpClient,
+		repo:             repo,
+		userRepo:         userRepo,
+		companyRepo:      companyRepo,
+		projectRepo:      projectRepo,
+		signatureRepo:    signatureRepo,
+		signatureService: signatureService,
+		corpConsoleURL:   corpConsoleURL,
+		httpClient:       httpClient,
 	}
 }
 
-func (s service) AddCclaWhitelistRequest(companyID string, projectID string, args models.CclaWhitelistRequestInput) (string, error) {
-	list, err := s.ListCclaWhitelistRequestByCompanyProjectUser(companyID, &projectID, nil, &args.ContributorID)
+func (s service) AddCclaWhitelistRequest(companyID string, projectID string, args models.CclaWhitelistRequestInput) (string, *signatures.AutoApprovalEvaluation, error) {
+	list, err := s.ListCclaWhitelistRequestByCompanyProjectUser(companyID, &projectID, nil, &args.ContributorID, nil)
 	if err != nil {
 		log.Warnf("AddCclaWhitelistRequest - error looking up existing contributor invite requests for company: %s, project: %s, user by id: %s with name: %s, email: %s, error: %+v",
 			companyID, projectID, args.ContributorID, args.ContributorName, args.ContributorEmail, err)
-		return "", err
+		return "", nil, err
 	}
 	for _, item := range list.List {
 		if item.RequestStatus == "pending" || item.RequestStatus == "approved" {
 			log.Warnf("AddCclaWhitelistRequest - found existing contributor invite - id: %s, request for company: %s, project: %s, user by id: %s with name: %s, email: %s",
 				list.List[0].RequestID, companyID, projectID, args.ContributorID, args.ContributorName, args.ContributorEmail)
-			return "", ErrCclaWhitelistRequestAlreadyExists
+			return "", nil, ErrCclaWhitelistRequestAlreadyExists
 		}
 	}
 	companyModel, err := s.companyRepo.GetCompany(companyID)
 	if err != nil {
 		log.Warnf("AddCclaWhitelistRequest - unable to lookup company by id: %s, error: %+v", companyID, err)
-		return "", err
+		return "", nil, err
 	}
 	projectModel, err := s.projectRepo.GetCLAGroupByID(projectID, DontLoadRepoDetails)
 	if err != nil {
 		log.Warnf("AddCclaWhitelistRequest - unable to lookup project by id: %s, error: %+v", projectID, err)
-		return "", err
+		return "", nil, err
 	}
 	userModel, err := s.userRepo.GetUser(args.ContributorID)
 	if err != nil {
 		log.Warnf("AddCclaWhitelistRequest - unable to lookup user by id: %s with name: %s, email: %s, error: %+v",
 			args.ContributorID, args.ContributorName, args.ContributorEmail, err)
-		return "", err
+		return "", nil, err
 	}
 	if userModel == nil {
 		log.Warnf("AddCclaWhitelistRequest - unable to lookup user by id: %s with name: %s, email: %s, error: user object not found",
 			args.ContributorID, args.ContributorName, args.ContributorEmail)
-		return "", errors.New("invalid user")
+		return "", nil, errors.New("invalid user")
 	}
 
 	signed, approved := true, true
@@ -104,7 +118,7 @@ func (s service) AddCclaWhitelistRequest(companyID string, projectID string, arg
 	if sigErr != nil || sig == nil || sig.Signatures == nil {
 		log.Warnf("AddCclaWhitelistRequest - unable to lookup signature by company id: %s project id: %s - (or no managers), sig: %+v, error: %+v",
 			companyID, projectID, sig, err)
-		return "", err
+		return "", nil, err
 	}
 
 	requestID, addErr := s.repo.AddCclaWhitelistRequest(companyModel, projectModel, userModel, args.ContributorName, args.ContributorEmail)
@@ -113,10 +127,22 @@ func (s service) AddCclaWhitelistRequest(companyID string, projectID string, arg
 			args.ContributorID, args.ContributorName, args.ContributorEmail, addErr)
 	}
 
+	// Approve the request right away if the requester matches one of the auto-approval rules of the CCLA
+	evaluation := s.evaluateAutoApprovalRules(sig.Signatures[0], userModel)
+	if addErr == nil && evaluation != nil && evaluation.Approved {
+		approveErr := s.autoApproveCclaWhitelistRequest(companyModel, projectModel, requestID, userModel, evaluation)
+		if approveErr == nil {
+			return requestID, evaluation, nil
+		}
+		log.Warnf("AddCclaWhitelistRequest - unable to auto-approve request: %s for company: %s, project: %s - leaving the request pending, error: %+v",
+			requestID, companyID, projectID, approveErr)
+		evaluation.Approved = false
+	}
+
 	// Send the emails to the CLA managers for this CCLA Signature which includes the managers in the ACL list
 	s.sendRequestSentEmail(companyModel, projectModel, sig.Signatures[0], args.ContributorName, args.ContributorEmail, args.RecipientName, args.RecipientEmail, args.Message)
 
-	return requestID, nil
+	return requestID, evaluation, nil
 }
 
 // ApproveCclaWhitelistRequest is the handler for the approve CLA request
@@ -153,7 +179,7 @@ func (s service) ApproveCclaWhitelistRequest(companyID, projectID, requestID str
 	}
 
 	// Send the email
-	s.sendRequestApprovedEmailToRecipient(companyModel, projectModel, requestModel.UserName, requestModel.UserEmails[0])
+	s.sendRequestApprovedEmailToRecipient(companyModel, projectModel, requestModel.UserName, requestModel.UserEmails[0], "")
 
 	return nil
 }
@@ -200,19 +226,143 @@ func (s service) RejectCclaWhitelistRequest(companyID, projectID, requestID stri
 	}
 
 	// Send the email
-	s.sendRequestRejectedEmailToRecipient(companyModel, projectModel, sig.Signatures[0], requestModel.UserName, requestModel.UserEmails[0])
+	s.sendRequestRejectedEmailToRecipient(companyModel, projectModel, sig.Signatures[0], requestModel.UserName, requestModel.UserEmails[0], "")
 
 	return nil
 }
 
-// ListCclaWhitelistRequest is the handler for the list CLA request
-func (s service) ListCclaWhitelistRequest(companyID string, projectID, status *string) (*models.CclaWhitelistRequestList, error) {
-	return s.repo.ListCclaWhitelistRequest(companyID, projectID, status, nil)
+// ListCclaWhitelistRequest is the handler for the list CLA request - olderThan is an optional age in days
+func (s service) ListCclaWhitelistRequest(companyID string, projectID, status *string, olderThan *int64) (*models.CclaWhitelistRequestList, error) {
+	return s.repo.ListCclaWhitelistRequest(companyID, projectID, status, nil, createdBefore(olderThan))
+}
+
+// ListCclaWhitelistRequestByCompanyProjectUser is the handler for the list CLA request - olderThan is an optional age in days
+func (s service) ListCclaWhitelistRequestByCompanyProjectUser(companyID string, projectID, status, userID *string, olderThan *int64) (*models.CclaWhitelistRequestList, error) {
+	return s.repo.ListCclaWhitelistRequest(companyID, projectID, status, userID, createdBefore(olderThan))
+}
+
+// createdBefore converts the optional age in days to the matching creation date/time
+func createdBefore(olderThan *int64) *string {
+	if olderThan == nil {
+		return nil
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -int(*olderThan)).Format(time.RFC3339)
+	return &cutoff
+}
+
+// GetAutoApprovalRules returns the auto-approval rules of the CCLA signature of the company and project - only
+// the CLA Managers of the signature may view the rules
+func (s service) GetAutoApprovalRules(lfUsername, companyID, projectID string) (*models.AutoApprovalRuleList, error) {
+	sig, err := s.getCclaSignature(companyID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isCLAManager(sig, lfUsername) {
+		log.Warnf("GetAutoApprovalRules - user: %s is not a CLA Manager of signature: %s for company: %s, project: %s",
+			lfUsername, sig.SignatureID, companyID, projectID)
+		return nil, ErrNotCLAManager
+	}
+	return &models.AutoApprovalRuleList{Rules: sig.AutoApprovalRules}, nil
 }
 
-// ListCclaWhitelistRequestByCompanyProjectUser is the handler for the list CLA request
-func (s service) ListCclaWhitelistRequestByCompanyProjectUser(companyID string, projectID, status, userID *string) (*models.CclaWhitelistRequestList, error) {
-	return s.repo.ListCclaWhitelistRequest(companyID, projectID, status, userID)
+// UpdateAutoApprovalRules replaces the auto-approval rules of the CCLA signature of the company and project - only
+// the CLA Managers of the signature may update the rules
+func (s service) UpdateAutoApprovalRules(lfUsername, companyID, projectID string, rules *models.AutoApprovalRuleList) (*models.AutoApprovalRuleList, error) {
+	sig, err := s.getCclaSignature(companyID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isCLAManager(sig, lfUsername) {
+		log.Warnf("UpdateAutoApprovalRules - user: %s is not a CLA Manager of signature: %s for company: %s, project: %s",
+			lfUsername, sig.SignatureID, companyID, projectID)
+		return nil, ErrNotCLAManager
+	}
+
+	var updatedRules []*models.AutoApprovalRule
+	for _, rule := range rules.Rules {
+		if msg, valid := signatures.ValidAutoApprovalRule(rule); !valid {
+			return nil, fmt.Errorf("invalid auto-approval rule %s: %s - %s", rule.RuleType, rule.Value, msg)
+		}
+		duplicate := false
+		for _, existing := range updatedRules {
+			if existing.RuleType == rule.RuleType && strings.EqualFold(existing.Value, strings.TrimSpace(rule.Value)) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			updatedRules = append(updatedRules, &models.AutoApprovalRule{RuleType: rule.RuleType, Value: strings.TrimSpace(rule.Value)})
+		}
+	}
+
+	err = s.signatureRepo.UpdateAutoApprovalRules(sig.SignatureID, updatedRules)
+	if err != nil {
+		log.Warnf("UpdateAutoApprovalRules - unable to update the auto-approval rules of signature: %s, error: %+v", sig.SignatureID, err)
+		return nil, err
+	}
+
+	return &models.AutoApprovalRuleList{Rules: updatedRules}, nil
+}
+
+// getCclaSignature returns the signed and approved CCLA signature of the company and project
+func (s service) getCclaSignature(companyID, projectID string) (*models.Signature, error) {
+	signed, approved := true, true
+	pageSize := int64(5)
+	sigs, err := s.signatureRepo.GetProjectCompanySignatures(companyID, projectID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		log.Warnf("unable to lookup signature by company id: %s project id: %s, error: %+v", companyID, projectID, err)
+		return nil, err
+	}
+	if sigs == nil || len(sigs.Signatures) == 0 {
+		return nil, ErrCclaSignatureNotFound
+	}
+	return sigs.Signatures[0], nil
+}
+
+// evaluateAutoApprovalRules evaluates the auto-approval rules of the CCLA signature for the requester, returns nil
+// when the signature has no auto-approval rules. The email_domain rules are only matched against the email addresses
+// of the user record, never against the email address entered in the request.
+func (s service) evaluateAutoApprovalRules(sig *models.Signature, userModel *models.User) *signatures.AutoApprovalEvaluation {
+	if len(sig.AutoApprovalRules) == 0 {
+		return nil
+	}
+
+	var githubOrgs []string
+	if signatures.HasAutoApprovalRule(sig.AutoApprovalRules, signatures.AutoApprovalRuleGitHubOrg) && userModel.GithubUsername != "" {
+		var err error
+		githubOrgs, err = github.GetUserOrganizations(userModel.GithubUsername)
+		if err != nil {
+			log.Warnf("unable to lookup the GitHub organizations of user: %s - skipping the GitHub organization rules, error: %+v",
+				userModel.GithubUsername, err)
+		}
+	}
+
+	evaluation := signatures.EvaluateAutoApprovalRules(sig.AutoApprovalRules, signatures.GetUserEmails(userModel), githubOrgs)
+	log.Debugf("evaluated %d auto-approval rules for signature: %s, requester: %s - approved: %t",
+		evaluation.RulesEvaluated, sig.SignatureID, userModel.UserID, evaluation.Approved)
+	return evaluation
+}
+
+// autoApproveCclaWhitelistRequest approves the request and adds the requester to the approval list through the
+// signature service, which logs the approval list events and notifies the CLA Managers and the requester
+func (s service) autoApproveCclaWhitelistRequest(companyModel *models.Company, projectModel *models.Project, requestID string, userModel *models.User, evaluation *signatures.AutoApprovalEvaluation) error {
+	// only the verified identity which matched the rule is added - the email domain rules match the user record emails
+	// and the GitHub organization rules match the GitHub username of the user record
+	approvalList := &models.ApprovalList{}
+	switch evaluation.MatchedRuleType {
+	case signatures.AutoApprovalRuleEmailDomain:
+		approvalList.AddEmailApprovalList = []string{evaluation.MatchedEmail}
+	case signatures.AutoApprovalRuleGitHubOrg:
+		approvalList.AddGithubUsernameApprovalList = []string{userModel.GithubUsername}
+	}
+
+	_, err := s.signatureService.UpdateApprovalListAsSystem(projectModel, companyModel, approvalList)
+	if err != nil {
+		return err
+	}
+	return s.repo.ApproveCclaWhitelistRequest(requestID)
 }
 
 // sendRequestSentEmail sends emails to the CLA managers specified in the signature record
@@ -283,7 +433,15 @@ repository. This will permit them to begin contributing to %s on behalf of %s.</
 		companyModel.CompanyID, projectName, companyName,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          fmt.Sprintf("- %s (%s) requested to be added to the Allow List", html.EscapeString(contributorName), html.EscapeString(contributorEmail)),
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -291,10 +449,12 @@ repository. This will permit them to begin contributing to %s on behalf of %s.</
 	}
 }
 
-// sendRequestApprovedEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestApprovedEmailToRecipient(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string) {
+// sendRequestApprovedEmailToRecipient generates and sends an email to the specified recipient - the optional message
+// of the CLA Manager is included in the email
+func (s service) sendRequestApprovedEmailToRecipient(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress, message string) {
 	companyName := companyModel.CompanyName
 	projectName := projectModel.ProjectName
+	optionalMessage := getOptionalManagerMessage(message)
 
 	// subject string, body string, recipients []string
 	subject := fmt.Sprintf("EasyCLA: Contributor Access Approved for %s", projectName)
@@ -303,6 +463,7 @@ func (s service) sendRequestApprovedEmailToRecipient(companyModel *models.Compan
 <p>Hello %s,</p>
 <p>This is a notification email from EasyCLA regarding the project %s.</p>
 <p>You have now been approved as a contributor from %s for the project %s.</p>
+%s
 <p> To get started, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>,
 and select your company and then the project %s. From here you will
 be able to edit the list of approved employees and CLA Managers.
@@ -310,7 +471,7 @@ be able to edit the list of approved employees and CLA Managers.
 %s
 %s`,
 		recipientName, projectName,
-		companyName, projectName,
+		companyName, projectName, optionalMessage,
 		utils.GetCorporateURL(projectModel.Version == utils.V2), projectName,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
@@ -322,10 +483,12 @@ be able to edit the list of approved employees and CLA Managers.
 	}
 }
 
-// sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient
-func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Company, projectModel *models.Project, signature *models.Signature, recipientName, recipientAddress string) {
+// sendRequestRejectedEmailToRecipient generates and sends an email to the specified recipient - the optional message
+// of the CLA Manager is included in the email
+func (s service) sendRequestRejectedEmailToRecipient(companyModel *models.Company, projectModel *models.Project, signature *models.Signature, recipientName, recipientAddress, message string) {
 	companyName := companyModel.CompanyName
 	projectName := projectModel.ProjectName
+	optionalMessage := getOptionalManagerMessage(message)
 
 	var claManagerText = ""
 	claManagerText += "<ul>"
@@ -362,10 +525,11 @@ If you have further questions about this denial, please contact one of the exist
 %s for %s:</p>
 %s
 %s
+%s
 %s`,
 		recipientName, projectName,
 		companyName, projectName, companyName, projectName,
-		claManagerText,
+		claManagerText, optionalMessage,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
 	err := utils.SendEmail(subject, body, recipients)
@@ -375,3 +539,11 @@ If you have further questions about this denial, please contact one of the exist
 		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
 	}
 }
+
+// getOptionalManagerMessage returns the optional message of the CLA Manager as an email paragraph
+func getOptionalManagerMessage(message string) string {
+	if message == "" {
+		return ""
+	}
+	return fmt.Sprintf("<p>The CLA Manager included the following message:</p><br/><p>%s</p><br/>", html.EscapeString(message))
+}
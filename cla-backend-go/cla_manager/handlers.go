@@ -5,6 +5,7 @@ package cla_manager
 
 import (
 	"fmt"
+	"html"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/cla_manager"
 
@@ -692,6 +693,14 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 		// Audit Event sent from service upon success
 		signature, deleteErr := service.RemoveClaManager(params.CompanyID, params.ProjectID, params.UserLFID)
 
+		if deleteErr == signatures.ErrLastCLAManager {
+			msg := buildErrorMessageDeleteManager("EasyCLA - 409 Conflict - Delete CLA Manager - transfer the CLA Manager role instead", params, deleteErr)
+			log.Warn(msg)
+			return cla_manager.NewDeleteCLAManagerConflict().WithPayload(&models.ErrorResponse{
+				Message: msg,
+				Code:    "409",
+			})
+		}
 		if deleteErr != nil {
 			msg := buildErrorMessageDeleteManager("EasyCLA - 400 Bad Request - Delete CLA Manager - Service Error", params, deleteErr)
 			log.Warn(msg)
@@ -712,6 +721,64 @@ func Configure(api *operations.ClaAPI, service IService, companyService company.
 
 		return cla_manager.NewDeleteCLAManagerOK().WithPayload(signature)
 	})
+
+	// Transfer CLA Manager
+	api.ClaManagerTransferCLAManagerHandler = cla_manager.TransferCLAManagerHandlerFunc(func(params cla_manager.TransferCLAManagerParams, claUser *user.CLAUser) middleware.Responder {
+		if !isValidUser(claUser) {
+			return cla_manager.NewTransferCLAManagerUnauthorized().WithPayload(&models.ErrorResponse{
+				Message: "unauthorized",
+				Code:    "401",
+			})
+		}
+		if params.Body.FromUserLFID == "" || params.Body.ToUserLFID == "" || params.Body.FromUserLFID == params.Body.ToUserLFID {
+			msg := buildErrorMessageTransferManager("EasyCLA - 400 Bad Request - Transfer CLA Manager - expecting two different user LFIDs", params, nil)
+			log.Warn(msg)
+			return cla_manager.NewTransferCLAManagerBadRequest().WithPayload(&models.ErrorResponse{
+				Message: msg,
+				Code:    "400",
+			})
+		}
+
+		sigModel, sigErr := sigService.GetProjectCompanySignature(params.CompanyID, params.ProjectID, aws.Bool(true), aws.Bool(true), nil, aws.Int64(5))
+		if sigErr != nil || sigModel == nil {
+			msg := buildErrorMessageTransferManager("EasyCLA - 400 Bad Request - Transfer CLA Manager - error reading CCLA Signature", params, sigErr)
+			log.Warn(msg)
+			return cla_manager.NewTransferCLAManagerBadRequest().WithPayload(&models.ErrorResponse{
+				Message: msg,
+				Code:    "400",
+			})
+		}
+		if !currentUserInACL(claUser, sigModel.SignatureACL) {
+			msg := fmt.Sprintf("User %s / %s / %s is not authorized to transfer a CLA Manager for company ID: %s, project ID: %s",
+				claUser.UserID, claUser.Name, claUser.LFEmail, params.CompanyID, params.ProjectID)
+			log.Debug(msg)
+			return cla_manager.NewTransferCLAManagerUnauthorized().WithPayload(&models.ErrorResponse{
+				Message: msg,
+				Code:    "401",
+			})
+		}
+
+		// Audit Events sent from service upon success
+		signature, transferErr := service.TransferClaManager(params.CompanyID, params.ProjectID, params.Body.FromUserLFID, params.Body.ToUserLFID)
+		if transferErr == signatures.ErrCLAManagerACLConflict {
+			msg := buildErrorMessageTransferManager("EasyCLA - 409 Conflict - Transfer CLA Manager", params, transferErr)
+			log.Warn(msg)
+			return cla_manager.NewTransferCLAManagerConflict().WithPayload(&models.ErrorResponse{
+				Message: msg,
+				Code:    "409",
+			})
+		}
+		if transferErr != nil || signature == nil {
+			msg := buildErrorMessageTransferManager("EasyCLA - 400 Bad Request - Transfer CLA Manager - Service Error", params, transferErr)
+			log.Warn(msg)
+			return cla_manager.NewTransferCLAManagerBadRequest().WithPayload(&models.ErrorResponse{
+				Message: msg,
+				Code:    "400",
+			})
+		}
+
+		return cla_manager.NewTransferCLAManagerOK().WithPayload(signature)
+	})
 }
 
 // currentUserInACL is a helper function to determine if the current logged in user is in the CLA Manager list
@@ -776,6 +843,12 @@ func buildErrorMessageDeleteManager(errPrefix string, params cla_manager.DeleteC
 		errPrefix, params.CompanyID, params.ProjectID, params.UserLFID, err)
 }
 
+// buildErrorMessageTransferManager helper function to build an error message
+func buildErrorMessageTransferManager(errPrefix string, params cla_manager.TransferCLAManagerParams, err error) string {
+	return fmt.Sprintf("%s - problem transferring CLA Manager for company ID: %s, project ID: %s, from user ID: %s, to user ID: %s, error: %+v",
+		errPrefix, params.CompanyID, params.ProjectID, params.Body.FromUserLFID, params.Body.ToUserLFID, err)
+}
+
 // sendRequestAccessEmailToCLAManagers sends the request access email to the specified CLA Managers
 func sendRequestAccessEmailToCLAManagers(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail, recipientName, recipientAddress string) {
 	companyName := companyModel.CompanyName
@@ -804,7 +877,15 @@ additional CLA Manager.</p>
 		utils.GetCorporateURL(projectModel.Version == utils.V2), projectName,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          fmt.Sprintf("- %s (%s) requested to be added as a CLA Manager", html.EscapeString(requesterName), html.EscapeString(requesterEmail)),
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -835,7 +916,15 @@ list of company’s CLA Managers for %s.</p>
 		requesterName, requesterEmail,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          fmt.Sprintf("- %s (%s) was approved as a CLA Manager", html.EscapeString(requesterName), html.EscapeString(requesterEmail)),
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -895,7 +984,15 @@ be able to maintain the list of employees allowed to contribute to %s on behalf
 		requesterName, requesterEmail,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          fmt.Sprintf("- %s (%s) was denied as a CLA Manager", html.EscapeString(requesterName), html.EscapeString(requesterEmail)),
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
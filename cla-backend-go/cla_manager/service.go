@@ -5,6 +5,7 @@ package cla_manager
 
 import (
 	"fmt"
+	"html"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/communitybridge/easycla/cla-backend-go/company"
@@ -13,11 +14,17 @@ import (
 	sigAPI "github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/signatures"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	v2AcsService "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
+	v2OrgService "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
 )
 
+// claManagerRole is the name of the role assigned to the CLA Managers for the projects of the CLA Group
+const claManagerRole = "cla-manager"
+
 // IService interface defining the functions for the company service
 type IService interface {
 	CreateRequest(reqModel *CLAManagerRequest) (*models.ClaManagerRequest, error)
@@ -32,6 +39,7 @@ type IService interface {
 
 	AddClaManager(companyID string, projectID string, LFID string) (*models.Signature, error)
 	RemoveClaManager(companyID string, projectID string, LFID string) (*models.Signature, error)
+	TransferClaManager(companyID string, projectID string, fromLFID string, toLFID string) (*models.Signature, error)
 }
 
 type service struct {
@@ -41,11 +49,12 @@ type service struct {
 	usersService        users.Service
 	sigService          signatures.SignatureService
 	eventsService       events.Service
+	projectClaGroupRepo projects_cla_groups.Repository
 	corporateConsoleURL string
 }
 
 // NewService creates a new service object
-func NewService(repo IRepository, companyService company.IService, projectService project.Service, usersService users.Service, sigService signatures.SignatureService, eventsService events.Service, corporateConsoleURL string) IService {
+func NewService(repo IRepository, companyService company.IService, projectService project.Service, usersService users.Service, sigService signatures.SignatureService, eventsService events.Service, projectClaGroupRepo projects_cla_groups.Repository, corporateConsoleURL string) IService {
 	return service{
 		repo:                repo,
 		companyService:      companyService,
@@ -53,6 +62,7 @@ func NewService(repo IRepository, companyService company.IService, projectServic
 		usersService:        usersService,
 		sigService:          sigService,
 		eventsService:       eventsService,
+		projectClaGroupRepo: projectClaGroupRepo,
 		corporateConsoleURL: corporateConsoleURL,
 	}
 }
@@ -347,6 +357,158 @@ func (s service) RemoveClaManager(companyID string, projectID string, LFID strin
 	return updatedSignature, nil
 }
 
+// TransferClaManager replaces the CLA Manager fromLFID with the user toLFID in the signature Access Control List
+func (s service) TransferClaManager(companyID string, projectID string, fromLFID string, toLFID string) (*models.Signature, error) {
+	fromUserModel, userErr := s.usersService.GetUserByLFUserName(fromLFID)
+	if userErr != nil || fromUserModel == nil {
+		return nil, userErr
+	}
+	toUserModel, userErr := s.usersService.GetUserByLFUserName(toLFID)
+	if userErr != nil || toUserModel == nil {
+		return nil, userErr
+	}
+	companyModel, companyErr := s.companyService.GetCompany(companyID)
+	if companyErr != nil || companyModel == nil {
+		return nil, companyErr
+	}
+
+	projectModel, projectErr := s.projectService.GetCLAGroupByID(projectID)
+	if projectErr != nil || projectModel == nil {
+		return nil, projectErr
+	}
+
+	signed := true
+	approved := true
+	sigModel, sigErr := s.sigService.GetProjectCompanySignature(companyID, projectID, &signed, &approved, nil, aws.Int64(5))
+	if sigErr != nil || sigModel == nil {
+		return nil, sigErr
+	}
+
+	// Swap the managers in the signature ACL with a single update
+	updatedSignature, aclErr := s.sigService.TransferCLAManager(sigModel.SignatureID, fromLFID, toLFID)
+	if aclErr != nil || updatedSignature == nil {
+		log.Warnf("transfer CLA Manager returned an error or empty signature model using Signature ID: %s, error: %+v",
+			sigModel.SignatureID, aclErr)
+		return nil, aclErr
+	}
+
+	// Update the company ACL
+	companyACLError := s.companyService.AddUserToCompanyAccessList(companyID, toLFID)
+	if companyACLError != nil {
+		log.Warnf("TransferClaManager - Unable to add user to company ACL, companyID: %s, user: %s, error: %+v", companyID, toLFID, companyACLError)
+	}
+
+	// Move the cla-manager role scopes of the projects of the CLA Group
+	s.transferCLAManagerRoleScopes(companyModel, projectID, fromUserModel, toUserModel)
+
+	// Notify the remaining CLA Managers and the new manager
+	for _, manager := range updatedSignature.SignatureACL {
+		if manager.LfUsername == toLFID {
+			continue
+		}
+		sendClaManagerAddedEmailToCLAManagers(companyModel, projectModel, toUserModel.Username, toUserModel.LfEmail,
+			manager.Username, manager.LfEmail)
+		sendClaManagerDeleteEmailToCLAManagers(companyModel, projectModel, fromUserModel.LfUsername,
+			manager.Username, manager.LfEmail)
+	}
+	sendClaManagerAddedEmailToUser(companyModel, projectModel, toUserModel.Username, toUserModel.LfEmail)
+
+	// Notify the previous manager
+	sendRemovedClaManagerEmailToRecipient(companyModel, projectModel, fromUserModel.LfUsername, fromUserModel.LfEmail, updatedSignature.SignatureACL)
+
+	// Send an event for each side of the transfer
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:         events.ClaManagerCreated,
+		ProjectID:         projectID,
+		ProjectModel:      projectModel,
+		CompanyID:         companyID,
+		CompanyModel:      companyModel,
+		LfUsername:        toLFID,
+		UserID:            toLFID,
+		UserModel:         toUserModel,
+		ExternalProjectID: projectModel.ProjectExternalID,
+		EventData: &events.CLAManagerCreatedEventData{
+			CompanyName: companyModel.CompanyName,
+			ProjectName: projectModel.ProjectName,
+			UserName:    toUserModel.Username,
+			UserEmail:   toUserModel.LfEmail,
+			UserLFID:    toUserModel.LfUsername,
+		},
+	})
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:         events.ClaManagerDeleted,
+		ProjectID:         projectID,
+		ProjectModel:      projectModel,
+		CompanyID:         companyID,
+		CompanyModel:      companyModel,
+		LfUsername:        fromUserModel.LfUsername,
+		UserID:            fromLFID,
+		UserModel:         fromUserModel,
+		ExternalProjectID: projectModel.ProjectExternalID,
+		EventData: &events.CLAManagerDeletedEventData{
+			CompanyName: companyModel.CompanyName,
+			ProjectName: projectModel.ProjectName,
+			UserName:    fromUserModel.LfUsername,
+			UserEmail:   fromUserModel.LfEmail,
+			UserLFID:    fromLFID,
+		},
+	})
+
+	return updatedSignature, nil
+}
+
+// transferCLAManagerRoleScopes assigns the cla-manager role scope of each project of the CLA Group to the new manager
+// before removing the scope of the previous manager, so the company always keeps a manager scope. The signature ACL is
+// already updated at this point - a scope which can not be moved is logged and left to the ACL reconciler.
+func (s service) transferCLAManagerRoleScopes(companyModel *models.Company, claGroupID string, fromUserModel, toUserModel *models.User) {
+	if companyModel.CompanyExternalID == "" {
+		log.Debugf("transferCLAManagerRoleScopes - company: %s has no external ID - no role scopes to transfer", companyModel.CompanyID)
+		return
+	}
+
+	projectCLAGroups, err := s.projectClaGroupRepo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil {
+		log.Warnf("transferCLAManagerRoleScopes - unable to lookup the projects of CLA Group: %s, error: %+v", claGroupID, err)
+		return
+	}
+	if len(projectCLAGroups) == 0 {
+		return
+	}
+
+	roleID, err := v2AcsService.GetClient().GetRoleID(claManagerRole)
+	if err != nil {
+		log.Warnf("transferCLAManagerRoleScopes - unable to lookup the role ID of %s, error: %+v", claManagerRole, err)
+		return
+	}
+
+	orgClient := v2OrgService.GetClient()
+	companySFID := companyModel.CompanyExternalID
+	for _, projectCG := range projectCLAGroups {
+		if toUserModel.LfEmail == "" {
+			log.Warnf("transferCLAManagerRoleScopes - user: %s has no email - unable to assign the %s role scope for project: %s",
+				toUserModel.LfUsername, claManagerRole, projectCG.ProjectSFID)
+		} else {
+			scopeErr := orgClient.CreateOrgUserRoleOrgScopeProjectOrg(toUserModel.LfEmail, projectCG.ProjectSFID, companySFID, roleID)
+			if scopeErr != nil {
+				log.Warnf("transferCLAManagerRoleScopes - unable to assign the %s role scope to user: %s for company: %s, project: %s, error: %+v",
+					claManagerRole, toUserModel.LfUsername, companySFID, projectCG.ProjectSFID, scopeErr)
+			}
+		}
+
+		scopeID, scopeErr := orgClient.GetScopeID(companySFID, projectCG.ProjectSFID, claManagerRole, "project|organization", fromUserModel.LfUsername)
+		if scopeErr != nil || scopeID == "" {
+			log.Warnf("transferCLAManagerRoleScopes - unable to lookup the %s role scope of user: %s for company: %s, project: %s, error: %+v",
+				claManagerRole, fromUserModel.LfUsername, companySFID, projectCG.ProjectSFID, scopeErr)
+			continue
+		}
+		deleteErr := orgClient.DeleteOrgUserRoleOrgScopeProjectOrg(companySFID, roleID, scopeID, &fromUserModel.LfUsername, &fromUserModel.LfEmail)
+		if deleteErr != nil {
+			log.Warnf("transferCLAManagerRoleScopes - unable to remove the %s role scope of user: %s for company: %s, project: %s, error: %+v",
+				claManagerRole, fromUserModel.LfUsername, companySFID, projectCG.ProjectSFID, deleteErr)
+		}
+	}
+}
+
 func sendClaManagerAddedEmailToUser(companyModel *models.Company, projectModel *models.Project, requesterName, requesterEmail string) {
 	companyName := companyModel.CompanyName
 	projectName := projectModel.ProjectName
@@ -369,7 +531,15 @@ company and then the project %s. From here you will be able to edit the list of
 		utils.GetCorporateURL(projectModel.Version == utils.V2), projectName,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    requesterName,
+		RecipientAddress: requesterEmail,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          "- you were added as a CLA Manager",
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -400,7 +570,15 @@ list of company’s CLA Managers for %s.</p>
 		name, email,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          fmt.Sprintf("- %s (%s) was added as a CLA Manager", html.EscapeString(name), html.EscapeString(email)),
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -454,7 +632,15 @@ func sendRemovedClaManagerEmailToRecipient(companyModel *models.Company, project
 		recipientName, projectName, companyName, projectName, companyName, companyManagerText,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          "- you were removed as a CLA Manager",
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -479,7 +665,15 @@ func sendClaManagerDeleteEmailToCLAManagers(companyModel *models.Company, projec
 		recipientName, projectName, name, companyName, projectName,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          fmt.Sprintf("- %s was removed as a CLA Manager", html.EscapeString(name)),
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -0,0 +1,116 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/token"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/acl_reconciler"
+	acs_service "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
+	organization_service "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
+	user_service "github.com/communitybridge/easycla/cla-backend-go/v2/user-service"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var (
+	reconcilerService acl_reconciler.Service
+	dryRun            = true
+)
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	// DRY_RUN=false repairs the role scopes, the drift is only reported otherwise
+	if value := os.Getenv("DRY_RUN"); value != "" {
+		parsed, parseErr := strconv.ParseBool(value)
+		if parseErr != nil {
+			log.Warnf("invalid DRY_RUN value: %s - using dry run", value)
+		} else {
+			dryRun = parsed
+		}
+	}
+	log.Infof("dry run set to %t", dryRun)
+
+	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
+	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+	organization_service.InitClient(configFile.APIGatewayURL)
+	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+
+	reconcilerService = acl_reconciler.NewService(signaturesRepo, companyRepo, projectClaGroupRepo)
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	report, err := reconcilerService.Reconcile(dryRun)
+	if err != nil {
+		log.Warnf("unable to reconcile the CCLA signature ACLs, error: %+v", err)
+		return
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		log.Warnf("unable to marshal the drift report, error: %+v", err)
+		return
+	}
+	log.Infof("drift report: %s", string(reportJSON))
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(context.Background(), events.CloudWatchEvent{})
+	} else {
+		awslambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
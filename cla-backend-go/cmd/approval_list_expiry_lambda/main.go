@@ -0,0 +1,137 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	claevents "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+type combinedRepo struct {
+	users.UserRepository
+	company.IRepository
+	project.ProjectRepository
+}
+
+var (
+	signaturesService signatures.SignatureService
+	companyRepo       company.IRepository
+	projectRepo       project.ProjectRepository
+)
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	github.Init(configFile.Github.AppID, configFile.Github.AppPrivateKey, configFile.Github.AccessToken)
+	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	userRepo := user.NewDynamoRepository(awsSession, stage)
+	companyRepo = company.NewRepository(awsSession, stage)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo = project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+	})
+	usersService := users.NewService(usersRepo, eventsService)
+	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService)
+	// the GitHub organization validation only applies to the v1 GitHub organization approval list endpoints
+	signaturesService = signatures.NewService(signaturesRepo, companyService, usersService, eventsService, false, false)
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	now := time.Now().UTC()
+	sigs, err := signaturesService.GetApprovalListExpirationSignatures()
+	if err != nil {
+		log.Warnf("unable to load the signatures with approval list expirations, error: %+v", err)
+		return
+	}
+
+	for _, sig := range sigs {
+		projectModel, projErr := projectRepo.GetCLAGroupByID(sig.ProjectID, project.DontLoadRepoDetails)
+		if projErr != nil || projectModel == nil {
+			log.Warnf("unable to load CLA Group: %s of signature: %s - skipping, error: %+v", sig.ProjectID, sig.SignatureID, projErr)
+			continue
+		}
+		companyModel, compErr := companyRepo.GetCompany(sig.SignatureReferenceID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to load company: %s of signature: %s - skipping, error: %+v", sig.SignatureReferenceID, sig.SignatureID, compErr)
+			continue
+		}
+
+		err = signaturesService.ProcessApprovalListExpirations(projectModel, companyModel, sig, now)
+		if err != nil {
+			log.Warnf("unable to process the approval list expirations of signature: %s, error: %+v", sig.SignatureID, err)
+		}
+	}
+	log.Debugf("processed the approval list expirations of %d signatures", len(sigs))
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(context.Background(), events.CloudWatchEvent{})
+	} else {
+		awslambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
@@ -0,0 +1,143 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	claevents "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/user"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+type combinedRepo struct {
+	users.UserRepository
+	company.IRepository
+	project.ProjectRepository
+}
+
+var (
+	approvalListService approval_list.IService
+	reminderAge         = approval_list.DefaultRequestReminderAge
+	expiryAge           = approval_list.DefaultRequestExpiryAge
+)
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	// REQUEST_REMINDER_DAYS and REQUEST_EXPIRY_DAYS override the default ages - an expiry of 0 days disables the expiry
+	if days, ok := getDaysEnv("REQUEST_REMINDER_DAYS"); ok && days > 0 {
+		reminderAge = time.Duration(days) * 24 * time.Hour
+	}
+	if days, ok := getDaysEnv("REQUEST_EXPIRY_DAYS"); ok {
+		expiryAge = time.Duration(days) * 24 * time.Hour
+	}
+	log.Infof("reminder age set to %s, expiry age set to %s", reminderAge, expiryAge)
+
+	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	approvalListRepo := approval_list.NewRepository(awsSession, stage)
+	userRepo := user.NewDynamoRepository(awsSession, stage)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+	})
+	usersService := users.NewService(usersRepo, eventsService)
+	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService)
+	// the GitHub organization validation only applies to the v1 GitHub organization approval list endpoints
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, false, false)
+
+	approvalListService = approval_list.NewService(approvalListRepo, usersRepo, companyRepo, projectRepo, signaturesRepo, signaturesService, configFile.CorporateConsoleURL, http.DefaultClient)
+}
+
+// getDaysEnv returns the number of days set in the environment variable
+func getDaysEnv(name string) (int, bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		log.Warnf("invalid %s value: %s - using the default", name, value)
+		return 0, false
+	}
+	return days, true
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	err := approvalListService.ProcessPendingCclaWhitelistRequests(reminderAge, expiryAge)
+	if err != nil {
+		log.Warnf("unable to process the pending approval list requests, error: %+v", err)
+	}
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(context.Background(), events.CloudWatchEvent{})
+	} else {
+		awslambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
@@ -0,0 +1,96 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	ini "github.com/communitybridge/easycla/cla-backend-go/init"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/token"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	v2ClaManager "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
+	organization_service "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var backfillDesigneeDryRun bool
+
+// backfillDesigneeGrantsCmd represents the backfill-designee-grants command
+var backfillDesigneeGrantsCmd = &cobra.Command{
+	Use:   "backfill-designee-grants",
+	Short: "Record the cla-manager-designee role scopes which have no designee grant",
+	Long: `Load the cla-manager-designee role scopes of every company with an external ID and record a designee grant for
+each role scope without one, such as the role scopes assigned before the grants were recorded. The TTL of the
+backfilled grants starts with the backfill. The grants are printed without being recorded unless --dry-run=false is
+given.`,
+	Run: runBackfillDesigneeGrants,
+}
+
+func init() {
+	backfillDesigneeGrantsCmd.Flags().BoolVar(&backfillDesigneeDryRun, "dry-run", true, "print the missing grants without recording them")
+	rootCmd.AddCommand(backfillDesigneeGrantsCmd)
+}
+
+func runBackfillDesigneeGrants(cmd *cobra.Command, args []string) {
+	stage := viper.GetString("STAGE")
+	log.Infof("STAGE set to %s, dry run: %t", stage, backfillDesigneeDryRun)
+
+	awsSession, err := ini.GetAWSSession()
+	if err != nil {
+		log.Panicf("Unable to load AWS session - Error: %v", err)
+	}
+
+	configFile, err := config.LoadConfig(configFile, awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
+	organization_service.InitClient(configFile.APIGatewayURL)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsService := events.NewService(events.NewRepository(awsSession, stage), combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+	})
+
+	companies, err := companyRepo.GetCompanies()
+	if err != nil {
+		log.Fatalf("unable to load the companies, error: %+v", err)
+	}
+	var organizationIDs []string
+	for _, companyModel := range companies.Companies {
+		if companyModel.CompanyExternalID != "" {
+			organizationIDs = append(organizationIDs, companyModel.CompanyExternalID)
+		}
+	}
+
+	designeeExpiryService := v2ClaManager.NewDesigneeExpiryService(v2ClaManager.NewDesigneeRepository(awsSession, stage), eventsService)
+	grants, err := designeeExpiryService.BackfillDesigneeGrants(organizationIDs, backfillDesigneeDryRun)
+	if err != nil {
+		log.Fatalf("unable to backfill the designee grants, error: %+v", err)
+	}
+
+	grantsJSON, err := json.MarshalIndent(grants, "", "  ")
+	if err != nil {
+		log.Fatalf("unable to marshal the designee grants, error: %+v", err)
+	}
+	fmt.Println(string(grantsJSON))
+}
@@ -0,0 +1,139 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	claevents "github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/token"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	acs_service "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
+	v2ClaManager "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
+	organization_service "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
+
+	"github.com/aws/aws-lambda-go/events"
+	awslambda "github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+type combinedRepo struct {
+	users.UserRepository
+	company.IRepository
+	project.ProjectRepository
+}
+
+var (
+	designeeExpiryService v2ClaManager.DesigneeExpiryService
+	designeeTTL           = v2ClaManager.DefaultDesigneeTTL
+	reminderBefore        = v2ClaManager.DefaultDesigneeReminderBefore
+)
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	// DESIGNEE_TTL_DAYS and DESIGNEE_REMINDER_DAYS override the default ages - a reminder of 0 days disables the reminder
+	if days, ok := getDaysEnv("DESIGNEE_TTL_DAYS"); ok && days > 0 {
+		designeeTTL = time.Duration(days) * 24 * time.Hour
+	}
+	if days, ok := getDaysEnv("DESIGNEE_REMINDER_DAYS"); ok {
+		reminderBefore = time.Duration(days) * 24 * time.Hour
+	}
+	log.Infof("designee TTL set to %s, reminder set to %s before the expiry", designeeTTL, reminderBefore)
+
+	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
+	organization_service.InitClient(configFile.APIGatewayURL)
+	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+	designeeRepo := v2ClaManager.NewDesigneeRepository(awsSession, stage)
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+	})
+	designeeExpiryService = v2ClaManager.NewDesigneeExpiryService(designeeRepo, eventsService)
+}
+
+// getDaysEnv returns the number of days set in the environment variable
+func getDaysEnv(name string) (int, bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		log.Warnf("invalid %s value: %s - using the default", name, value)
+		return 0, false
+	}
+	return days, true
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	err := designeeExpiryService.ProcessDesigneeGrants(designeeTTL, reminderBefore)
+	if err != nil {
+		log.Warnf("unable to process the cla-manager-designee grants, error: %+v", err)
+	}
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(context.Background(), events.CloudWatchEvent{})
+	} else {
+		awslambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
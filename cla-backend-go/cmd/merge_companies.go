@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	ini "github.com/communitybridge/easycla/cla-backend-go/init"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/company_merge"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	mergeSurvivorCompanyID   string
+	mergeDuplicateCompanyIDs []string
+	mergeFile                string
+	mergeDryRun              bool
+	mergeLfUsername          string
+)
+
+// mergeCompaniesCmd represents the merge-companies command
+var mergeCompaniesCmd = &cobra.Command{
+	Use:   "merge-companies",
+	Short: "Merge duplicate company records into a surviving company",
+	Long: `Move the signatures, ACL entries, invites, approval list requests and CLA Manager requests of the duplicate
+companies to the surviving company and delete the duplicates. Either pass --survivor and --duplicates or, for a bulk
+cleanup, --file with a JSON array of {"survivorCompanyID": "...", "duplicateCompanyIDs": ["..."]} entries. The merge
+plans are printed without changing any record unless --dry-run=false is given.`,
+	Run: runMergeCompanies,
+}
+
+func init() {
+	mergeCompaniesCmd.Flags().StringVar(&mergeSurvivorCompanyID, "survivor", "", "the ID of the company which is kept")
+	mergeCompaniesCmd.Flags().StringSliceVar(&mergeDuplicateCompanyIDs, "duplicates", nil, "the IDs of the duplicate companies")
+	mergeCompaniesCmd.Flags().StringVar(&mergeFile, "file", "", "a JSON file with the companies to merge")
+	mergeCompaniesCmd.Flags().BoolVar(&mergeDryRun, "dry-run", true, "print the merge plans without merging the companies")
+	mergeCompaniesCmd.Flags().StringVar(&mergeLfUsername, "lf-username", "", "the LF username recorded in the audit event of the merge")
+	rootCmd.AddCommand(mergeCompaniesCmd)
+}
+
+func runMergeCompanies(cmd *cobra.Command, args []string) {
+	stage := viper.GetString("STAGE")
+	log.Infof("STAGE set to %s, dry run: %t", stage, mergeDryRun)
+
+	if !mergeDryRun && mergeLfUsername == "" {
+		log.Fatal("the --lf-username flag is required to merge the companies")
+	}
+
+	var inputs []*models.CompanyMergeInput
+	if mergeFile != "" {
+		data, err := ioutil.ReadFile(mergeFile)
+		if err != nil {
+			log.Fatalf("unable to read the file: %s, error: %+v", mergeFile, err)
+		}
+		if err := json.Unmarshal(data, &inputs); err != nil {
+			log.Fatalf("unable to parse the file: %s, error: %+v", mergeFile, err)
+		}
+	} else {
+		inputs = append(inputs, &models.CompanyMergeInput{
+			SurvivorCompanyID:   mergeSurvivorCompanyID,
+			DuplicateCompanyIDs: mergeDuplicateCompanyIDs,
+		})
+	}
+
+	awsSession, err := ini.GetAWSSession()
+	if err != nil {
+		log.Panicf("Unable to load AWS session - Error: %v", err)
+	}
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	projectRepo := project.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsService := events.NewService(events.NewRepository(awsSession, stage), combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+	})
+
+	mergeService := company_merge.NewService(company_merge.NewRepository(awsSession, stage), companyRepo, eventsService)
+
+	var plans []*models.CompanyMergePlan
+	failed := 0
+	for _, input := range inputs {
+		var plan *models.CompanyMergePlan
+		if mergeDryRun {
+			plan, err = mergeService.PreviewMerge(input.SurvivorCompanyID, input.DuplicateCompanyIDs)
+		} else {
+			plan, err = mergeService.Merge(input.SurvivorCompanyID, input.DuplicateCompanyIDs, mergeLfUsername)
+		}
+		if err != nil {
+			log.Warnf("unable to merge companies %+v into company: %s, error: %+v", input.DuplicateCompanyIDs, input.SurvivorCompanyID, err)
+			failed++
+		}
+		if plan != nil {
+			plans = append(plans, plan)
+		}
+	}
+
+	plansJSON, err := json.MarshalIndent(plans, "", "  ")
+	if err != nil {
+		log.Fatalf("unable to marshal the merge plans, error: %+v", err)
+	}
+	fmt.Println(string(plansJSON))
+	log.Infof("processed %d merges, %d failed", len(inputs), failed)
+}
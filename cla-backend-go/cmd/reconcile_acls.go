@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/config"
+	ini "github.com/communitybridge/easycla/cla-backend-go/init"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/token"
+	"github.com/communitybridge/easycla/cla-backend-go/users"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/acl_reconciler"
+	acs_service "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
+	organization_service "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
+	user_service "github.com/communitybridge/easycla/cla-backend-go/v2/user-service"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var reconcileDryRun bool
+
+// reconcileACLsCmd represents the reconcile-acls command
+var reconcileACLsCmd = &cobra.Command{
+	Use:   "reconcile-acls",
+	Short: "Reconcile the CCLA signature ACLs with the cla-manager role scopes",
+	Long: `Compare the CLA Managers of every CCLA signature with the cla-manager role scopes of the organization and print
+the drift report. Use --dry-run=false to create the missing role scopes and delete the stale role scopes.`,
+	Run: runReconcileACLs,
+}
+
+func init() {
+	reconcileACLsCmd.Flags().BoolVar(&reconcileDryRun, "dry-run", true, "report the drift without repairing the role scopes")
+	rootCmd.AddCommand(reconcileACLsCmd)
+}
+
+func runReconcileACLs(cmd *cobra.Command, args []string) {
+	stage := viper.GetString("STAGE")
+	log.Infof("STAGE set to %s, dry run: %t", stage, reconcileDryRun)
+
+	awsSession, err := ini.GetAWSSession()
+	if err != nil {
+		log.Panicf("Unable to load AWS session - Error: %v", err)
+	}
+
+	configFile, err := config.LoadConfig(configFile, awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	token.Init(configFile.Auth0Platform.ClientID, configFile.Auth0Platform.ClientSecret, configFile.Auth0Platform.URL, configFile.Auth0Platform.Audience)
+	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+	organization_service.InitClient(configFile.APIGatewayURL)
+	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+
+	reconcilerService := acl_reconciler.NewService(signaturesRepo, companyRepo, projectClaGroupRepo)
+	report, err := reconcilerService.Reconcile(reconcileDryRun)
+	if err != nil {
+		log.Fatalf("unable to reconcile the CCLA signature ACLs, error: %+v", err)
+	}
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("unable to marshal the drift report, error: %+v", err)
+	}
+	fmt.Println(string(reportJSON))
+}
@@ -35,6 +35,8 @@ func init() {
 		"GH_ORG_VALIDATION": "true",
 		// should we validate company API queries against the current authenticated user?
 		"COMPANY_USER_VALIDATION": "true",
+		// should we reject domain approval list entries which are not verified for the company? - warn only when false
+		"DOMAIN_VERIFICATION_ENFORCEMENT": "false",
 	}
 
 	for key, value := range defaults {
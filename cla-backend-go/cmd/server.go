@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	v2ClaGroupConfig "github.com/communitybridge/easycla/cla-backend-go/v2/cla_group_config"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_groups"
 	openapi_runtime "github.com/go-openapi/runtime"
 
@@ -53,8 +54,14 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/events"
 
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	v2Notifications "github.com/communitybridge/easycla/cla-backend-go/v2/notifications"
+
+	v2CompanyMerge "github.com/communitybridge/easycla/cla-backend-go/v2/company_merge"
+
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 	v2Project "github.com/communitybridge/easycla/cla-backend-go/v2/project"
+	v2ProjectMove "github.com/communitybridge/easycla/cla-backend-go/v2/project_move"
 
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
@@ -140,6 +147,10 @@ func server(localMode bool) http.Handler {
 	if err != nil {
 		log.Fatalf("COMPANY_USER_VALIDATION value must be a boolean string. Error: %v", err)
 	}
+	domainVerificationEnforcement, err := strconv.ParseBool(viper.GetString("DOMAIN_VERIFICATION_ENFORCEMENT"))
+	if err != nil {
+		log.Fatalf("DOMAIN_VERIFICATION_ENFORCEMENT value must be a boolean string. Error: %v", err)
+	}
 	stage := viper.GetString("STAGE")
 	dynamodbRegion := ini.GetProperty("DYNAMODB_AWS_REGION")
 
@@ -156,6 +167,7 @@ func server(localMode bool) http.Handler {
 	log.Infof("DYANAMODB_AWS_REGION    : %s", dynamodbRegion)
 	log.Infof("GH_ORG_VALIDATION       : %t", githubOrgValidation)
 	log.Infof("COMPANY_USER_VALIDATION : %t", companyUserValidation)
+	log.Infof("DOMAIN_VERIFICATION_ENFORCEMENT : %t", domainVerificationEnforcement)
 	log.Infof("STAGE                   : %s", stage)
 	log.Infof("Service Host            : %s", host)
 	log.Infof("Service Port            : %d", *portFlag)
@@ -215,6 +227,10 @@ func server(localMode bool) http.Handler {
 	metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, projectClaGroupRepo)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
 	claManagerReqRepo := cla_manager.NewRepository(awsSession, stage)
+	designeeRepo := v2ClaManager.NewDesigneeRepository(awsSession, stage)
+	notificationsRepo := notifications.NewRepository(awsSession, stage)
+	companyMergeRepo := v2CompanyMerge.NewRepository(awsSession, stage)
+	projectMoveRepo := v2ProjectMove.NewRepository(awsSession, stage)
 
 	// Our service layer handlers
 	eventsService := events.NewService(eventsRepo, combinedRepo{
@@ -228,15 +244,16 @@ func server(localMode bool) http.Handler {
 	projectService := project.NewService(projectRepo, repositoriesRepo, gerritRepo)
 	v2ProjectService := v2Project.NewService(projectRepo, projectClaGroupRepo)
 	companyService := company.NewService(companyRepo, configFile.CorporateConsoleURL, userRepo, usersService)
+	companyDomainService := company.NewDomainVerificationService(companyRepo, utils.NewTXTResolver())
 	v2CompanyService := v2Company.NewService(companyService, signaturesRepo, projectRepo, usersRepo, companyRepo, projectClaGroupRepo)
 	v2SignService := sign.NewService(configFile.ClaV1ApiURL, companyRepo, projectRepo, projectClaGroupRepo, companyService)
-	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, githubOrgValidation)
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, githubOrgValidation, domainVerificationEnforcement)
 	v2SignatureService := v2Signatures.NewService(projectService, companyService, signaturesService, projectClaGroupRepo)
-	claManagerService := cla_manager.NewService(claManagerReqRepo, companyService, projectService, usersService, signaturesService, eventsService, configFile.CorporateConsoleURL)
+	claManagerService := cla_manager.NewService(claManagerReqRepo, companyService, projectService, usersService, signaturesService, eventsService, projectClaGroupRepo, configFile.CorporateConsoleURL)
 	repositoriesService := repositories.NewService(repositoriesRepo)
 	v2RepositoriesService := v2Repositories.NewService(repositoriesRepo, projectClaGroupRepo, githubOrganizationsRepo)
-	v2ClaManagerService := v2ClaManager.NewService(companyService, projectService, claManagerService, usersService, repositoriesService, v2CompanyService, eventsService, projectClaGroupRepo)
-	approvalListService := approval_list.NewService(approvalListRepo, usersRepo, companyRepo, projectRepo, signaturesRepo, configFile.CorporateConsoleURL, http.DefaultClient)
+	v2ClaManagerService := v2ClaManager.NewService(companyService, projectService, claManagerService, usersService, repositoriesService, v2CompanyService, eventsService, projectClaGroupRepo, designeeRepo)
+	approvalListService := approval_list.NewService(approvalListRepo, usersRepo, companyRepo, projectRepo, signaturesRepo, signaturesService, configFile.CorporateConsoleURL, http.DefaultClient)
 	authorizer := auth.NewAuthorizer(authValidator, userRepo)
 	v2MetricsService := metrics.NewService(metricsRepo, projectClaGroupRepo)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, repositoriesRepo)
@@ -248,13 +265,19 @@ func server(localMode bool) http.Handler {
 		RefreshToken: configFile.LFGroup.RefreshToken,
 	})
 	v2GerritService := v2Gerrits.NewService()
-	v2ClaGroupService := cla_groups.NewService(projectService, templateService, projectClaGroupRepo, metricsRepo)
+	v2ClaGroupService := cla_groups.NewService(projectService, templateService, projectClaGroupRepo, metricsRepo, gerritService, githubOrganizationsService)
+	v2ClaGroupArchiveService := cla_groups.NewArchiveService(projectRepo, projectClaGroupRepo, gerritService, repositoriesService, signaturesService, eventsService)
+	notificationsService := notifications.NewService(notificationsRepo)
+	companyMergeService := v2CompanyMerge.NewService(companyMergeRepo, companyRepo, eventsService)
+	projectMoveService := v2ProjectMove.NewService(projectMoveRepo, projectClaGroupRepo, projectRepo, repositoriesRepo, gerritRepo, eventsService)
+	claGroupConfigService := v2ClaGroupConfig.NewService(projectService, v2ClaGroupService, projectClaGroupRepo, templateService, v2RepositoriesService, gerritService, v2GithubOrganizationsService, eventsService)
 
 	sessionStore, err := dynastore.New(dynastore.Path("/"), dynastore.HTTPOnly(), dynastore.TableName(configFile.SessionStoreTableName), dynastore.DynamoDB(dynamodb.New(awsSession)))
 	if err != nil {
 		log.Fatalf("Unable to create new Dynastore session - Error: %v", err)
 	}
 	utils.SetSnsEmailSender(awsSession, configFile.SNSEventTopicARN, configFile.SenderEmailAddress)
+	utils.SetNotificationSender(notificationsService)
 	utils.SetS3Storage(awsSession, configFile.SignatureFilesBucket)
 
 	// Setup security handlers
@@ -268,10 +291,10 @@ func server(localMode bool) http.Handler {
 	health.Configure(api, healthService)
 	v2Health.Configure(v2API, healthService)
 	template.Configure(api, templateService, eventsService)
-	v2Template.Configure(v2API, templateService, eventsService)
+	v2Template.Configure(v2API, templateService, projectService, eventsService)
 	github.Configure(api, configFile.Github.ClientID, configFile.Github.ClientSecret, configFile.Github.AccessToken, sessionStore)
 	signatures.Configure(api, signaturesService, sessionStore, eventsService)
-	v2Signatures.Configure(v2API, projectService, projectRepo, companyService, signaturesService, sessionStore, eventsService, v2SignatureService, projectClaGroupRepo)
+	v2Signatures.Configure(v2API, projectService, projectRepo, companyService, signaturesService, sessionStore, eventsService, v2SignatureService, projectClaGroupRepo, approvalListService)
 	approval_list.Configure(api, approvalListService, sessionStore, signaturesService, eventsService)
 	company.Configure(api, companyService, usersService, companyUserValidation, eventsService)
 	docs.Configure(api)
@@ -287,11 +310,15 @@ func server(localMode bool) http.Handler {
 	v2Repositories.Configure(v2API, v2RepositoriesService, eventsService)
 	gerrits.Configure(api, gerritService, projectService, eventsService)
 	v2Gerrits.Configure(v2API, gerritService, v2GerritService, projectService, eventsService, projectClaGroupRepo)
-	v2Company.Configure(v2API, v2CompanyService, companyRepo, configFile.LFXPortalURL)
+	v2Company.Configure(v2API, v2CompanyService, companyRepo, companyDomainService, configFile.LFXPortalURL)
 	cla_manager.Configure(api, claManagerService, companyService, projectService, usersService, signaturesService, eventsService, configFile.CorporateConsoleURL)
 	v2ClaManager.Configure(v2API, v2ClaManagerService, configFile.LFXPortalURL, projectClaGroupRepo, userRepo, eventsService)
 	sign.Configure(v2API, v2SignService)
-	cla_groups.Configure(v2API, v2ClaGroupService, projectService, eventsService, gerritService, repositoriesService, signaturesService)
+	v2Notifications.Configure(v2API, notificationsService)
+	v2CompanyMerge.Configure(v2API, companyMergeService)
+	v2ProjectMove.Configure(v2API, projectMoveService, projectService)
+	v2ClaGroupConfig.Configure(v2API, claGroupConfigService, projectService)
+	cla_groups.Configure(v2API, v2ClaGroupService, v2ClaGroupArchiveService, projectService, eventsService)
 
 	user_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
 	project_service.InitClient(configFile.APIGatewayURL)
@@ -0,0 +1,188 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+)
+
+// domain verification errors
+var (
+	ErrDomainAlreadyClaimed = errors.New("domain is already claimed by the company")
+	ErrDomainNotClaimed     = errors.New("domain is not claimed by the company")
+	ErrDomainNotVerified    = errors.New("the domain verification DNS TXT record was not found")
+)
+
+// DomainVerificationService lets a company claim domains and verify them with a DNS TXT record
+type DomainVerificationService interface {
+	GetCompanyDomains(companyID string) ([]*models.CompanyDomain, error)
+	ClaimCompanyDomain(companyID, domain string) (*models.CompanyDomain, error)
+	VerifyCompanyDomain(companyID, domain string) (*models.CompanyDomain, error)
+	RemoveCompanyDomain(companyID, domain string) error
+}
+
+type domainVerificationService struct {
+	repo     IRepository
+	resolver utils.TXTResolver
+}
+
+// NewDomainVerificationService creates a new domain verification service using the resolver to lookup the DNS TXT records
+func NewDomainVerificationService(repo IRepository, resolver utils.TXTResolver) DomainVerificationService {
+	return domainVerificationService{
+		repo:     repo,
+		resolver: resolver,
+	}
+}
+
+// GetCompanyDomains returns the domains claimed by the company, with the verification record of the pending domains
+func (s domainVerificationService) GetCompanyDomains(companyID string) ([]*models.CompanyDomain, error) {
+	domains, err := s.repo.GetCompanyDomains(companyID)
+	if err != nil {
+		return nil, err
+	}
+	return ToPendingDomainModels(domains), nil
+}
+
+// ClaimCompanyDomain claims the domain for the company - the domain is verified once the DNS TXT record of the
+// returned verification record is published
+func (s domainVerificationService) ClaimCompanyDomain(companyID, domain string) (*models.CompanyDomain, error) {
+	normalizedDomain, err := normalizeCompanyDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := s.repo.GetCompanyDomains(companyID)
+	if err != nil {
+		return nil, err
+	}
+	if findCompanyDomain(domains, normalizedDomain) >= 0 {
+		return nil, ErrDomainAlreadyClaimed
+	}
+
+	token, err := uuid.NewV4()
+	if err != nil {
+		log.Warnf("unable to generate a domain verification token, error: %v", err)
+		return nil, err
+	}
+	_, now := utils.CurrentTime()
+	claimed := DomainDBModel{
+		Domain:            normalizedDomain,
+		VerificationToken: token.String(),
+		DateCreated:       now,
+	}
+
+	err = s.repo.UpdateCompanyDomains(companyID, append(domains, claimed))
+	if err != nil {
+		return nil, err
+	}
+	return ToPendingDomainModels([]DomainDBModel{claimed})[0], nil
+}
+
+// VerifyCompanyDomain marks the domain as verified when the domain publishes the DNS TXT verification record
+func (s domainVerificationService) VerifyCompanyDomain(companyID, domain string) (*models.CompanyDomain, error) {
+	normalizedDomain, err := normalizeCompanyDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := s.repo.GetCompanyDomains(companyID)
+	if err != nil {
+		return nil, err
+	}
+	index := findCompanyDomain(domains, normalizedDomain)
+	if index < 0 {
+		return nil, ErrDomainNotClaimed
+	}
+
+	found, err := utils.HasDomainVerificationRecord(s.resolver, normalizedDomain, domains[index].VerificationToken)
+	if err != nil {
+		log.Warnf("unable to lookup the DNS TXT records of domain: %s, error: %v", normalizedDomain, err)
+		return nil, err
+	}
+	if !found {
+		return nil, ErrDomainNotVerified
+	}
+
+	if !domains[index].Verified {
+		_, now := utils.CurrentTime()
+		domains[index].Verified = true
+		domains[index].DateVerified = now
+		err = s.repo.UpdateCompanyDomains(companyID, domains)
+		if err != nil {
+			return nil, err
+		}
+		log.Debugf("verified domain: %s of company: %s", normalizedDomain, companyID)
+	}
+	return toDomainModels(domains[index : index+1])[0], nil
+}
+
+// RemoveCompanyDomain removes the domain from the domains claimed by the company
+func (s domainVerificationService) RemoveCompanyDomain(companyID, domain string) error {
+	normalizedDomain, err := normalizeCompanyDomain(domain)
+	if err != nil {
+		return err
+	}
+
+	domains, err := s.repo.GetCompanyDomains(companyID)
+	if err != nil {
+		return err
+	}
+	index := findCompanyDomain(domains, normalizedDomain)
+	if index < 0 {
+		return ErrDomainNotClaimed
+	}
+
+	return s.repo.UpdateCompanyDomains(companyID, append(domains[:index], domains[index+1:]...))
+}
+
+// ToPendingDomainModels converts the claimed domains to the swagger models with the verification record of the domains
+// which are not verified yet, so that the company manager can publish the DNS TXT record of a pending domain
+func ToPendingDomainModels(dbDomains []DomainDBModel) []*models.CompanyDomain {
+	domains := toDomainModels(dbDomains)
+	for i, domain := range domains {
+		if !domain.Verified {
+			domain.VerificationRecord = utils.DomainVerificationRecord(dbDomains[i].VerificationToken)
+		}
+	}
+	return domains
+}
+
+// VerifiedDomains returns the verified domains of the company
+func VerifiedDomains(companyModel *models.Company) []string {
+	var domains []string
+	for _, domain := range companyModel.CompanyDomains {
+		if domain.Verified {
+			domains = append(domains, domain.Domain)
+		}
+	}
+	return domains
+}
+
+// normalizeCompanyDomain returns the normalized domain, returns an error if the domain is not valid
+func normalizeCompanyDomain(domain string) (string, error) {
+	normalizedDomain, err := utils.NormalizeDomain(domain)
+	if err != nil {
+		return "", err
+	}
+	if msg, valid := utils.ValidDomain(normalizedDomain); !valid {
+		return "", errors.New(msg)
+	}
+	return normalizedDomain, nil
+}
+
+// findCompanyDomain returns the index of the domain in the claimed domains, or -1 when the domain is not claimed
+func findCompanyDomain(domains []DomainDBModel, domain string) int {
+	for i, claimed := range domains {
+		if strings.EqualFold(claimed.Domain, domain) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,108 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"errors"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// MaxCompanyHierarchyDepth is the maximum number of parent companies above a company
+const MaxCompanyHierarchyDepth = 10
+
+// company hierarchy errors
+var (
+	ErrCompanyHierarchyCycle = errors.New("the parent company is the company itself or one of its subsidiaries")
+	ErrCompanyHierarchyDepth = errors.New("the company hierarchy is too deep")
+)
+
+// ValidateParentCompany returns an error if the parent company does not exist or if the company can not be a
+// subsidiary of the parent company without creating a cycle in the hierarchy
+func ValidateParentCompany(repo IRepository, companyID, parentCompanyID string) error {
+	ancestorID := parentCompanyID
+	for depth := 0; ancestorID != ""; depth++ {
+		if ancestorID == companyID {
+			return ErrCompanyHierarchyCycle
+		}
+		if depth >= MaxCompanyHierarchyDepth {
+			return ErrCompanyHierarchyDepth
+		}
+		ancestor, err := repo.GetCompany(ancestorID)
+		if err != nil {
+			return err
+		}
+		ancestorID = ancestor.ParentCompanyID
+	}
+	return nil
+}
+
+// GetCCLACoveringCompanies returns the parent companies of the company, nearest first, whose CCLAs cover the
+// employees of their subsidiaries
+func GetCCLACoveringCompanies(repo IRepository, companyModel *models.Company) ([]*models.Company, error) {
+	var coveringCompanies []*models.Company
+	visited := map[string]bool{companyModel.CompanyID: true}
+	ancestorID := companyModel.ParentCompanyID
+	for depth := 0; ancestorID != "" && depth < MaxCompanyHierarchyDepth; depth++ {
+		if visited[ancestorID] {
+			log.Warnf("the hierarchy of company: %s contains a cycle at company: %s", companyModel.CompanyID, ancestorID)
+			break
+		}
+		visited[ancestorID] = true
+
+		ancestor, err := repo.GetCompany(ancestorID)
+		if err != nil {
+			if err == ErrCompanyDoesNotExist {
+				log.Warnf("the parent company: %s of the hierarchy of company: %s does not exist", ancestorID, companyModel.CompanyID)
+				break
+			}
+			return nil, err
+		}
+		if ancestor.SubsidiaryCclaCoverage {
+			coveringCompanies = append(coveringCompanies, ancestor)
+		}
+		ancestorID = ancestor.ParentCompanyID
+	}
+	return coveringCompanies, nil
+}
+
+// GetCCLACoveredSubsidiaries returns the subsidiaries of the company, at any depth, whose employees are covered by the
+// CCLA of the company. A subsidiary with its own CCLA is not covered, and neither are the subsidiaries below a
+// subsidiary whose own CCLA covers them. hasCCLA returns true when the company signed the CCLA.
+func GetCCLACoveredSubsidiaries(getSubsidiaries func(parentCompanyID string) ([]*models.Company, error), companyModel *models.Company, hasCCLA func(companyID string) bool) ([]*models.Company, error) {
+	var coveredCompanies []*models.Company
+	if !companyModel.SubsidiaryCclaCoverage {
+		return coveredCompanies, nil
+	}
+
+	visited := map[string]bool{companyModel.CompanyID: true}
+	parentIDs := []string{companyModel.CompanyID}
+	for depth := 0; len(parentIDs) > 0 && depth < MaxCompanyHierarchyDepth; depth++ {
+		var nextParentIDs []string
+		for _, parentID := range parentIDs {
+			subsidiaries, err := getSubsidiaries(parentID)
+			if err != nil {
+				return nil, err
+			}
+			for _, subsidiary := range subsidiaries {
+				if visited[subsidiary.CompanyID] {
+					log.Warnf("the hierarchy of company: %s contains a cycle at company: %s", companyModel.CompanyID, subsidiary.CompanyID)
+					continue
+				}
+				visited[subsidiary.CompanyID] = true
+
+				if !hasCCLA(subsidiary.CompanyID) {
+					coveredCompanies = append(coveredCompanies, subsidiary)
+				} else if subsidiary.SubsidiaryCclaCoverage {
+					// the CCLA of the subsidiary covers its own subsidiaries
+					continue
+				}
+				nextParentIDs = append(nextParentIDs, subsidiary.CompanyID)
+			}
+		}
+		parentIDs = nextParentIDs
+	}
+	return coveredCompanies, nil
+}
@@ -12,15 +12,29 @@ import (
 
 // DBModel data model
 type DBModel struct {
-	CompanyID         string   `dynamodbav:"company_id" json:"company_id"`
-	CompanyName       string   `dynamodbav:"company_name" json:"company_name"`
-	CompanyACL        []string `dynamodbav:"company_acl" json:"company_acl"`
-	CompanyExternalID string   `dynamodbav:"company_external_id" json:"company_external_id"`
-	CompanyManagerID  string   `dynamodbav:"company_manager_id" json:"company_manager_id"`
-	Created           string   `dynamodbav:"date_created" json:"date_created"`
-	Updated           string   `dynamodbav:"date_modified" json:"date_modified"`
-	Note              string   `dynamodbav:"note" json:"note"`
-	Version           string   `dynamodbav:"version" json:"version"`
+	CompanyID              string          `dynamodbav:"company_id" json:"company_id"`
+	CompanyName            string          `dynamodbav:"company_name" json:"company_name"`
+	CompanyAliases         []string        `dynamodbav:"company_aliases,omitempty" json:"company_aliases"`
+	CompanyFormerNames     []string        `dynamodbav:"company_former_names,omitempty" json:"company_former_names"`
+	CompanyACL             []string        `dynamodbav:"company_acl" json:"company_acl"`
+	CompanyExternalID      string          `dynamodbav:"company_external_id" json:"company_external_id"`
+	CompanyManagerID       string          `dynamodbav:"company_manager_id" json:"company_manager_id"`
+	CompanyDomains         []DomainDBModel `dynamodbav:"company_domains,omitempty" json:"company_domains"`
+	ParentCompanyID        string          `dynamodbav:"parent_company_id,omitempty" json:"parent_company_id"`
+	SubsidiaryCCLACoverage bool            `dynamodbav:"subsidiary_ccla_coverage" json:"subsidiary_ccla_coverage"`
+	Created                string          `dynamodbav:"date_created" json:"date_created"`
+	Updated                string          `dynamodbav:"date_modified" json:"date_modified"`
+	Note                   string          `dynamodbav:"note" json:"note"`
+	Version                string          `dynamodbav:"version" json:"version"`
+}
+
+// DomainDBModel is a domain claimed by the company
+type DomainDBModel struct {
+	Domain            string `dynamodbav:"domain" json:"domain"`
+	VerificationToken string `dynamodbav:"verification_token" json:"verification_token"`
+	Verified          bool   `dynamodbav:"verified" json:"verified"`
+	DateCreated       string `dynamodbav:"date_created" json:"date_created"`
+	DateVerified      string `dynamodbav:"date_verified,omitempty" json:"date_verified"`
 }
 
 // Invite data model
@@ -66,15 +80,20 @@ func (dbCompanyModel *DBModel) toModel() (*models.Company, error) {
 
 	// Convert the local DB model to a public swagger model
 	return &models.Company{
-		CompanyACL:        dbCompanyModel.CompanyACL,
-		CompanyID:         dbCompanyModel.CompanyID,
-		CompanyName:       dbCompanyModel.CompanyName,
-		CompanyExternalID: dbCompanyModel.CompanyExternalID,
-		CompanyManagerID:  dbCompanyModel.CompanyManagerID,
-		Created:           strfmt.DateTime(createdDateTime),
-		Updated:           strfmt.DateTime(updateDateTime),
-		Note:              dbCompanyModel.Note,
-		Version:           dbCompanyModel.Version,
+		CompanyACL:             dbCompanyModel.CompanyACL,
+		CompanyID:              dbCompanyModel.CompanyID,
+		CompanyName:            dbCompanyModel.CompanyName,
+		CompanyAliases:         dbCompanyModel.CompanyAliases,
+		CompanyFormerNames:     dbCompanyModel.CompanyFormerNames,
+		CompanyExternalID:      dbCompanyModel.CompanyExternalID,
+		CompanyManagerID:       dbCompanyModel.CompanyManagerID,
+		CompanyDomains:         toDomainModels(dbCompanyModel.CompanyDomains),
+		ParentCompanyID:        dbCompanyModel.ParentCompanyID,
+		SubsidiaryCclaCoverage: dbCompanyModel.SubsidiaryCCLACoverage,
+		Created:                strfmt.DateTime(createdDateTime),
+		Updated:                strfmt.DateTime(updateDateTime),
+		Note:                   dbCompanyModel.Note,
+		Version:                dbCompanyModel.Version,
 	}, nil
 }
 
@@ -94,14 +113,34 @@ func toSwaggerModel(dbCompanyModel *DBModel) (*models.Company, error) {
 
 	// Convert the local DB model to a public swagger model
 	return &models.Company{
-		CompanyACL:        dbCompanyModel.CompanyACL,
-		CompanyID:         dbCompanyModel.CompanyID,
-		CompanyName:       dbCompanyModel.CompanyName,
-		CompanyExternalID: dbCompanyModel.CompanyExternalID,
-		CompanyManagerID:  dbCompanyModel.CompanyManagerID,
-		Created:           strfmt.DateTime(createdDateTime),
-		Updated:           strfmt.DateTime(updateDateTime),
-		Note:              dbCompanyModel.Note,
-		Version:           dbCompanyModel.Version,
+		CompanyACL:             dbCompanyModel.CompanyACL,
+		CompanyID:              dbCompanyModel.CompanyID,
+		CompanyName:            dbCompanyModel.CompanyName,
+		CompanyAliases:         dbCompanyModel.CompanyAliases,
+		CompanyFormerNames:     dbCompanyModel.CompanyFormerNames,
+		CompanyExternalID:      dbCompanyModel.CompanyExternalID,
+		CompanyManagerID:       dbCompanyModel.CompanyManagerID,
+		CompanyDomains:         toDomainModels(dbCompanyModel.CompanyDomains),
+		ParentCompanyID:        dbCompanyModel.ParentCompanyID,
+		SubsidiaryCclaCoverage: dbCompanyModel.SubsidiaryCCLACoverage,
+		Created:                strfmt.DateTime(createdDateTime),
+		Updated:                strfmt.DateTime(updateDateTime),
+		Note:                   dbCompanyModel.Note,
+		Version:                dbCompanyModel.Version,
 	}, nil
 }
+
+// toDomainModels converts the claimed domains of the database model to the (public) swagger models - the verification
+// record is left out, it is only returned to the company manager claiming the domain
+func toDomainModels(dbDomains []DomainDBModel) []*models.CompanyDomain {
+	var domains []*models.CompanyDomain
+	for _, dbDomain := range dbDomains {
+		domains = append(domains, &models.CompanyDomain{
+			Domain:       dbDomain.Domain,
+			Verified:     dbDomain.Verified,
+			DateCreated:  dbDomain.DateCreated,
+			DateVerified: dbDomain.DateVerified,
+		})
+	}
+	return domains
+}
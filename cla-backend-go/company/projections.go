@@ -11,9 +11,14 @@ func buildCompanyProjection() expression.ProjectionBuilder {
 	return expression.NamesList(
 		expression.Name("company_id"),
 		expression.Name("company_name"),
+		expression.Name("company_aliases"),
+		expression.Name("company_former_names"),
 		expression.Name("company_acl"),
 		expression.Name("company_external_id"),
 		expression.Name("company_manager_id"),
+		expression.Name("company_domains"),
+		expression.Name("parent_company_id"),
+		expression.Name("subsidiary_ccla_coverage"),
 		expression.Name("date_created"),
 		expression.Name("date_modified"),
 		expression.Name("note"),
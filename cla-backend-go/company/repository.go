@@ -6,6 +6,7 @@ package company
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -55,6 +56,11 @@ type IRepository interface { //nolint
 	updateInviteRequestStatus(companyInviteID, status string) error
 
 	UpdateCompanyAccessList(companyID string, companyACL []string) error
+	GetCompanyDomains(companyID string) ([]DomainDBModel, error)
+	UpdateCompanyDomains(companyID string, domains []DomainDBModel) error
+	UpdateCompanyHierarchy(companyID, parentCompanyID string, subsidiaryCCLACoverage bool) error
+	UpdateCompanyAliases(companyID string, aliases, formerNames []string) error
+	GetSubsidiaryCompanies(parentCompanyID string) ([]*models.Company, error)
 }
 
 type repository struct {
@@ -265,7 +271,8 @@ func (repo repository) GetCompany(companyID string) (*models.Company, error) {
 	return dbCompanyModel.toModel()
 }
 
-// SearchCompanyByName locates companies by the matching name and return any potential matches
+// SearchCompanyByName locates companies by a fuzzy match of the name, aliases and former names and returns the
+// potential matches ordered by relevance
 func (repo repository) SearchCompanyByName(companyName string, nextKey string) (*models.Companies, error) {
 	// Sorry, no results if empty company name
 	if strings.TrimSpace(companyName) == "" {
@@ -278,11 +285,9 @@ func (repo repository) SearchCompanyByName(companyName string, nextKey string) (
 		}, nil
 	}
 
-	// This is the company name we want to match
-	filter := expression.Name("company_name").Contains(companyName)
-
-	// Use the nice builder to create the expression
-	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(buildCompanyProjection()).Build()
+	// The names, aliases and former names are scored in memory - a DynamoDB filter only supports exact and
+	// contains matches
+	expr, err := expression.NewBuilder().WithProjection(buildCompanyProjection()).Build()
 	if err != nil {
 		log.Warnf("error building expression for company scan, companyName: %s, error: %v",
 			companyName, err)
@@ -291,11 +296,9 @@ func (repo repository) SearchCompanyByName(companyName string, nextKey string) (
 
 	// Assemble the query input parameters
 	scanInput := &dynamodb.ScanInput{
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
-		TableName:                 aws.String(repo.companyTableName),
+		ExpressionAttributeNames: expr.Names(),
+		ProjectionExpression:     expr.Projection(),
+		TableName:                aws.String(repo.companyTableName),
 	}
 
 	// If we have the next key, set the exclusive start key value
@@ -331,8 +334,13 @@ func (repo repository) SearchCompanyByName(companyName string, nextKey string) (
 			return nil, modelErr
 		}
 
-		// Add to our response model list
-		companies = append(companies, companyList...)
+		// Add the relevant companies to our response model list
+		for _, companyModel := range companyList {
+			companyModel.RelevanceScore = companyRelevanceScore(companyName, companyModel)
+			if companyModel.RelevanceScore >= utils.MinCompanySearchScore {
+				companies = append(companies, companyModel)
+			}
+		}
 
 		if results.LastEvaluatedKey["company_id"] != nil {
 			//log.Debugf("LastEvaluatedKey: %+v", result.LastEvaluatedKey["signature_id"])
@@ -360,7 +368,16 @@ func (repo repository) SearchCompanyByName(companyName string, nextKey string) (
 
 	totalCount := *describeTableResult.Table.ItemCount
 
+	// Most relevant first, then by name
+	sort.SliceStable(companies, func(i, j int) bool {
+		if companies[i].RelevanceScore != companies[j].RelevanceScore {
+			return companies[i].RelevanceScore > companies[j].RelevanceScore
+		}
+		return strings.ToLower(companies[i].CompanyName) < strings.ToLower(companies[j].CompanyName)
+	})
+
 	return &models.Companies{
+		SearchTerms:    companyName,
 		ResultCount:    int64(len(companies)),
 		TotalCount:     totalCount,
 		LastKeyScanned: lastEvaluatedKey,
@@ -368,6 +385,14 @@ func (repo repository) SearchCompanyByName(companyName string, nextKey string) (
 	}, nil
 }
 
+// companyRelevanceScore returns the best relevance score of the company name, aliases and former names for the search term
+func companyRelevanceScore(searchTerm string, companyModel models.Company) float64 {
+	names := []string{companyModel.CompanyName}
+	names = append(names, companyModel.CompanyAliases...)
+	names = append(names, companyModel.CompanyFormerNames...)
+	return utils.BestCompanyNameMatchScore(searchTerm, names...)
+}
+
 // DeleteCompanyByID deletes the company by ID
 func (repo repository) DeleteCompanyByID(companyID string) error {
 	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
@@ -570,12 +595,14 @@ func buildCompanyModels(results *dynamodb.ScanOutput) ([]models.Company, error)
 	var companies []models.Company
 
 	type ItemSignature struct {
-		CompanyID         string   `json:"company_id"`
-		CompanyName       string   `json:"company_name"`
-		CompanyACL        []string `json:"company_acl"`
-		CompanyExternalID string   `json:"company_external_id"`
-		Created           string   `json:"date_created"`
-		Modified          string   `json:"date_modified"`
+		CompanyID          string   `json:"company_id"`
+		CompanyName        string   `json:"company_name"`
+		CompanyAliases     []string `json:"company_aliases"`
+		CompanyFormerNames []string `json:"company_former_names"`
+		CompanyACL         []string `json:"company_acl"`
+		CompanyExternalID  string   `json:"company_external_id"`
+		Created            string   `json:"date_created"`
+		Modified           string   `json:"date_modified"`
 	}
 
 	// The DB company model
@@ -605,12 +632,14 @@ func buildCompanyModels(results *dynamodb.ScanOutput) ([]models.Company, error)
 		}
 
 		companies = append(companies, models.Company{
-			CompanyACL:        dbCompany.CompanyACL,
-			CompanyID:         dbCompany.CompanyID,
-			CompanyName:       dbCompany.CompanyName,
-			CompanyExternalID: dbCompany.CompanyExternalID,
-			Created:           strfmt.DateTime(createdDateTime),
-			Updated:           strfmt.DateTime(modifiedDateTime),
+			CompanyACL:         dbCompany.CompanyACL,
+			CompanyID:          dbCompany.CompanyID,
+			CompanyName:        dbCompany.CompanyName,
+			CompanyAliases:     dbCompany.CompanyAliases,
+			CompanyFormerNames: dbCompany.CompanyFormerNames,
+			CompanyExternalID:  dbCompany.CompanyExternalID,
+			Created:            strfmt.DateTime(createdDateTime),
+			Updated:            strfmt.DateTime(modifiedDateTime),
 		})
 	}
 
@@ -1027,6 +1056,201 @@ func (repo repository) UpdateCompanyAccessList(companyID string, companyACL []st
 	return nil
 }
 
+// GetCompanyDomains returns the domains claimed by the company
+func (repo repository) GetCompanyDomains(companyID string) ([]DomainDBModel, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		ProjectionExpression: aws.String("company_id, company_domains"),
+	})
+	if err != nil {
+		log.Warnf("error fetching the domains of company: %s, error: %v", companyID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrCompanyDoesNotExist
+	}
+
+	dbCompanyModel := DBModel{}
+	err = dynamodbattribute.UnmarshalMap(result.Item, &dbCompanyModel)
+	if err != nil {
+		log.Warnf("error unmarshalling the domains of company: %s, error: %v", companyID, err)
+		return nil, err
+	}
+	return dbCompanyModel.CompanyDomains, nil
+}
+
+// UpdateCompanyDomains replaces the domains claimed by the company
+func (repo repository) UpdateCompanyDomains(companyID string, domains []DomainDBModel) error {
+	_, now := utils.CurrentTime()
+
+	domainsAttribute, err := dynamodbattribute.Marshal(domains)
+	if err != nil {
+		log.Warnf("error marshalling the domains of company: %s, error: %v", companyID, err)
+		return err
+	}
+	if len(domains) == 0 {
+		domainsAttribute = &dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{}}
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#D": aws.String("company_domains"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":d": domainsAttribute,
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		TableName: aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+		UpdateExpression: aws.String("SET #D = :d, #M = :m"),
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.Warnf("Error updating the domains of company: %s, error: %v", companyID, err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateCompanyHierarchy updates the parent company of the company and whether the CCLAs of the company cover the
+// employees of its subsidiaries - an empty parent company ID removes the parent company
+func (repo repository) UpdateCompanyHierarchy(companyID, parentCompanyID string, subsidiaryCCLACoverage bool) error {
+	_, now := utils.CurrentTime()
+
+	update := expression.Set(expression.Name("subsidiary_ccla_coverage"), expression.Value(subsidiaryCCLACoverage)).
+		Set(expression.Name("date_modified"), expression.Value(now))
+	if parentCompanyID == "" {
+		update = update.Remove(expression.Name("parent_company_id"))
+	} else {
+		update = update.Set(expression.Name("parent_company_id"), expression.Value(parentCompanyID))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		log.Warnf("error building the hierarchy update expression of company: %s, error: %v", companyID, err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		TableName:                 aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("Error updating the hierarchy of company: %s, error: %v", companyID, err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateCompanyAliases updates the aliases and former names of the company - empty lists remove the values
+func (repo repository) UpdateCompanyAliases(companyID string, aliases, formerNames []string) error {
+	_, now := utils.CurrentTime()
+
+	update := expression.Set(expression.Name("date_modified"), expression.Value(now))
+	if len(aliases) == 0 {
+		update = update.Remove(expression.Name("company_aliases"))
+	} else {
+		update = update.Set(expression.Name("company_aliases"), expression.Value(aliases))
+	}
+	if len(formerNames) == 0 {
+		update = update.Remove(expression.Name("company_former_names"))
+	} else {
+		update = update.Set(expression.Name("company_former_names"), expression.Value(formerNames))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		log.Warnf("error building the aliases update expression of company: %s, error: %v", companyID, err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		TableName:                 aws.String(repo.companyTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"company_id": {
+				S: aws.String(companyID),
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("Error updating the aliases of company: %s, error: %v", companyID, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSubsidiaryCompanies returns the companies with the specified parent company
+func (repo repository) GetSubsidiaryCompanies(parentCompanyID string) ([]*models.Company, error) {
+	filter := expression.Name("parent_company_id").Equal(expression.Value(parentCompanyID))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(buildCompanyProjection()).Build()
+	if err != nil {
+		log.Warnf("error building expression for the subsidiaries of company: %s, error: %v", parentCompanyID, err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.companyTableName),
+	}
+
+	var companies []*models.Company
+	for {
+		results, dbErr := repo.dynamoDBClient.Scan(scanInput)
+		if dbErr != nil {
+			log.Warnf("error retrieving the subsidiaries of company: %s, error: %v", parentCompanyID, dbErr)
+			return nil, dbErr
+		}
+
+		var dbCompanies []DBModel
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &dbCompanies)
+		if err != nil {
+			log.Warnf("error unmarshalling the subsidiaries of company: %s, error: %v", parentCompanyID, err)
+			return nil, err
+		}
+		for i := range dbCompanies {
+			companyModel, modelErr := dbCompanies[i].toModel()
+			if modelErr != nil {
+				return nil, modelErr
+			}
+			companies = append(companies, companyModel)
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return companies, nil
+}
+
 // CreateCompany creates a new company record
 func (repo repository) CreateCompany(in *models.Company) (*models.Company, error) {
 	companyID, err := uuid.NewV4()
@@ -41,6 +41,8 @@ type IService interface { // nolint
 	SearchCompanyByName(companyName string, nextKey string) (*models.Companies, error)
 	GetCompaniesByUserManager(userID string) (*models.Companies, error)
 	GetCompaniesByUserManagerWithInvites(userID string) (*models.CompaniesWithInvites, error)
+	GetCCLACoveringCompanies(companyModel *models.Company) ([]*models.Company, error)
+	GetCCLACoveredSubsidiaries(companyModel *models.Company, hasCCLA func(companyID string) bool) ([]*models.Company, error)
 
 	AddUserToCompanyAccessList(companyID, lfid string) error
 	GetCompanyInviteRequests(companyID string, status *string) ([]models.CompanyInviteUser, error)
@@ -78,6 +80,17 @@ func (s service) GetCompany(companyID string) (*models.Company, error) {
 	return s.repo.GetCompany(companyID)
 }
 
+// GetCCLACoveringCompanies returns the parent companies of the company, nearest first, whose CCLAs cover the
+// employees of their subsidiaries
+func (s service) GetCCLACoveringCompanies(companyModel *models.Company) ([]*models.Company, error) {
+	return GetCCLACoveringCompanies(s.repo, companyModel)
+}
+
+// GetCCLACoveredSubsidiaries returns the subsidiaries of the company whose employees are covered by the CCLA of the company
+func (s service) GetCCLACoveredSubsidiaries(companyModel *models.Company, hasCCLA func(companyID string) bool) ([]*models.Company, error) {
+	return GetCCLACoveredSubsidiaries(s.repo.GetSubsidiaryCompanies, companyModel, hasCCLA)
+}
+
 // SearchCompanyByName locates companies by the matching name and return any potential matches
 func (s service) SearchCompanyByName(companyName string, nextKey string) (*models.Companies, error) {
 	companies, err := s.repo.SearchCompanyByName(companyName, nextKey)
@@ -614,6 +627,9 @@ func (s service) CreateOrgFromExternalID(companySFID string) (*models.Company, e
 		}
 	}
 
+	// Warn about probable duplicates - the names are fuzzy matched, so the company is created anyway
+	s.warnProbableDuplicateCompanies(f, org.Name)
+
 	newComp := &models.Company{
 		CompanyACL:        []string{companyAdmin.LfUsername},
 		CompanyExternalID: org.ID,
@@ -633,6 +649,23 @@ func (s service) CreateOrgFromExternalID(companySFID string) (*models.Company, e
 	return comp, nil
 }
 
+// warnProbableDuplicateCompanies logs the existing companies whose name, aliases or former names are similar enough
+// to the company name to probably be the same company
+func (s service) warnProbableDuplicateCompanies(f logrus.Fields, companyName string) {
+	companies, err := s.repo.SearchCompanyByName(companyName, "")
+	if err != nil {
+		log.WithFields(f).Warnf("unable to search for probable duplicates of the company, error: %+v", err)
+		return
+	}
+	for _, companyModel := range companies.Companies {
+		if companyModel.RelevanceScore < utils.ProbableDuplicateCompanyScore {
+			continue
+		}
+		log.WithFields(f).Warnf("company: %s is a probable duplicate of the existing company: %s (%s) with relevance score: %.2f",
+			companyName, companyModel.CompanyName, companyModel.CompanyID, companyModel.RelevanceScore)
+	}
+}
+
 // getCompanyAdmin is helper function which queries org-service to get first company-admin
 func getCompanyAdmin(companySFID string) (*models.User, error) {
 	osc := organization_service.GetClient()
@@ -3,6 +3,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 )
 
 // EventData returns event data string which is used for event logging and containsPII field
@@ -31,6 +32,13 @@ type GithubProjectDeletedEventData struct{}
 
 type SignatureProjectInvalidatedEventData struct{}
 
+type SignatureApprovalListInvalidatedEventData struct {
+	SignatureID string
+	UserName    string
+	UserLFID    string
+	UserEmail   string
+}
+
 type UserCreatedEventData struct{}
 type UserDeletedEventData struct {
 	DeletedUserID string
@@ -59,6 +67,12 @@ type CompanyACLUserAddedEventData struct {
 	UserLFID string
 }
 
+type CompanyMergedEventData struct {
+	DuplicateCompanyIDs   []string
+	DuplicateCompanyNames []string
+	UpdatedRecordsCount   int
+}
+
 type CLATemplateCreatedEventData struct{}
 
 type GithubOrganizationAddedEventData struct {
@@ -75,6 +89,23 @@ type CCLAApprovalListRequestCreatedEventData struct {
 
 type CCLAApprovalListRequestApprovedEventData struct {
 	RequestID string
+	// AutoApproved is true when the request was approved by an auto-approval rule - the remaining fields record the
+	// rule evaluation
+	AutoApproved     bool
+	RulesEvaluated   int
+	MatchedRuleType  string
+	MatchedRuleValue string
+}
+
+type CCLAApprovalListAutoApprovalRulesUpdatedEventData struct {
+	Rules []string
+}
+
+type CCLAApprovalListRequestsBulkProcessedEventData struct {
+	Decision            string
+	ProcessedRequestIDs []string
+	FailedRequestIDs    []string
+	Message             string
 }
 
 type CCLAApprovalListRequestRejectedEventData struct {
@@ -206,9 +237,63 @@ type ClaManagerAccessRequestDeletedEventData struct {
 	RequestID string
 }
 
-type CLAGroupCreatedEventData struct{}
+type CLAGroupCreatedEventData struct {
+	SourceCLAGroupID   string
+	SourceCLAGroupName string
+}
 type CLAGroupUpdatedEventData struct{}
 type CLAGroupDeletedEventData struct{}
+type CLAGroupArchivedEventData struct {
+	ProjectSFIDs []string
+}
+type CLAGroupRestoredEventData struct {
+	ProjectSFIDs []string
+	Warnings     []string
+}
+
+type CLAGroupDocumentMajorVersionUpdatedEventData struct {
+	IclaMajorVersion int
+	CclaMajorVersion int
+	ResignPolicy     string
+	ResignDeadline   string
+}
+
+type CLAGroupProjectMovedEventData struct {
+	ProjectSFID              string
+	ProjectName              string
+	FromCLAGroupID           string
+	FromCLAGroupName         string
+	ToCLAGroupID             string
+	ToCLAGroupName           string
+	RepositoriesCount        int
+	GerritInstancesCount     int
+	UncoveredSignaturesCount int
+}
+
+type CLAGroupConfigAppliedEventData struct {
+	Changes []string
+}
+
+type SignatureResignRequiredEventData struct {
+	SignatureID           string
+	SignatureType         string
+	SignatureMajorVersion string
+	CurrentMajorVersion   int
+	ResignDeadline        string
+}
+
+type SignatureResignOverdueInvalidatedEventData struct {
+	SignatureID           string
+	SignatureType         string
+	SignatureMajorVersion string
+	ResignDeadline        string
+}
+
+type CorporateContributorDetachedEventData struct {
+	SignatureID            string
+	RemovedEmails          []string
+	RemovedGitHubUsernames []string
+}
 
 type ContributorNotifyCompanyAdminData struct {
 	AdminName  string
@@ -232,6 +317,13 @@ type AssignRoleScopeData struct {
 	Scope string
 }
 
+type ExpireRoleScopeData struct {
+	Role      string
+	Scope     string
+	GrantedOn string
+	TTLDays   int
+}
+
 func (ed *GithubRepositoryAddedEventData) GetEventString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user [%s] added github repository [%s] to project [%s]", args.userName, ed.RepositoryName, args.projectName)
 	return data, true
@@ -280,6 +372,12 @@ func (ed *CompanyACLUserAddedEventData) GetEventString(args *LogEventArgs) (stri
 	return data, true
 }
 
+func (ed *CompanyMergedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] merged the duplicate companies [%s] with ids [%s] into company: [%s] - %d records were updated",
+		args.userName, strings.Join(ed.DuplicateCompanyNames, ", "), strings.Join(ed.DuplicateCompanyIDs, ", "), args.companyName, ed.UpdatedRecordsCount)
+	return data, true
+}
+
 func (ed *CLATemplateCreatedEventData) GetEventString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user [%s] created PDF templates for project [%s]", args.userName, args.projectName)
 	return data, true
@@ -298,11 +396,34 @@ func (ed *GithubOrganizationDeletedEventData) GetEventString(args *LogEventArgs)
 }
 
 func (ed *CCLAApprovalListRequestApprovedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	if ed.AutoApproved {
+		data := fmt.Sprintf("CCLA Approval Request of user [%s] for project: [%s], company: [%s] was approved automatically - request id: %s, matched auto-approval rule %s: %s, rules evaluated: %d",
+			args.userName, args.projectName, args.companyName, ed.RequestID, ed.MatchedRuleType, ed.MatchedRuleValue, ed.RulesEvaluated)
+		return data, true
+	}
 	data := fmt.Sprintf("user [%s] approved a CCLA Approval Request for project: [%s], company: [%s] - request id: %s",
 		args.userName, args.projectName, args.companyName, ed.RequestID)
 	return data, true
 }
 
+func (ed *CCLAApprovalListAutoApprovalRulesUpdatedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] updated the CCLA Approval Request auto-approval rules for project: [%s], company: [%s] - rules: %s",
+		args.userName, args.projectName, args.companyName, strings.Join(ed.Rules, ", "))
+	return data, true
+}
+
+func (ed *CCLAApprovalListRequestsBulkProcessedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] applied the decision [%s] to %d CCLA Approval Requests for project: [%s], company: [%s] - request ids: [%s]",
+		args.userName, ed.Decision, len(ed.ProcessedRequestIDs), args.projectName, args.companyName, strings.Join(ed.ProcessedRequestIDs, ", "))
+	if len(ed.FailedRequestIDs) > 0 {
+		data = data + fmt.Sprintf(", failed request ids: [%s]", strings.Join(ed.FailedRequestIDs, ", "))
+	}
+	if ed.Message != "" {
+		data = data + fmt.Sprintf(", message: %s", ed.Message)
+	}
+	return data, true
+}
+
 func (ed *CCLAApprovalListRequestRejectedEventData) GetEventString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user [%s] rejected a CCLA Approval Request for project: [%s], company: [%s] - request id: %s",
 		args.userName, args.projectName, args.companyName, ed.RequestID)
@@ -424,11 +545,29 @@ func (ed *ClaManagerAccessRequestDeletedEventData) GetEventString(args *LogEvent
 }
 
 func (ed *CLAGroupCreatedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	if ed.SourceCLAGroupID != "" {
+		data := fmt.Sprintf("user [%s] has created a CLA Group [%s - %s] as a clone of the CLA Group [%s - %s]",
+			args.userName, args.projectName, args.ProjectID, ed.SourceCLAGroupName, ed.SourceCLAGroupID)
+		return data, true
+	}
 	data := fmt.Sprintf("user [%s] has created a CLA Group [%s - %s]",
 		args.userName, args.projectName, args.ProjectID)
 	return data, true
 }
 
+func (ed *CLAGroupProjectMovedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has moved project [%s - %s] from CLA Group [%s - %s] to CLA Group [%s - %s] with %d repositories and %d gerrit instances - %d signatures no longer cover the project",
+		args.userName, ed.ProjectName, ed.ProjectSFID, ed.FromCLAGroupName, ed.FromCLAGroupID, ed.ToCLAGroupName, ed.ToCLAGroupID,
+		ed.RepositoriesCount, ed.GerritInstancesCount, ed.UncoveredSignaturesCount)
+	return data, true
+}
+
+func (ed *CLAGroupConfigAppliedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has applied a configuration to CLA Group [%s - %s] with %d changes [%s]",
+		args.userName, args.projectName, args.ProjectID, len(ed.Changes), strings.Join(ed.Changes, ", "))
+	return data, true
+}
+
 func (ed *CLAGroupUpdatedEventData) GetEventString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user [%s] has updated CLA Group [%s - %s]",
 		args.userName, args.projectName, args.ProjectID)
@@ -440,6 +579,40 @@ func (ed *CLAGroupDeletedEventData) GetEventString(args *LogEventArgs) (string,
 	return data, true
 }
 
+func (ed *CLAGroupArchivedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has archived CLA Group [%s - %s] - the projects [%s] are no longer associated with the CLA Group",
+		args.userName, args.projectName, args.ProjectID, strings.Join(ed.ProjectSFIDs, ", "))
+	return data, true
+}
+
+func (ed *CLAGroupRestoredEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has restored CLA Group [%s - %s] with the projects [%s]",
+		args.userName, args.projectName, args.ProjectID, strings.Join(ed.ProjectSFIDs, ", "))
+	if len(ed.Warnings) > 0 {
+		data = fmt.Sprintf("%s - %s", data, strings.Join(ed.Warnings, ", "))
+	}
+	return data, true
+}
+
+func (ed *CLAGroupDocumentMajorVersionUpdatedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] has published a new major version of the CLA Group [%s - %s] documents - ICLA version [%d], CCLA version [%d], resign policy [%s], resign deadline [%s]",
+		args.userName, args.projectName, args.ProjectID, ed.IclaMajorVersion, ed.CclaMajorVersion, ed.ResignPolicy, ed.ResignDeadline)
+	return data, true
+}
+
+func (ed *SignatureResignRequiredEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] requested a re-sign of the %s signature [%s] for CLA Group [%s - %s] - signed version [%s], current version [%d], resign deadline [%s]",
+		args.userName, ed.SignatureType, ed.SignatureID, args.projectName, args.ProjectID,
+		ed.SignatureMajorVersion, ed.CurrentMajorVersion, ed.ResignDeadline)
+	return data, true
+}
+
+func (ed *SignatureResignOverdueInvalidatedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("the %s signature [%s] for CLA Group [%s - %s] signed against version [%s] was invalidated as it was not re-signed by the resign deadline [%s]",
+		ed.SignatureType, ed.SignatureID, args.projectName, args.ProjectID, ed.SignatureMajorVersion, ed.ResignDeadline)
+	return data, true
+}
+
 func (ed *GerritProjectDeletedEventData) GetEventString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("Gerrit Repository Deleted  due to CLA Group/Project: [%s] deletion",
 		args.projectName)
@@ -471,6 +644,18 @@ func (ed *SignatureProjectInvalidatedEventData) GetEventString(args *LogEventArg
 	return data, true
 }
 
+func (ed *SignatureApprovalListInvalidatedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] updated the approval list of company [%s] for project [%s] - employee signature [%s] of contributor [%s / %s / %s] invalidated (approved set to false)",
+		args.userName, args.companyName, args.projectName, ed.SignatureID, ed.UserName, ed.UserLFID, ed.UserEmail)
+	return data, true
+}
+
+func (ed *CorporateContributorDetachedEventData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("user [%s] detached from the corporate CLA of company [%s] for project [%s] - employee signature [%s] invalidated, removed emails %v and GitHub usernames %v from the approval list",
+		args.userName, args.companyName, args.projectName, ed.SignatureID, ed.RemovedEmails, ed.RemovedGitHubUsernames)
+	return data, true
+}
+
 func (ed *ContributorNotifyCompanyAdminData) GetEventString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("user [%s] notified company admin by email: %s %s for company [%s / %s]",
 		args.userName, ed.AdminName, ed.AdminEmail, args.companyName, args.CompanyID)
@@ -505,3 +690,9 @@ func (ed *AssignRoleScopeData) GetEventString(args *LogEventArgs) (string, bool)
 		ed.Scope, ed.Role, args.ExternalProjectID)
 	return data, true
 }
+
+func (ed *ExpireRoleScopeData) GetEventString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("scope [%s] with role [%s] of user [%s] for project [%s] expired - granted on %s, not used within %d days",
+		ed.Scope, ed.Role, args.LfUsername, args.ExternalProjectID, ed.GrantedOn, ed.TTLDays)
+	return data, true
+}
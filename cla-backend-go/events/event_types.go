@@ -50,10 +50,15 @@ const (
 	CompanyACLRequestApproved = "company_acl.request_approved"
 	CompanyACLRequestDenied   = "company_acl.request_denied"
 
+	CompanyMerged = "company.merged"
+
 	CCLAApprovalListRequestCreated  = "ccla_approval_list_request.created"
 	CCLAApprovalListRequestApproved = "ccla_approval_list_request.approved"
 	CCLAApprovalListRequestRejected = "ccla_approval_list_request.rejected"
 
+	CCLAApprovalListAutoApprovalRulesUpdated = "ccla_approval_list_request.auto_approval_rules_updated"
+	CCLAApprovalListRequestsBulkProcessed    = "ccla_approval_list_request.bulk_processed"
+
 	ApprovalListGithubOrganizationAdded   = "approval_list.github_organization_added"
 	ApprovalListGithubOrganizationDeleted = "approval_list.github_organization_deleted"
 
@@ -67,15 +72,30 @@ const (
 	ClaManagerCreated = "cla_manager.added"
 	ClaManagerDeleted = "cla_manager.deleted"
 
-	CLAGroupCreated = "cla_group.created"
-	CLAGroupUpdated = "cla_group.updated"
-	CLAGroupDeleted = "cla_group.deleted"
+	CLAGroupCreated  = "cla_group.created"
+	CLAGroupUpdated  = "cla_group.updated"
+	CLAGroupDeleted  = "cla_group.deleted"
+	CLAGroupArchived = "cla_group.archived"
+	CLAGroupRestored = "cla_group.restored"
+
+	CLAGroupDocumentMajorVersionUpdated = "cla_group.document_major_version_updated"
+
+	CLAGroupProjectMovedOut = "cla_group.project_moved_out"
+	CLAGroupProjectMovedIn  = "cla_group.project_moved_in"
+
+	CLAGroupConfigApplied = "cla_group.config_applied"
+
+	InvalidatedSignature    = "signature.invalidated"
+	SignatureResignRequired = "signature.resign_required"
+
+	SignatureResignOverdueInvalidated = "signature.resign_overdue_invalidated"
 
-	InvalidatedSignature = "signature.invalidated"
+	CorporateContributorDetached = "signature.corporate_contributor_detached"
 
 	ContributorNotifyCompanyAdminType = "contributor.notify_company_admin"
 	ContributorNotifyCLADesigneeType  = "contributor.notify_cla_designee"
 	ContributorAssignCLADesigneeType  = "contributor.assign_designee"
 	ConvertUserToContactType          = "lfx_user.convert_to_contact"
 	AssignUserRoleScopeType           = "lfx_org_service.assign_user_role_scope"
+	ExpireUserRoleScopeType           = "lfx_org_service.expire_user_role_scope"
 )
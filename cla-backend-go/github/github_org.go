@@ -32,3 +32,29 @@ func GetOrganization(organizationName string) (*github.Organization, error) {
 	}
 	return org, nil
 }
+
+// GetUserOrganizations returns the names of the public github organizations of the user
+func GetUserOrganizations(user string) ([]string, error) {
+	client := newGithubOauthClient()
+	opt := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var orgNames []string
+	for {
+		orgs, resp, err := client.Organizations.List(context.TODO(), user, opt)
+		if err != nil {
+			logging.Warnf("GetUserOrganizations %s failed. error = %s", user, err.Error())
+			return nil, err
+		}
+		for _, org := range orgs {
+			orgNames = append(orgNames, org.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return orgNames, nil
+}
@@ -0,0 +1,195 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/gofrs/uuid"
+)
+
+// Preference is the notification frequency chosen by a user
+type Preference struct {
+	UserEmail    string `dynamodbav:"user_email"`
+	Frequency    string `dynamodbav:"frequency"`
+	DateModified string `dynamodbav:"date_modified"`
+}
+
+// QueuedNotification is a notification waiting to be sent in a digest email
+type QueuedNotification struct {
+	NotificationID   string `dynamodbav:"notification_id"`
+	Frequency        string `dynamodbav:"frequency"`
+	RecipientName    string `dynamodbav:"recipient_name"`
+	RecipientAddress string `dynamodbav:"recipient_address"`
+	CompanyName      string `dynamodbav:"company_name"`
+	ClaGroupName     string `dynamodbav:"cla_group_name"`
+	Subject          string `dynamodbav:"subject"`
+	Summary          string `dynamodbav:"summary"`
+	DateCreated      string `dynamodbav:"date_created"`
+}
+
+// Repository stores the notification preferences and the queued notifications
+type Repository interface {
+	GetPreference(userEmail string) (*Preference, error)
+	UpdatePreference(userEmail, frequency string) (*Preference, error)
+	QueueNotification(notification *QueuedNotification) error
+	GetQueuedNotifications(frequency string) ([]*QueuedNotification, error)
+	DeleteQueuedNotification(notificationID string) error
+}
+
+type repository struct {
+	preferencesTableName string
+	queueTableName       string
+	dynamoDBClient       *dynamodb.DynamoDB
+}
+
+// NewRepository creates a new notifications repository instance
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return repository{
+		preferencesTableName: fmt.Sprintf("cla-%s-notification-preferences", stage),
+		queueTableName:       fmt.Sprintf("cla-%s-notification-queue", stage),
+		dynamoDBClient:       dynamodb.New(awsSession),
+	}
+}
+
+// GetPreference returns the notification preference of the user, nil is returned when the user has no preference
+func (repo repository) GetPreference(userEmail string) (*Preference, error) {
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(repo.preferencesTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"user_email": {S: aws.String(userEmail)},
+		},
+	})
+	if err != nil {
+		log.Warnf("unable to load the notification preference of user: %s, error: %v", userEmail, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, nil
+	}
+
+	var preference Preference
+	err = dynamodbattribute.UnmarshalMap(result.Item, &preference)
+	if err != nil {
+		log.Warnf("error unmarshalling the notification preference of user: %s, error: %v", userEmail, err)
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// UpdatePreference stores the notification frequency of the user
+func (repo repository) UpdatePreference(userEmail, frequency string) (*Preference, error) {
+	_, now := utils.CurrentTime()
+	preference := &Preference{
+		UserEmail:    userEmail,
+		Frequency:    frequency,
+		DateModified: now,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(preference)
+	if err != nil {
+		log.Warnf("unable to marshal the notification preference: %+v, error: %v", preference, err)
+		return nil, err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.preferencesTableName),
+	})
+	if err != nil {
+		log.Warnf("unable to update the notification preference of user: %s, error: %v", userEmail, err)
+		return nil, err
+	}
+	return preference, nil
+}
+
+// QueueNotification adds the notification to the queue of the next digest
+func (repo repository) QueueNotification(notification *QueuedNotification) error {
+	notificationID, err := uuid.NewV4()
+	if err != nil {
+		log.Warnf("unable to generate a UUID for the notification, error: %v", err)
+		return err
+	}
+	_, now := utils.CurrentTime()
+	notification.NotificationID = notificationID.String()
+	notification.DateCreated = now
+
+	av, err := dynamodbattribute.MarshalMap(notification)
+	if err != nil {
+		log.Warnf("unable to marshal the notification: %+v, error: %v", notification, err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.queueTableName),
+	})
+	if err != nil {
+		log.Warnf("unable to queue the notification for: %s, error: %v", notification.RecipientAddress, err)
+		return err
+	}
+	return nil
+}
+
+// GetQueuedNotifications returns the queued notifications of the digest frequency
+func (repo repository) GetQueuedNotifications(frequency string) ([]*QueuedNotification, error) {
+	filter := expression.Name("frequency").Equal(expression.Value(frequency))
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		log.Warnf("error building expression for the %s notifications scan, error: %v", frequency, err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.queueTableName),
+	}
+
+	var notifications []*QueuedNotification
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.Warnf("error scanning the %s notifications, error: %v", frequency, err)
+			return nil, err
+		}
+
+		var pageNotifications []*QueuedNotification
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &pageNotifications)
+		if err != nil {
+			log.Warnf("error unmarshalling the %s notifications, error: %v", frequency, err)
+			return nil, err
+		}
+		notifications = append(notifications, pageNotifications...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return notifications, nil
+}
+
+// DeleteQueuedNotification removes the notification from the queue
+func (repo repository) DeleteQueuedNotification(notificationID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.queueTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"notification_id": {S: aws.String(notificationID)},
+		},
+	})
+	if err != nil {
+		log.Warnf("unable to delete the queued notification: %s, error: %v", notificationID, err)
+		return err
+	}
+	return nil
+}
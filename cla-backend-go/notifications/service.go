@@ -0,0 +1,186 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// notification frequencies - immediate is the default when the user has no preference
+const (
+	FrequencyImmediate = "immediate"
+	FrequencyDaily     = "daily"
+	FrequencyWeekly    = "weekly"
+
+	// WeeklyDigestDay is the day on which the weekly digests are sent
+	WeeklyDigestDay = time.Monday
+)
+
+// ErrInvalidFrequency is returned when the notification frequency is not supported
+var ErrInvalidFrequency = errors.New("invalid notification frequency - expecting immediate, daily or weekly")
+
+// Service queues the CLA Manager notifications of the users who prefer a digest and sends the digests
+type Service interface {
+	GetPreference(userEmail string) (*Preference, error)
+	UpdatePreference(userEmail, frequency string) (*Preference, error)
+	SendNotification(notification *utils.NotificationEmail) error
+	SendDigests(now time.Time) error
+}
+
+type service struct {
+	repo Repository
+}
+
+// NewService creates a new notifications service
+func NewService(repo Repository) Service {
+	return service{
+		repo: repo,
+	}
+}
+
+// GetPreference returns the notification preference of the user
+func (s service) GetPreference(userEmail string) (*Preference, error) {
+	preference, err := s.repo.GetPreference(strings.ToLower(userEmail))
+	if err != nil {
+		return nil, err
+	}
+	if preference == nil {
+		return &Preference{
+			UserEmail: strings.ToLower(userEmail),
+			Frequency: FrequencyImmediate,
+		}, nil
+	}
+	return preference, nil
+}
+
+// UpdatePreference updates the notification frequency of the user
+func (s service) UpdatePreference(userEmail, frequency string) (*Preference, error) {
+	switch frequency {
+	case FrequencyImmediate, FrequencyDaily, FrequencyWeekly:
+	default:
+		return nil, ErrInvalidFrequency
+	}
+	return s.repo.UpdatePreference(strings.ToLower(userEmail), frequency)
+}
+
+// SendNotification sends the notification right away or queues it for the digest of the recipient. The notification
+// is sent right away when the preference can not be loaded or the notification can not be queued.
+func (s service) SendNotification(notification *utils.NotificationEmail) error {
+	preference, err := s.GetPreference(notification.RecipientAddress)
+	if err != nil {
+		log.Warnf("unable to load the notification preference of: %s - sending the email right away", notification.RecipientAddress)
+	} else if preference.Frequency != FrequencyImmediate {
+		queueErr := s.repo.QueueNotification(&QueuedNotification{
+			Frequency:        preference.Frequency,
+			RecipientName:    notification.RecipientName,
+			RecipientAddress: strings.ToLower(notification.RecipientAddress),
+			CompanyName:      notification.CompanyName,
+			ClaGroupName:     notification.ClaGroupName,
+			Subject:          notification.Subject,
+			Summary:          notification.Summary,
+		})
+		if queueErr == nil {
+			log.Debugf("queued notification with subject: %s for the %s digest of: %s",
+				notification.Subject, preference.Frequency, notification.RecipientAddress)
+			return nil
+		}
+		log.Warnf("unable to queue the notification for: %s - sending the email right away", notification.RecipientAddress)
+	}
+
+	return utils.SendEmail(notification.Subject, notification.Body, []string{notification.RecipientAddress})
+}
+
+// SendDigests sends the daily digests and, on the weekly digest day, the weekly digests. The notifications of a
+// recipient are removed from the queue once the digest was sent, a failed digest is sent again on the next run.
+func (s service) SendDigests(now time.Time) error {
+	for _, frequency := range DigestFrequencies(now) {
+		notifications, err := s.repo.GetQueuedNotifications(frequency)
+		if err != nil {
+			return err
+		}
+
+		byRecipient := make(map[string][]*QueuedNotification)
+		for _, notification := range notifications {
+			byRecipient[notification.RecipientAddress] = append(byRecipient[notification.RecipientAddress], notification)
+		}
+		log.Debugf("sending %s digests of %d notifications to %d recipients", frequency, len(notifications), len(byRecipient))
+
+		for recipientAddress, recipientNotifications := range byRecipient {
+			subject, body := BuildDigestEmail(frequency, recipientNotifications)
+			recipients := []string{recipientAddress}
+			err := utils.SendEmail(subject, body, recipients)
+			if err != nil {
+				log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+				continue
+			}
+			log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+
+			for _, notification := range recipientNotifications {
+				_ = s.repo.DeleteQueuedNotification(notification.NotificationID)
+			}
+		}
+	}
+	return nil
+}
+
+// DigestFrequencies returns the frequencies of the digests which are sent on the day of now
+func DigestFrequencies(now time.Time) []string {
+	frequencies := []string{FrequencyDaily}
+	if now.Weekday() == WeeklyDigestDay {
+		frequencies = append(frequencies, FrequencyWeekly)
+	}
+	return frequencies
+}
+
+// BuildDigestEmail returns the subject and body of the digest email - the notifications are grouped by company and
+// CLA Group
+func BuildDigestEmail(frequency string, notifications []*QueuedNotification) (string, string) {
+	sort.Slice(notifications, func(i, j int) bool {
+		if notifications[i].CompanyName != notifications[j].CompanyName {
+			return notifications[i].CompanyName < notifications[j].CompanyName
+		}
+		if notifications[i].ClaGroupName != notifications[j].ClaGroupName {
+			return notifications[i].ClaGroupName < notifications[j].ClaGroupName
+		}
+		return notifications[i].DateCreated < notifications[j].DateCreated
+	})
+
+	var activity strings.Builder
+	var companyName, claGroupName string
+	for i, notification := range notifications {
+		if i == 0 || notification.CompanyName != companyName || notification.ClaGroupName != claGroupName {
+			if i > 0 {
+				activity.WriteString("</ul>\n")
+			}
+			companyName, claGroupName = notification.CompanyName, notification.ClaGroupName
+			activity.WriteString(fmt.Sprintf("<p><b>%s - %s</b></p>\n<ul>\n", html.EscapeString(companyName), html.EscapeString(claGroupName)))
+		}
+		activity.WriteString(fmt.Sprintf("<li>%s: %s %s</li>\n", notification.DateCreated,
+			html.EscapeString(strings.TrimPrefix(notification.Subject, "EasyCLA: ")), notification.Summary))
+	}
+	activity.WriteString("</ul>\n")
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: Your %s CLA Manager Digest", frequency)
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA with the %s digest of the activity of the companies and CLA Groups you
+manage.</p>
+%s
+<p>To review the activity, please log into the <a href="%s" target="_blank">EasyCLA Corporate Console</a>.
+You can change how often you receive these notifications in your notification preferences.</p>
+%s
+%s`,
+		notifications[0].RecipientName, frequency, activity.String(), utils.GetCorporateURL(true),
+		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
+	return subject, body
+}
@@ -22,6 +22,15 @@ type DBProjectModel struct {
 	ProjectIndividualDocuments       []DBProjectDocumentModel `dynamodbav:"project_individual_documents"`
 	ProjectMemberDocuments           []DBProjectDocumentModel `dynamodbav:"project_member_documents"`
 	ProjectACL                       []string                 `dynamodbav:"project_acl"`
+	ProjectResignPolicy              string                   `dynamodbav:"project_resign_policy"`
+	ProjectResignDeadline            string                   `dynamodbav:"project_resign_deadline"`
+	ProjectResignRequestedBy         string                   `dynamodbav:"project_resign_requested_by"`
+	ProjectContributionPolicy        string                   `dynamodbav:"project_contribution_policy"`
+	ProjectContributionPolicyYears   int64                    `dynamodbav:"project_contribution_policy_years"`
+	ProjectArchived                  bool                     `dynamodbav:"project_archived"`
+	ProjectDateArchived              string                   `dynamodbav:"project_date_archived"`
+	ProjectArchivedBy                string                   `dynamodbav:"project_archived_by"`
+	ProjectArchivedProjectSFIDs      []string                 `dynamodbav:"project_archived_project_sfids"`
 }
 
 // DBProjectDocumentModel is a data model for the CLA Group Project documents
@@ -22,6 +22,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
@@ -34,6 +35,8 @@ import (
 var (
 	ErrProjectDoesNotExist = errors.New("project does not exist")
 	ErrProjectIDMissing    = errors.New("project id is missing")
+	ErrCLAGroupArchived    = errors.New("cla group is archived")
+	ErrCLAGroupNotArchived = errors.New("cla group is not archived")
 )
 
 // constants
@@ -56,6 +59,14 @@ type ProjectRepository interface { //nolint
 	GetClaGroupsByFoundationSFID(foundationSFID string, loadRepoDetails bool) (*models.Projects, error)
 	GetClaGroupByProjectSFID(projectSFID string, loadRepoDetails bool) (*models.Project, error)
 	UpdateRootCLAGroupRepositoriesCount(claGroupID string, diff int64) error
+
+	ArchiveCLAGroup(claGroupID string, projectSFIDs []string, archivedBy string) error
+	RestoreCLAGroup(claGroupID string) ([]string, error)
+	GetArchivedCLAGroup(claGroupID string) (*models.Project, error)
+	GetArchivedCLAGroups() ([]models.Project, error)
+
+	GetResignCLAGroups() ([]models.Project, error)
+	UpdateResignRequestedBy(claGroupID, requestedBy string) error
 }
 
 // NewRepository creates instance of project repository
@@ -113,6 +124,10 @@ func (repo *repo) CreateCLAGroup(projectModel *models.Project) (*models.Project,
 	addBooleanAttribute(input.Item, "project_icla_enabled", projectModel.ProjectICLAEnabled)
 	addBooleanAttribute(input.Item, "project_ccla_enabled", projectModel.ProjectCCLAEnabled)
 	addBooleanAttribute(input.Item, "project_ccla_requires_icla_signature", projectModel.ProjectCCLARequiresICLA)
+	addStringAttribute(input.Item, "project_resign_policy", projectModel.ProjectResignPolicy)
+	addStringAttribute(input.Item, "project_resign_deadline", projectModel.ProjectResignDeadline)
+	addStringAttribute(input.Item, "project_contribution_policy", projectModel.ProjectContributionPolicy)
+	addNumberAttribute(input.Item, "project_contribution_policy_years", projectModel.ProjectContributionPolicyYears)
 
 	// Empty documents for now - will add the template details later
 	addListAttribute(input.Item, "project_corporate_documents", []*dynamodb.AttributeValue{})
@@ -189,9 +204,17 @@ func (repo *repo) getCLAGroupByID(projectID string, loadCLAGroupDetails bool) (*
 	return repo.buildCLAGroupModel(dbModel, loadCLAGroupDetails), nil
 }
 
-// GetCLAGroupByID returns the project model associated for the specified projectID
+// GetCLAGroupByID returns the project model associated for the specified projectID - an archived CLA Group does not exist
 func (repo *repo) GetCLAGroupByID(projectID string, loadRepoDetails bool) (*models.Project, error) {
-	return repo.getCLAGroupByID(projectID, loadRepoDetails)
+	claGroup, err := repo.getCLAGroupByID(projectID, loadRepoDetails)
+	if err != nil {
+		return nil, err
+	}
+	if claGroup.ProjectArchived {
+		log.WithField("projectID", projectID).Debug("CLA Group is archived")
+		return nil, ErrProjectDoesNotExist
+	}
+	return claGroup, nil
 }
 
 // GetCLAGroupsByExternalID queries the database and returns a list of the projects
@@ -209,7 +232,7 @@ func (repo *repo) GetCLAGroupsByExternalID(params *project.GetProjectsByExternal
 	condition := expression.Key("project_external_id").Equal(expression.Value(params.ProjectSFID))
 
 	// Use the nice builder to create the expression
-	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(buildProjection()).Build()
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(notArchivedFilter()).WithProjection(buildProjection()).Build()
 	if err != nil {
 		log.WithFields(f).Warnf("error building expression for project scan, error: %v", err)
 	}
@@ -219,6 +242,7 @@ func (repo *repo) GetCLAGroupsByExternalID(params *project.GetProjectsByExternal
 		KeyConditionExpression:    expr.KeyCondition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
 		ProjectionExpression:      expr.Projection(),
 		TableName:                 aws.String(repo.claGroupTable),
 		IndexName:                 aws.String("external-project-index"),
@@ -306,7 +330,7 @@ func (repo *repo) GetClaGroupsByFoundationSFID(foundationSFID string, loadRepoDe
 	condition := expression.Key("foundation_sfid").Equal(expression.Value(foundationSFID))
 
 	// Use the nice builder to create the expression
-	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(buildProjection()).Build()
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(notArchivedFilter()).WithProjection(buildProjection()).Build()
 	if err != nil {
 		log.WithFields(f).Warnf("error building expression for project scan, error: %v", err)
 	}
@@ -316,6 +340,7 @@ func (repo *repo) GetClaGroupsByFoundationSFID(foundationSFID string, loadRepoDe
 		KeyConditionExpression:    expr.KeyCondition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
 		ProjectionExpression:      expr.Projection(),
 		TableName:                 aws.String(repo.claGroupTable),
 		IndexName:                 aws.String("foundation-sfid-project-name-index"),
@@ -368,7 +393,7 @@ func (repo *repo) GetClaGroupByProjectSFID(projectSFID string, loadRepoDetails b
 		return nil, err
 	}
 
-	return repo.getCLAGroupByID(claGroupProject.ClaGroupID, loadRepoDetails)
+	return repo.GetCLAGroupByID(claGroupProject.ClaGroupID, loadRepoDetails)
 }
 
 // GetCLAGroupByName returns the project model associated for the specified project name
@@ -383,7 +408,7 @@ func (repo *repo) GetCLAGroupByName(projectName string) (*models.Project, error)
 	condition := expression.Key("project_name_lower").Equal(expression.Value(strings.ToLower(projectName)))
 
 	// Use the builder to create the expression
-	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(buildProjection()).Build()
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(notArchivedFilter()).WithProjection(buildProjection()).Build()
 	if err != nil {
 		log.WithFields(f).Warnf("error building expression for CLAGroup query, projectName: %s, error: %v",
 			projectName, err)
@@ -395,6 +420,7 @@ func (repo *repo) GetCLAGroupByName(projectName string) (*models.Project, error)
 		KeyConditionExpression:    expr.KeyCondition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
 		ProjectionExpression:      expr.Projection(),
 		TableName:                 aws.String(repo.claGroupTable),
 		IndexName:                 aws.String("project-name-lower-search-index"),
@@ -441,7 +467,7 @@ func (repo *repo) GetExternalCLAGroup(projectExternalID string) (*models.Project
 	condition := expression.Key("project_external_id").Equal(expression.Value(projectExternalID))
 
 	// Use the builder to create the expression
-	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithProjection(buildProjection()).Build()
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(notArchivedFilter()).WithProjection(buildProjection()).Build()
 	if err != nil {
 		log.WithFields(f).Warnf("error building expression for CLAGroup query, projectExternalID: %s, error: %v",
 			projectExternalID, err)
@@ -453,6 +479,7 @@ func (repo *repo) GetExternalCLAGroup(projectExternalID string) (*models.Project
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
 		ProjectionExpression:      expr.Projection(),
 		TableName:                 aws.String(repo.claGroupTable),
 		IndexName:                 aws.String("external-project-index"),
@@ -499,7 +526,7 @@ func (repo *repo) GetCLAGroups(params *project.GetProjectsParams) (*models.Proje
 	log.WithFields(f).Debugf("searching project")
 
 	// Use the nice builder to create the expression
-	expr, err := expression.NewBuilder().WithProjection(buildProjection()).Build()
+	expr, err := expression.NewBuilder().WithFilter(notArchivedFilter()).WithProjection(buildProjection()).Build()
 	if err != nil {
 		log.WithFields(f).Warnf("error building expression for project scan, error: %v", err)
 	}
@@ -589,7 +616,8 @@ func (repo *repo) DeleteCLAGroup(projectID string) error {
 		"tableName":    repo.claGroupTable}
 	log.WithFields(f).Debugf("deleting CLA Group")
 
-	existingCLAGroup, getErr := repo.GetCLAGroupByID(projectID, DontLoadRepoDetails)
+	// the archived CLA Groups are deleted as well, by the purge of the archived CLA Groups
+	existingCLAGroup, getErr := repo.getCLAGroupByID(projectID, DontLoadRepoDetails)
 	if getErr != nil {
 		log.WithFields(f).Warnf("delete - error locating the CLA Group, error: %+v", getErr)
 		return getErr
@@ -661,6 +689,12 @@ func (repo *repo) UpdateCLAGroup(projectModel *models.Project) (*models.Project,
 		expressionAttributeValues[":low"] = &dynamodb.AttributeValue{S: aws.String(strings.ToLower(projectModel.ProjectName))}
 		updateExpression = updateExpression + " #LOW = :low, "
 	}
+	if projectModel.ProjectDescription != "" {
+		log.WithFields(f).Debugf("adding project_description: %s", projectModel.ProjectDescription)
+		expressionAttributeNames["#D"] = aws.String("project_description")
+		expressionAttributeValues[":d"] = &dynamodb.AttributeValue{S: aws.String(projectModel.ProjectDescription)}
+		updateExpression = updateExpression + " #D = :d, "
+	}
 	if projectModel.ProjectACL != nil && len(projectModel.ProjectACL) > 0 {
 		log.WithFields(f).Debugf("adding project_acl: %s", projectModel.ProjectACL)
 		expressionAttributeNames["#A"] = aws.String("project_acl")
@@ -683,11 +717,48 @@ func (repo *repo) UpdateCLAGroup(projectModel *models.Project) (*models.Project,
 	expressionAttributeValues[":ci"] = &dynamodb.AttributeValue{BOOL: aws.Bool(projectModel.ProjectCCLARequiresICLA)}
 	updateExpression = updateExpression + " #CI = :ci, "
 
+	// The resign policy and deadline are updated together - an update with a resign policy and no deadline removes
+	// the deadline, an update without a resign policy leaves both as they are
+	removeResignDeadline := false
+	if projectModel.ProjectResignPolicy != "" {
+		log.WithFields(f).Debugf("adding project_resign_policy: %s", projectModel.ProjectResignPolicy)
+		expressionAttributeNames["#RP"] = aws.String("project_resign_policy")
+		expressionAttributeValues[":rp"] = &dynamodb.AttributeValue{S: aws.String(projectModel.ProjectResignPolicy)}
+		updateExpression = updateExpression + " #RP = :rp, "
+
+		expressionAttributeNames["#RD"] = aws.String("project_resign_deadline")
+		if projectModel.ProjectResignDeadline != "" {
+			log.WithFields(f).Debugf("adding project_resign_deadline: %s", projectModel.ProjectResignDeadline)
+			expressionAttributeValues[":rd"] = &dynamodb.AttributeValue{S: aws.String(projectModel.ProjectResignDeadline)}
+			updateExpression = updateExpression + " #RD = :rd, "
+		} else {
+			log.WithFields(f).Debug("removing project_resign_deadline")
+			removeResignDeadline = true
+		}
+	}
+
+	// The contribution policy and years are updated together - an update without a contribution policy leaves both as
+	// they are, the policy is reset to the default by updating with the icla_or_ccla policy
+	if projectModel.ProjectContributionPolicy != "" {
+		log.WithFields(f).Debugf("adding project_contribution_policy: %s with years: %d",
+			projectModel.ProjectContributionPolicy, projectModel.ProjectContributionPolicyYears)
+		expressionAttributeNames["#CP"] = aws.String("project_contribution_policy")
+		expressionAttributeValues[":cp"] = &dynamodb.AttributeValue{S: aws.String(projectModel.ProjectContributionPolicy)}
+		updateExpression = updateExpression + " #CP = :cp, "
+		// the years only apply to the recent_signature policy - zero clears a previous value
+		expressionAttributeNames["#CPY"] = aws.String("project_contribution_policy_years")
+		expressionAttributeValues[":cpy"] = &dynamodb.AttributeValue{N: aws.String(strconv.FormatInt(projectModel.ProjectContributionPolicyYears, 10))}
+		updateExpression = updateExpression + " #CPY = :cpy, "
+	}
+
 	_, currentTimeString := utils.CurrentTime()
 	log.WithFields(f).Debugf("adding date_modified: %s", currentTimeString)
 	expressionAttributeNames["#M"] = aws.String("date_modified")
 	expressionAttributeValues[":m"] = &dynamodb.AttributeValue{S: aws.String(currentTimeString)}
 	updateExpression = updateExpression + " #M = :m "
+	if removeResignDeadline {
+		updateExpression = updateExpression + "REMOVE #RD "
+	}
 
 	// Assemble the query input parameters
 	updateInput := &dynamodb.UpdateItemInput{
@@ -734,6 +805,239 @@ func (repo *repo) UpdateRootCLAGroupRepositoriesCount(claGroupID string, diff in
 	return err
 }
 
+// ArchiveCLAGroup marks the CLA Group as archived and records the projects associated with the CLA Group, so the
+// associations can be restored
+func (repo *repo) ArchiveCLAGroup(claGroupID string, projectSFIDs []string, archivedBy string) error {
+	f := logrus.Fields{
+		"functionName": "ArchiveCLAGroup",
+		"claGroupID":   claGroupID,
+		"archivedBy":   archivedBy,
+		"tableName":    repo.claGroupTable}
+
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name("project_archived"), expression.Value(true)).
+		Set(expression.Name("project_date_archived"), expression.Value(now)).
+		Set(expression.Name("project_archived_by"), expression.Value(archivedBy)).
+		Set(expression.Name("date_modified"), expression.Value(now))
+	if len(projectSFIDs) > 0 {
+		update = update.Set(expression.Name("project_archived_project_sfids"), expression.Value(projectSFIDs))
+	}
+	condition := expression.AttributeExists(expression.Name("project_id")).And(notArchivedFilter())
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for the CLA Group archive, error: %v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.claGroupTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(claGroupID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Warn("unable to archive the CLA Group - the CLA Group does not exist or is already archived")
+			return ErrCLAGroupArchived
+		}
+		log.WithFields(f).Warnf("unable to archive the CLA Group, error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// RestoreCLAGroup clears the archived state of the CLA Group and returns the projects which were associated with the
+// CLA Group when it was archived
+func (repo *repo) RestoreCLAGroup(claGroupID string) ([]string, error) {
+	f := logrus.Fields{
+		"functionName": "RestoreCLAGroup",
+		"claGroupID":   claGroupID,
+		"tableName":    repo.claGroupTable}
+
+	_, now := utils.CurrentTime()
+	update := expression.Remove(expression.Name("project_archived")).
+		Remove(expression.Name("project_date_archived")).
+		Remove(expression.Name("project_archived_by")).
+		Remove(expression.Name("project_archived_project_sfids")).
+		Set(expression.Name("date_modified"), expression.Value(now))
+	condition := expression.Name("project_archived").Equal(expression.Value(true))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for the CLA Group restore, error: %v", err)
+		return nil, err
+	}
+
+	result, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.claGroupTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(claGroupID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ReturnValues:              aws.String(dynamodb.ReturnValueUpdatedOld),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).Warn("unable to restore the CLA Group - the CLA Group is not archived")
+			return nil, ErrCLAGroupNotArchived
+		}
+		log.WithFields(f).Warnf("unable to restore the CLA Group, error: %v", err)
+		return nil, err
+	}
+
+	var old DBProjectModel
+	err = dynamodbattribute.UnmarshalMap(result.Attributes, &old)
+	if err != nil {
+		log.WithFields(f).Warnf("error unmarshalling the archived CLA Group attributes, error: %v", err)
+		return nil, err
+	}
+	return old.ProjectArchivedProjectSFIDs, nil
+}
+
+// GetArchivedCLAGroup returns the archived CLA Group - returns ErrCLAGroupNotArchived if the CLA Group is not archived
+func (repo *repo) GetArchivedCLAGroup(claGroupID string) (*models.Project, error) {
+	claGroup, err := repo.getCLAGroupByID(claGroupID, DontLoadRepoDetails)
+	if err != nil {
+		return nil, err
+	}
+	if !claGroup.ProjectArchived {
+		return nil, ErrCLAGroupNotArchived
+	}
+	return claGroup, nil
+}
+
+// GetArchivedCLAGroups returns the archived CLA Groups
+func (repo *repo) GetArchivedCLAGroups() ([]models.Project, error) {
+	f := logrus.Fields{
+		"functionName": "GetArchivedCLAGroups",
+		"tableName":    repo.claGroupTable}
+
+	filter := expression.Name("project_archived").Equal(expression.Value(true))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for archived CLA Groups scan, error: %v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.claGroupTable),
+	}
+
+	var projects []models.Project
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error scanning archived CLA Groups, error: %v", err)
+			return nil, err
+		}
+
+		projectList, modelErr := repo.buildCLAGroupModels(results.Items, DontLoadRepoDetails)
+		if modelErr != nil {
+			log.WithFields(f).Warnf("error converting project DB model to response model, error: %v", modelErr)
+			return nil, modelErr
+		}
+		projects = append(projects, projectList...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return projects, nil
+}
+
+// GetResignCLAGroups returns the CLA Groups which are not archived and require a re-sign of the outdated signatures
+func (repo *repo) GetResignCLAGroups() ([]models.Project, error) {
+	f := logrus.Fields{
+		"functionName": "GetResignCLAGroups",
+		"tableName":    repo.claGroupTable}
+
+	filter := expression.Name("project_resign_policy").Equal(expression.Value("resign")).And(notArchivedFilter())
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(buildProjection()).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for resign CLA Groups scan, error: %v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.claGroupTable),
+	}
+
+	var projects []models.Project
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).Warnf("error scanning resign CLA Groups, error: %v", err)
+			return nil, err
+		}
+
+		projectList, modelErr := repo.buildCLAGroupModels(results.Items, DontLoadRepoDetails)
+		if modelErr != nil {
+			log.WithFields(f).Warnf("error converting project DB model to response model, error: %v", modelErr)
+			return nil, modelErr
+		}
+		projects = append(projects, projectList...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return projects, nil
+}
+
+// UpdateResignRequestedBy records the user who published a new document major version - the re-sign notifications
+// of the CLA Group are pending until the value is cleared with an empty user name
+func (repo *repo) UpdateResignRequestedBy(claGroupID, requestedBy string) error {
+	f := logrus.Fields{
+		"functionName": "UpdateResignRequestedBy",
+		"claGroupID":   claGroupID,
+		"requestedBy":  requestedBy,
+		"tableName":    repo.claGroupTable}
+
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name("date_modified"), expression.Value(now))
+	if requestedBy != "" {
+		update = update.Set(expression.Name("project_resign_requested_by"), expression.Value(requestedBy))
+	} else {
+		update = update.Remove(expression.Name("project_resign_requested_by"))
+	}
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for the resign requested by update, error: %v", err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.claGroupTable),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {S: aws.String(claGroupID)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+	})
+	if err != nil {
+		log.WithFields(f).Warnf("unable to update the resign requested by, error: %v", err)
+		return err
+	}
+	return nil
+}
+
 // buildCLAGroupModels converts the database response model into an API response data model
 func (repo *repo) buildCLAGroupModels(results []map[string]*dynamodb.AttributeValue, loadRepoDetails bool) ([]models.Project, error) {
 	var projects []models.Project
@@ -809,24 +1113,32 @@ func (repo *repo) buildCLAGroupModel(dbModel DBProjectModel, loadRepoDetails boo
 		}
 	}
 	return &models.Project{
-		ProjectID:                    dbModel.ProjectID,
-		FoundationSFID:               dbModel.FoundationSFID,
-		RootProjectRepositoriesCount: dbModel.RootProjectRepositoriesCount,
-		ProjectDescription:           dbModel.ProjectDescription,
-		ProjectExternalID:            dbModel.ProjectExternalID,
-		ProjectName:                  dbModel.ProjectName,
-		ProjectACL:                   dbModel.ProjectACL,
-		ProjectCCLAEnabled:           dbModel.ProjectCclaEnabled,
-		ProjectICLAEnabled:           dbModel.ProjectIclaEnabled,
-		ProjectCCLARequiresICLA:      dbModel.ProjectCclaRequiresIclaSignature,
-		ProjectCorporateDocuments:    repo.buildCLAGroupDocumentModels(dbModel.ProjectCorporateDocuments),
-		ProjectIndividualDocuments:   repo.buildCLAGroupDocumentModels(dbModel.ProjectIndividualDocuments),
-		ProjectMemberDocuments:       repo.buildCLAGroupDocumentModels(dbModel.ProjectMemberDocuments),
-		GithubRepositories:           ghOrgs,
-		Gerrits:                      gerrits,
-		DateCreated:                  dbModel.DateCreated,
-		DateModified:                 dbModel.DateModified,
-		Version:                      dbModel.Version,
+		ProjectID:                      dbModel.ProjectID,
+		FoundationSFID:                 dbModel.FoundationSFID,
+		RootProjectRepositoriesCount:   dbModel.RootProjectRepositoriesCount,
+		ProjectDescription:             dbModel.ProjectDescription,
+		ProjectExternalID:              dbModel.ProjectExternalID,
+		ProjectName:                    dbModel.ProjectName,
+		ProjectACL:                     dbModel.ProjectACL,
+		ProjectCCLAEnabled:             dbModel.ProjectCclaEnabled,
+		ProjectICLAEnabled:             dbModel.ProjectIclaEnabled,
+		ProjectCCLARequiresICLA:        dbModel.ProjectCclaRequiresIclaSignature,
+		ProjectResignPolicy:            dbModel.ProjectResignPolicy,
+		ProjectResignDeadline:          dbModel.ProjectResignDeadline,
+		ProjectResignRequestedBy:       dbModel.ProjectResignRequestedBy,
+		ProjectContributionPolicy:      dbModel.ProjectContributionPolicy,
+		ProjectContributionPolicyYears: dbModel.ProjectContributionPolicyYears,
+		ProjectArchived:                dbModel.ProjectArchived,
+		ProjectDateArchived:            dbModel.ProjectDateArchived,
+		ProjectArchivedBy:              dbModel.ProjectArchivedBy,
+		ProjectCorporateDocuments:      repo.buildCLAGroupDocumentModels(dbModel.ProjectCorporateDocuments),
+		ProjectIndividualDocuments:     repo.buildCLAGroupDocumentModels(dbModel.ProjectIndividualDocuments),
+		ProjectMemberDocuments:         repo.buildCLAGroupDocumentModels(dbModel.ProjectMemberDocuments),
+		GithubRepositories:             ghOrgs,
+		Gerrits:                        gerrits,
+		DateCreated:                    dbModel.DateCreated,
+		DateModified:                   dbModel.DateModified,
+		Version:                        dbModel.Version,
 	}
 }
 
@@ -875,12 +1187,26 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("project_corporate_documents"),
 		expression.Name("project_individual_documents"),
 		expression.Name("project_member_documents"),
+		expression.Name("project_resign_policy"),
+		expression.Name("project_resign_deadline"),
+		expression.Name("project_resign_requested_by"),
+		expression.Name("project_contribution_policy"),
+		expression.Name("project_contribution_policy_years"),
+		expression.Name("project_archived"),
+		expression.Name("project_date_archived"),
+		expression.Name("project_archived_by"),
 		expression.Name("date_created"),
 		expression.Name("date_modified"),
 		expression.Name("version"),
 	)
 }
 
+// notArchivedFilter matches the CLA Groups which are not archived
+func notArchivedFilter() expression.ConditionBuilder {
+	return expression.Name("project_archived").AttributeNotExists().
+		Or(expression.Name("project_archived").Equal(expression.Value(false)))
+}
+
 // addStringAttribute adds a new string attribute to the existing map
 func addStringAttribute(item map[string]*dynamodb.AttributeValue, key string, value string) {
 	if value != "" {
@@ -888,6 +1214,13 @@ func addStringAttribute(item map[string]*dynamodb.AttributeValue, key string, va
 	}
 }
 
+// addNumberAttribute adds a new number attribute to the existing map
+func addNumberAttribute(item map[string]*dynamodb.AttributeValue, key string, value int64) {
+	if value != 0 {
+		item[key] = &dynamodb.AttributeValue{N: aws.String(strconv.FormatInt(value, 10))}
+	}
+}
+
 // addBooleanAttribute adds a new boolean attribute to the existing map
 func addBooleanAttribute(item map[string]*dynamodb.AttributeValue, key string, value bool) {
 	item[key] = &dynamodb.AttributeValue{BOOL: aws.Bool(value)}
@@ -4,6 +4,7 @@
 package project
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -14,6 +15,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/restapi/operations/project"
 	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 )
 
 // Service interface defines the project service methods/functions
@@ -27,6 +29,8 @@ type Service interface {
 	DeleteCLAGroup(projectID string) error
 	UpdateCLAGroup(projectModel *models.Project) (*models.Project, error)
 	GetClaGroupsByFoundationSFID(foundationSFID string, loadRepoDetails bool) (*models.Projects, error)
+	GetResignCLAGroups() ([]models.Project, error)
+	UpdateResignRequestedBy(claGroupID, requestedBy string) error
 }
 
 // service
@@ -146,8 +150,22 @@ func (s service) DeleteCLAGroup(projectID string) error {
 	return s.repo.DeleteCLAGroup(projectID)
 }
 
-// UpdateCLAGroup service method
+// UpdateCLAGroup service method - the contribution policy of the update, or the stored policy when the update has
+// none, must be valid with the signature types enabled by the update
 func (s service) UpdateCLAGroup(projectModel *models.Project) (*models.Project, error) {
+	policy, years := projectModel.ProjectContributionPolicy, projectModel.ProjectContributionPolicyYears
+	if policy == "" {
+		existingCLAGroup, err := s.repo.GetCLAGroupByID(projectModel.ProjectID, DontLoadRepoDetails)
+		if err != nil {
+			return nil, err
+		}
+		policy, years = existingCLAGroup.ProjectContributionPolicy, existingCLAGroup.ProjectContributionPolicyYears
+	}
+	err := signatures.ValidateContributionPolicy(policy, years, projectModel.ProjectICLAEnabled, projectModel.ProjectCCLAEnabled)
+	if err != nil {
+		return nil, fmt.Errorf("bad request: %s", err)
+	}
+
 	return s.repo.UpdateCLAGroup(projectModel)
 }
 
@@ -155,3 +173,13 @@ func (s service) UpdateCLAGroup(projectModel *models.Project) (*models.Project,
 func (s service) GetClaGroupsByFoundationSFID(foundationSFID string, loadRepoDetails bool) (*models.Projects, error) {
 	return s.repo.GetClaGroupsByFoundationSFID(foundationSFID, loadRepoDetails)
 }
+
+// GetResignCLAGroups service method
+func (s service) GetResignCLAGroups() ([]models.Project, error) {
+	return s.repo.GetResignCLAGroups()
+}
+
+// UpdateResignRequestedBy service method
+func (s service) UpdateResignRequestedBy(claGroupID, requestedBy string) error {
+	return s.repo.UpdateResignRequestedBy(claGroupID, requestedBy)
+}
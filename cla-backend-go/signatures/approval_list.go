@@ -0,0 +1,243 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// approval list coverage reasons - which approval list of a corporate signature covers a contributor
+const (
+	CoverageReasonEmail          = "ccla_email"
+	CoverageReasonDomain         = "ccla_domain"
+	CoverageReasonGitHubUsername = "ccla_github_username"
+	CoverageReasonGitHubOrg      = "ccla_github_org"
+)
+
+// CoverageReasonICLA is the coverage reason of a contributor with an individual signature
+const CoverageReasonICLA = "icla"
+
+// CoverageReasonECLA is the coverage reason of a contributor with an employee acknowledgement of a company
+const CoverageReasonECLA = "ecla"
+
+// CoverageReasonParentCCLA is the coverage reason of an employee of a subsidiary without a CCLA whose parent company
+// covers the employees of its subsidiaries with its CCLA
+const CoverageReasonParentCCLA = "parent_ccla"
+
+// CoverageCheckGitHubOrgs is the incomplete check of a coverage explanation when the GitHub organizations of the
+// contributor could not be loaded - the GitHub organization approval lists were not checked
+const CoverageCheckGitHubOrgs = "github_orgs"
+
+// ApprovalListMatch is an approval list entry of a corporate signature which matches the contributor
+type ApprovalListMatch struct {
+	Reason string
+	Value  string
+}
+
+// GetApprovalListCoverage returns the reason the contributor is covered by the approval lists of the corporate
+// signature, or an empty string when none of the approval list entries match the contributor
+func GetApprovalListCoverage(sig *models.Signature, emails []string, githubUsername string, githubOrgs []string) string {
+	matches := GetApprovalListMatches(sig, emails, githubUsername, githubOrgs)
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[0].Reason
+}
+
+// GetApprovalListMatches returns all the approval list entries of the corporate signature which match the
+// contributor - email entries first, followed by the domain, GitHub username and GitHub organization entries
+func GetApprovalListMatches(sig *models.Signature, emails []string, githubUsername string, githubOrgs []string) []ApprovalListMatch {
+	var matches []ApprovalListMatch
+	for _, email := range emails {
+		if ContainsFold(sig.EmailApprovalList, email) {
+			matches = append(matches, ApprovalListMatch{Reason: CoverageReasonEmail, Value: email})
+		}
+	}
+
+	for _, email := range emails {
+		if _, covered := utils.MatchDomainRules(sig.DomainApprovalList, emailDomain(email)); covered {
+			matches = append(matches, ApprovalListMatch{Reason: CoverageReasonDomain, Value: email})
+		}
+	}
+
+	if ContainsFold(sig.GithubUsernameApprovalList, githubUsername) {
+		matches = append(matches, ApprovalListMatch{Reason: CoverageReasonGitHubUsername, Value: githubUsername})
+	}
+
+	for _, githubOrg := range githubOrgs {
+		if ContainsFold(sig.GithubOrgApprovalList, githubOrg) {
+			matches = append(matches, ApprovalListMatch{Reason: CoverageReasonGitHubOrg, Value: githubOrg})
+		}
+	}
+
+	return matches
+}
+
+// GetUserEmails returns the list of unique email addresses of the user
+func GetUserEmails(userModel *models.User) []string {
+	emailSet := map[string]struct{}{}
+	var emails []string
+	for _, email := range append([]string{userModel.LfEmail}, userModel.Emails...) {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		if _, ok := emailSet[strings.ToLower(email)]; ok {
+			continue
+		}
+		emailSet[strings.ToLower(email)] = struct{}{}
+		emails = append(emails, email)
+	}
+	return emails
+}
+
+// emailDomain returns the domain portion of the email address
+func emailDomain(email string) string {
+	index := strings.LastIndex(email, "@")
+	if index < 0 {
+		return ""
+	}
+	return email[index+1:]
+}
+
+// ContainsFold returns true if the list contains the value using a case insensitive comparison, ignoring the
+// surrounding white space
+func ContainsFold(list []string, value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	for _, entry := range list {
+		if strings.EqualFold(strings.TrimSpace(entry), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidDomainApprovalListRemovals returns the removed domain approval list entries which are not valid rules and
+// don't exactly match an existing entry - an existing entry can always be removed, even when it was saved before
+// the rules were validated
+func InvalidDomainApprovalListRemovals(existingList, removeEntries []string) []string {
+	var invalidEntries []string
+	for _, entry := range removeEntries {
+		if _, valid := utils.ValidDomainRule(entry); valid {
+			continue
+		}
+		// the repository removes the entries which exactly match the existing entries without the surrounding spaces
+		existing := false
+		for _, existingEntry := range existingList {
+			if strings.TrimSpace(existingEntry) == entry {
+				existing = true
+				break
+			}
+		}
+		if !existing {
+			invalidEntries = append(invalidEntries, entry)
+		}
+	}
+	return invalidEntries
+}
+
+// ApplyApprovalListChanges returns a copy of the corporate signature with the approval list changes applied the same
+// way the repository applies them - the signature itself is left unchanged
+func ApplyApprovalListChanges(sig *models.Signature, params *models.ApprovalList) *models.Signature {
+	updatedSig := *sig
+	updatedSig.EmailApprovalList = applyApprovalListEntries(sig.EmailApprovalList, params.AddEmailApprovalList, params.RemoveEmailApprovalList)
+	updatedSig.DomainApprovalList = applyApprovalListEntries(sig.DomainApprovalList, params.AddDomainApprovalList, params.RemoveDomainApprovalList)
+	updatedSig.GithubUsernameApprovalList = applyApprovalListEntries(sig.GithubUsernameApprovalList, params.AddGithubUsernameApprovalList, params.RemoveGithubUsernameApprovalList)
+	updatedSig.GithubOrgApprovalList = applyApprovalListEntries(sig.GithubOrgApprovalList, params.AddGithubOrgApprovalList, params.RemoveGithubOrgApprovalList)
+	return &updatedSig
+}
+
+// applyApprovalListEntries returns the approval list with the entries added and removed
+func applyApprovalListEntries(existingList, addEntries, removeEntries []string) []string {
+	var updatedList []string
+	for _, value := range append(append([]string{}, existingList...), addEntries...) {
+		if !utils.StringInSlice(value, updatedList) {
+			updatedList = append(updatedList, strings.TrimSpace(value))
+		}
+	}
+	return utils.RemoveDuplicates(utils.RemoveItemsFromList(updatedList, removeEntries))
+}
+
+// DetachApprovalListChanges returns the approval list changes which remove the email addresses and the GitHub username
+// of the contributor from the approval lists of the CCLA - the domain and GitHub organization entries cover other
+// contributors and are left as is
+func DetachApprovalListChanges(cclaSig *models.Signature, userModel *models.User) *models.ApprovalList {
+	approvalListChanges := &models.ApprovalList{}
+	for _, email := range GetUserEmails(userModel) {
+		for _, entry := range cclaSig.EmailApprovalList {
+			if strings.EqualFold(strings.TrimSpace(entry), email) {
+				approvalListChanges.RemoveEmailApprovalList = append(approvalListChanges.RemoveEmailApprovalList, entry)
+			}
+		}
+	}
+	for _, entry := range cclaSig.GithubUsernameApprovalList {
+		if userModel.GithubUsername != "" && strings.EqualFold(strings.TrimSpace(entry), userModel.GithubUsername) {
+			approvalListChanges.RemoveGithubUsernameApprovalList = append(approvalListChanges.RemoveGithubUsernameApprovalList, entry)
+		}
+	}
+	return approvalListChanges
+}
+
+// BuildCoverageReasons returns the coverage reasons and the contribution policy facts of a contributor identity - the
+// ICLA and the signed and approved employee acknowledgements of the CLA Group from the signatures of the user record,
+// if any, and the approval list entries of each CCLA which match the email addresses, GitHub username or GitHub
+// organizations of the identity
+func BuildCoverageReasons(claGroupID string, userModel *models.User, iclaSig *models.Signature, userSignatures, cclaSignatures []*models.Signature,
+	emails []string, githubUsername string, githubOrgs []string) ([]*models.CoverageReason, *ContributionPolicyFacts) {
+	reasons := []*models.CoverageReason{}
+	facts := &ContributionPolicyFacts{}
+
+	if userModel != nil {
+		facts.UserCompanyID = userModel.CompanyID
+		if iclaSig != nil {
+			facts.IndividualSignature = iclaSig
+			reasons = append(reasons, &models.CoverageReason{
+				Reason:        CoverageReasonICLA,
+				SignatureID:   iclaSig.SignatureID,
+				SignatureType: ICLA,
+				MatchedValue:  userModel.UserID,
+			})
+		}
+		for _, userSig := range userSignatures {
+			if userSig.ProjectID != claGroupID || userSig.SignatureUserCompanyID == "" ||
+				!userSig.SignatureSigned || !userSig.SignatureApproved {
+				continue
+			}
+			facts.EmployeeSignatures = append(facts.EmployeeSignatures, userSig)
+			reasons = append(reasons, &models.CoverageReason{
+				Reason:        CoverageReasonECLA,
+				SignatureID:   userSig.SignatureID,
+				SignatureType: ECLA,
+				CompanyID:     userSig.SignatureUserCompanyID,
+				CompanyName:   userSig.CompanyName,
+				MatchedValue:  userModel.UserID,
+			})
+		}
+	}
+
+	for _, cclaSig := range cclaSignatures {
+		matches := GetApprovalListMatches(cclaSig, emails, githubUsername, githubOrgs)
+		if len(matches) > 0 {
+			facts.CorporateSignatures = append(facts.CorporateSignatures, cclaSig)
+		}
+		for _, match := range matches {
+			reasons = append(reasons, &models.CoverageReason{
+				Reason:        match.Reason,
+				SignatureID:   cclaSig.SignatureID,
+				SignatureType: CCLA,
+				CompanyID:     cclaSig.SignatureReferenceID,
+				CompanyName:   cclaSig.CompanyName,
+				MatchedValue:  match.Value,
+			})
+		}
+	}
+
+	return reasons, facts
+}
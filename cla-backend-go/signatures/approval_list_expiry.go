@@ -0,0 +1,251 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// approval list entry types which support an expiry date
+const (
+	ApprovalListEntryEmail          = "email"
+	ApprovalListEntryDomain         = "domain"
+	ApprovalListEntryGitHubUsername = "github_username"
+)
+
+// ApprovalListExpiryReminderPeriod is how long before the expiry date the CLA Managers are notified
+const ApprovalListExpiryReminderPeriod = 7 * 24 * time.Hour
+
+// the user recorded in the events and emails of the approval list updates made by EasyCLA itself
+const (
+	easyCLASystemUserName   = "EasyCLA"
+	easyCLASystemLFUsername = "easycla_system_user"
+)
+
+// GetApprovalListExpirationSignatures returns the CCLA signatures with at least one approval list entry which expires
+func (s service) GetApprovalListExpirationSignatures() ([]*models.Signature, error) {
+	return s.repo.GetApprovalListExpirationSignatures()
+}
+
+// ProcessApprovalListExpirations notifies the CLA Managers of the approval list entries which expire within the
+// reminder period and removes the expired entries using the regular approval list update
+func (s service) ProcessApprovalListExpirations(projectModel *models.Project, companyModel *models.Company, sig *models.Signature, now time.Time) error {
+	f := logrus.Fields{
+		"functionName": "ProcessApprovalListExpirations",
+		"signatureID":  sig.SignatureID,
+		"claGroupID":   projectModel.ProjectID,
+		"claGroupName": projectModel.ProjectName,
+		"companyID":    companyModel.CompanyID,
+		"companyName":  companyModel.CompanyName,
+	}
+
+	expired, expiring := SelectApprovalListExpirations(sig.ApprovalListExpirations, now)
+	if len(expiring) == 0 && !hasApprovalListRemovals(expired) {
+		return nil
+	}
+
+	pageSize := int64(1)
+	signed, approved := true, true
+	cclaSig, err := s.GetProjectCompanySignature(companyModel.CompanyID, projectModel.ProjectID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the corporate signature, error: %+v", err)
+		return err
+	}
+	if cclaSig == nil {
+		log.WithFields(f).Warn("unable to locate the signed and approved corporate signature - skipping")
+		return nil
+	}
+
+	if len(expiring) > 0 {
+		for _, claManager := range cclaSig.SignatureACL {
+			sendApprovalListExpiryReminderEmail(companyModel, projectModel, claManager.Username, getBestEmail(claManager), expiring)
+		}
+		for _, expiration := range expiring {
+			expiration.ReminderSent = true
+		}
+		err = s.repo.UpdateApprovalListExpirations(sig.SignatureID, sig.ApprovalListExpirations)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to mark the approval list expiry reminders as sent, error: %+v", err)
+			return err
+		}
+	}
+
+	if hasApprovalListRemovals(expired) {
+		log.WithFields(f).Debugf("removing expired approval list entries: %+v", expired)
+		_, err = s.applySystemApprovalListUpdate(projectModel, companyModel, cclaSig, expired)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to remove the expired approval list entries, error: %+v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SelectApprovalListExpirations returns the removals of the approval list entries which have expired and the
+// expirations which are due within the reminder period and have not been reminded of yet
+func SelectApprovalListExpirations(expirations []*models.ApprovalListExpiration, now time.Time) (*models.ApprovalList, []*models.ApprovalListExpiration) {
+	expired := &models.ApprovalList{}
+	var expiring []*models.ApprovalListExpiration
+	for _, expiration := range expirations {
+		expiryDate, err := utils.ParseDateTime(expiration.ExpiryDate)
+		if err != nil {
+			log.Warnf("unable to parse expiry date: %s of approval list entry: %s - skipping",
+				expiration.ExpiryDate, expiration.Value)
+			continue
+		}
+
+		switch {
+		case !expiryDate.After(now):
+			addApprovalListRemoval(expired, expiration)
+		case !expiration.ReminderSent && expiryDate.Sub(now) <= ApprovalListExpiryReminderPeriod:
+			expiring = append(expiring, expiration)
+		}
+	}
+	return expired, expiring
+}
+
+// addApprovalListRemoval adds the approval list entry to the matching remove list of the approval list update
+func addApprovalListRemoval(params *models.ApprovalList, expiration *models.ApprovalListExpiration) {
+	switch expiration.EntryType {
+	case ApprovalListEntryEmail:
+		params.RemoveEmailApprovalList = append(params.RemoveEmailApprovalList, expiration.Value)
+	case ApprovalListEntryDomain:
+		params.RemoveDomainApprovalList = append(params.RemoveDomainApprovalList, expiration.Value)
+	case ApprovalListEntryGitHubUsername:
+		params.RemoveGithubUsernameApprovalList = append(params.RemoveGithubUsernameApprovalList, expiration.Value)
+	}
+}
+
+// BuildApprovalListExpirations returns the approval list expirations of the signature after the update and true if
+// they changed - the expirations of removed entries are dropped, entries added again replace their expiration with
+// the expiry date of the update, if any. Entries which are already on the approval list without an expiration are
+// kept permanently, unless the update asks to expire the existing entries as well.
+func BuildApprovalListExpirations(sig *models.Signature, params *models.ApprovalList) ([]*models.ApprovalListExpiration, bool) {
+	currentLists := map[string][]string{
+		ApprovalListEntryEmail:          sig.EmailApprovalList,
+		ApprovalListEntryDomain:         sig.DomainApprovalList,
+		ApprovalListEntryGitHubUsername: sig.GithubUsernameApprovalList,
+	}
+	addLists := map[string][]string{
+		ApprovalListEntryEmail:          params.AddEmailApprovalList,
+		ApprovalListEntryDomain:         params.AddDomainApprovalList,
+		ApprovalListEntryGitHubUsername: params.AddGithubUsernameApprovalList,
+	}
+	removeLists := map[string][]string{
+		ApprovalListEntryEmail:          params.RemoveEmailApprovalList,
+		ApprovalListEntryDomain:         params.RemoveDomainApprovalList,
+		ApprovalListEntryGitHubUsername: params.RemoveGithubUsernameApprovalList,
+	}
+
+	changed := false
+	expiringValues := map[string][]string{}
+	expirations := make([]*models.ApprovalListExpiration, 0, len(sig.ApprovalListExpirations))
+	for _, expiration := range sig.ApprovalListExpirations {
+		expiringValues[expiration.EntryType] = append(expiringValues[expiration.EntryType], expiration.Value)
+		if ContainsFold(addLists[expiration.EntryType], expiration.Value) || ContainsFold(removeLists[expiration.EntryType], expiration.Value) {
+			changed = true
+			continue
+		}
+		expirations = append(expirations, expiration)
+	}
+
+	if params.ExpiryDate != "" {
+		for _, entryType := range []string{ApprovalListEntryEmail, ApprovalListEntryDomain, ApprovalListEntryGitHubUsername} {
+			for _, value := range addLists[entryType] {
+				if ContainsFold(removeLists[entryType], value) {
+					continue
+				}
+				permanent := ContainsFold(currentLists[entryType], value) && !ContainsFold(expiringValues[entryType], value)
+				if permanent && !params.ExpireExistingEntries {
+					continue
+				}
+				changed = true
+				expirations = append(expirations, &models.ApprovalListExpiration{
+					EntryType:  entryType,
+					Value:      strings.TrimSpace(value),
+					ExpiryDate: params.ExpiryDate,
+				})
+			}
+		}
+	}
+
+	return expirations, changed
+}
+
+// buildApprovalListExpirationModels converts the approval list expiration database models
+func buildApprovalListExpirationModels(items []ItemApprovalListExpiration) []*models.ApprovalListExpiration {
+	if len(items) == 0 {
+		return nil
+	}
+	expirations := make([]*models.ApprovalListExpiration, 0, len(items))
+	for _, item := range items {
+		expirations = append(expirations, &models.ApprovalListExpiration{
+			EntryType:    item.EntryType,
+			Value:        item.Value,
+			ExpiryDate:   item.ExpiryDate,
+			ReminderSent: item.ReminderSent,
+		})
+	}
+	return expirations
+}
+
+// buildApprovalListExpirationItems converts the approval list expirations to database models
+func buildApprovalListExpirationItems(expirations []*models.ApprovalListExpiration) []ItemApprovalListExpiration {
+	items := make([]ItemApprovalListExpiration, 0, len(expirations))
+	for _, expiration := range expirations {
+		items = append(items, ItemApprovalListExpiration{
+			EntryType:    expiration.EntryType,
+			Value:        expiration.Value,
+			ExpiryDate:   expiration.ExpiryDate,
+			ReminderSent: expiration.ReminderSent,
+		})
+	}
+	return items
+}
+
+// sendApprovalListExpiryReminderEmail notifies the CLA Manager of the approval list entries which expire soon
+func sendApprovalListExpiryReminderEmail(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string, expiring []*models.ApprovalListExpiration) {
+	if recipientAddress == "" {
+		log.Warnf("unable to send approval list expiry reminder email to %s - recipient email address is empty", recipientName)
+		return
+	}
+
+	companyName := companyModel.CompanyName
+	projectName := projectModel.ProjectName
+
+	var entries string
+	for _, expiration := range expiring {
+		entries += fmt.Sprintf("<li>%s %s - expires on %s</li>", expiration.EntryType, expiration.Value, expiration.ExpiryDate)
+	}
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: Approval List Entries Expiring for %s on %s", companyName, projectName)
+	recipients := []string{recipientAddress}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>The following entries of the Approval List of %s for %s expire soon:</p>
+<ul>%s</ul>
+<p>Expired entries are removed from the Approval List automatically. To keep an entry, add it again to the
+Approval List with a later expiry date or without an expiry date using the EasyCLA Corporate Console.</p>
+%s
+%s`,
+		recipientName, projectName, companyName, projectName, entries,
+		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
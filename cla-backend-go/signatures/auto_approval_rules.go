@@ -0,0 +1,121 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// auto-approval rule types of the contributor approval list requests
+const (
+	AutoApprovalRuleEmailDomain = "email_domain"
+	AutoApprovalRuleGitHubOrg   = "github_org"
+)
+
+// AutoApprovalEvaluation is the outcome of the evaluation of the auto-approval rules for an approval list request
+type AutoApprovalEvaluation struct {
+	// RulesEvaluated is the number of rules evaluated
+	RulesEvaluated int
+	// Approved is true when at least one rule matches the requester
+	Approved bool
+	// MatchedRuleType and MatchedRuleValue identify the first rule which matches the requester
+	MatchedRuleType  string
+	MatchedRuleValue string
+	// MatchedEmail is the email address of the requester which matches an email_domain rule
+	MatchedEmail string
+}
+
+// HasAutoApprovalRule returns true if at least one of the rules has the specified type
+func HasAutoApprovalRule(rules []*models.AutoApprovalRule, ruleType string) bool {
+	for _, rule := range rules {
+		if rule != nil && rule.RuleType == ruleType {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluateAutoApprovalRules evaluates the auto-approval rules in order against the verified email addresses and
+// the GitHub organizations of the requester, the first matching rule approves the request
+func EvaluateAutoApprovalRules(rules []*models.AutoApprovalRule, emails []string, githubOrgs []string) *AutoApprovalEvaluation {
+	evaluation := &AutoApprovalEvaluation{}
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		evaluation.RulesEvaluated++
+
+		var matched bool
+		switch rule.RuleType {
+		case AutoApprovalRuleEmailDomain:
+			for _, email := range emails {
+				if _, matched = utils.MatchDomainRules([]string{rule.Value}, emailDomain(email)); matched {
+					evaluation.MatchedEmail = email
+					break
+				}
+			}
+		case AutoApprovalRuleGitHubOrg:
+			matched = ContainsFold(githubOrgs, rule.Value)
+		}
+
+		if matched {
+			evaluation.Approved = true
+			evaluation.MatchedRuleType = rule.RuleType
+			evaluation.MatchedRuleValue = rule.Value
+			return evaluation
+		}
+	}
+	return evaluation
+}
+
+// ValidAutoApprovalRule tests the auto-approval rule, returns true if the rule is valid, returns false and a message otherwise
+func ValidAutoApprovalRule(rule *models.AutoApprovalRule) (string, bool) {
+	if rule == nil {
+		return "auto-approval rule is empty", false
+	}
+
+	value := strings.TrimSpace(rule.Value)
+	switch rule.RuleType {
+	case AutoApprovalRuleEmailDomain:
+		if strings.HasPrefix(value, utils.DomainRuleExclusionPrefix) {
+			return fmt.Sprintf("exclusion rules are not supported as auto-approval rules: %s", value), false
+		}
+		return utils.ValidDomainRule(value)
+	case AutoApprovalRuleGitHubOrg:
+		return utils.ValidGitHubOrg(value)
+	}
+
+	return fmt.Sprintf("unsupported auto-approval rule type: %s", rule.RuleType), false
+}
+
+// buildAutoApprovalRuleModels converts the auto-approval rule database models
+func buildAutoApprovalRuleModels(items []ItemAutoApprovalRule) []*models.AutoApprovalRule {
+	if len(items) == 0 {
+		return nil
+	}
+	rules := make([]*models.AutoApprovalRule, 0, len(items))
+	for _, item := range items {
+		rules = append(rules, &models.AutoApprovalRule{
+			RuleType: item.RuleType,
+			Value:    item.Value,
+		})
+	}
+	return rules
+}
+
+// buildAutoApprovalRuleItems converts the auto-approval rules to database models
+func buildAutoApprovalRuleItems(rules []*models.AutoApprovalRule) []ItemAutoApprovalRule {
+	items := make([]ItemAutoApprovalRule, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, ItemAutoApprovalRule{
+			RuleType: rule.RuleType,
+			Value:    strings.TrimSpace(rule.Value),
+		})
+	}
+	return items
+}
@@ -0,0 +1,172 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// CLA Group contribution policies - the rule which decides whether the signatures of a contributor cover the contributor.
+// An approval list entry of a CCLA only counts with the employee acknowledgement of the company of the contributor.
+const (
+	// ContributionPolicyICLAOrCCLA covers a contributor with an ICLA or with an approval list entry of a CCLA - the
+	// default policy of the CLA Groups without a policy
+	ContributionPolicyICLAOrCCLA = "icla_or_ccla"
+	// ContributionPolicyCCLARequired covers a contributor only with an approval list entry of a CCLA, even with an ICLA
+	ContributionPolicyCCLARequired = "ccla_required"
+	// ContributionPolicyICLAAndECLA covers a contributor with an ICLA plus the employee acknowledgement of a company
+	// whose CCLA approval list contains the contributor
+	ContributionPolicyICLAAndECLA = "icla_and_ecla"
+	// ContributionPolicyRecentSignature covers a contributor with an ICLA or employee acknowledgement signed within the
+	// last number of years of the CLA Group
+	ContributionPolicyRecentSignature = "recent_signature"
+)
+
+// MaxContributionPolicyYears is the largest number of years of the recent_signature contribution policy
+const MaxContributionPolicyYears = 20
+
+// ContributionPolicyFacts are the signatures of a contributor which the contribution policy of a CLA Group is evaluated against
+type ContributionPolicyFacts struct {
+	// IndividualSignature is the signed and approved ICLA of the contributor, if any
+	IndividualSignature *models.Signature
+	// CorporateSignatures are the CCLAs with an approval list entry which matches the contributor
+	CorporateSignatures []*models.Signature
+	// EmployeeSignatures are the signed and approved employee acknowledgements of the contributor
+	EmployeeSignatures []*models.Signature
+	// UserCompanyID is the company of the contributor user record - as in the GitHub checks, only the employee
+	// acknowledgement of this company counts
+	UserCompanyID string
+	// ParentCompanyIDs are the parent companies, by subsidiary company ID, whose CCLAs cover the employees of the
+	// subsidiaries without a CCLA
+	ParentCompanyIDs map[string]string
+}
+
+// GetContributionPolicy returns the contribution policy of the CLA Group - the default policy when none is set
+func GetContributionPolicy(claGroupModel *models.Project) string {
+	if claGroupModel.ProjectContributionPolicy == "" {
+		return ContributionPolicyICLAOrCCLA
+	}
+	return claGroupModel.ProjectContributionPolicy
+}
+
+// ValidateContributionPolicy returns an error when the contribution policy is unknown or can not be combined with the
+// enabled signature types of the CLA Group. The number of years is only allowed with the recent_signature policy.
+func ValidateContributionPolicy(policy string, years int64, iclaEnabled, cclaEnabled bool) error {
+	switch policy {
+	case "", ContributionPolicyICLAOrCCLA:
+	case ContributionPolicyCCLARequired:
+		if !cclaEnabled {
+			return fmt.Errorf("the %s contribution policy requires the ccla to be enabled", policy)
+		}
+	case ContributionPolicyICLAAndECLA:
+		if !iclaEnabled || !cclaEnabled {
+			return fmt.Errorf("the %s contribution policy requires both the icla and the ccla to be enabled", policy)
+		}
+	case ContributionPolicyRecentSignature:
+		if years < 1 || years > MaxContributionPolicyYears {
+			return fmt.Errorf("the %s contribution policy requires a number of years between 1 and %d",
+				policy, MaxContributionPolicyYears)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown contribution policy: %s", policy)
+	}
+
+	if years != 0 {
+		return fmt.Errorf("the contribution policy years can only be set with the %s contribution policy",
+			ContributionPolicyRecentSignature)
+	}
+	return nil
+}
+
+// EvaluateContributionPolicy returns whether the signatures of the contributor cover the contributor under the
+// contribution policy of the CLA Group - when they don't, the second value describes the missing signature
+func EvaluateContributionPolicy(claGroupModel *models.Project, facts *ContributionPolicyFacts, now time.Time) (bool, string) {
+	hasICLA := facts.IndividualSignature != nil
+	// an approval list entry of a CCLA only covers the contributor with the employee acknowledgement of the company
+	hasCCLA := len(acknowledgedEmployeeSignatures(facts)) > 0
+
+	switch GetContributionPolicy(claGroupModel) {
+	case ContributionPolicyCCLARequired:
+		if !hasCCLA {
+			return false, "the CLA Group requires an approval list entry of a CCLA and the employee acknowledgement of the company"
+		}
+		if claGroupModel.ProjectCCLARequiresICLA && !hasICLA {
+			return false, "the CLA Group requires an ICLA in addition to the CCLA"
+		}
+		return true, ""
+
+	case ContributionPolicyICLAAndECLA:
+		if !hasICLA {
+			return false, "the CLA Group requires an ICLA"
+		}
+		if !hasCCLA {
+			return false, "the CLA Group requires the employee acknowledgement of a company whose CCLA approval list contains the contributor"
+		}
+		return true, ""
+
+	case ContributionPolicyRecentSignature:
+		cutoff := now.AddDate(-int(claGroupModel.ProjectContributionPolicyYears), 0, 0)
+		if hasICLA && signedAfter(facts.IndividualSignature, cutoff) {
+			return true, ""
+		}
+		for _, eclaSig := range acknowledgedEmployeeSignatures(facts) {
+			if signedAfter(eclaSig, cutoff) {
+				return true, ""
+			}
+		}
+		return false, fmt.Sprintf("the CLA Group requires an ICLA or employee acknowledgement signed within the last %d years",
+			claGroupModel.ProjectContributionPolicyYears)
+	}
+
+	if hasICLA {
+		return true, ""
+	}
+	if !hasCCLA {
+		return false, "the contributor has no ICLA and no employee acknowledgement of a company whose CCLA approval list contains the contributor"
+	}
+	if claGroupModel.ProjectCCLARequiresICLA {
+		return false, "the CLA Group requires an ICLA in addition to the CCLA"
+	}
+	return true, ""
+}
+
+// acknowledgedEmployeeSignatures returns the employee signatures of the contributor for the company of the contributor
+// whose CCLA, or the CCLA of its covering parent company, has an approval list which contains the contributor
+func acknowledgedEmployeeSignatures(facts *ContributionPolicyFacts) []*models.Signature {
+	var eclaSignatures []*models.Signature
+	for _, eclaSig := range facts.EmployeeSignatures {
+		if facts.UserCompanyID == "" || eclaSig.SignatureUserCompanyID != facts.UserCompanyID {
+			continue
+		}
+		parentCompanyID := facts.ParentCompanyIDs[eclaSig.SignatureUserCompanyID]
+		for _, cclaSig := range facts.CorporateSignatures {
+			if eclaSig.SignatureUserCompanyID == cclaSig.SignatureReferenceID ||
+				(parentCompanyID != "" && parentCompanyID == cclaSig.SignatureReferenceID) {
+				eclaSignatures = append(eclaSignatures, eclaSig)
+				break
+			}
+		}
+	}
+	return eclaSignatures
+}
+
+// signedAfter returns true if the signature was signed after the cutoff - the signature created date is used for the
+// older signatures without a signed on date
+func signedAfter(sig *models.Signature, cutoff time.Time) bool {
+	for _, dateTime := range []string{sig.SignedOn, sig.SignatureCreated} {
+		if dateTime == "" {
+			continue
+		}
+		signedOn, err := utils.ParseDateTime(dateTime)
+		if err == nil {
+			return signedOn.After(cutoff)
+		}
+	}
+	return false
+}
@@ -3,6 +3,17 @@
 
 package signatures
 
+import "errors"
+
+var (
+	// ErrLastCLAManager is returned when an update would leave the CCLA signature without a CLA Manager
+	ErrLastCLAManager = errors.New("last_cla_manager: the last CLA Manager of the signature can not be removed")
+	// ErrCLAManagerACLConflict is returned when the signature ACL no longer matches the expected CLA Managers
+	ErrCLAManagerACLConflict = errors.New("cla_manager_acl_conflict: the CLA Managers of the signature were modified by another request")
+	// ErrCLAManagerAlreadyInACL is returned when the user to add is already a CLA Manager of the signature
+	ErrCLAManagerAlreadyInACL = errors.New("manager already in signature ACL")
+)
+
 // NewBadRequestError returns an error that formats as the given text.
 func NewBadRequestError(text string) error {
 	return &BadRequestError{text}
@@ -32,3 +43,18 @@ type ForbiddenError struct {
 func (e ForbiddenError) Error() string {
 	return e.s
 }
+
+// NewNotFoundError returns an error that formats as the given text.
+func NewNotFoundError(text string) error {
+	return &NotFoundError{text}
+}
+
+// NotFoundError is a trivial implementation of error.
+type NotFoundError struct {
+	s string
+}
+
+// Error is the to string method for an error
+func (e NotFoundError) Error() string {
+	return e.s
+}
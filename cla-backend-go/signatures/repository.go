@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -26,6 +27,7 @@ import (
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
@@ -54,6 +56,7 @@ type SignatureRepository interface {
 	AddGithubOrganizationToWhitelist(signatureID, githubOrganizationID string) ([]models.GithubOrg, error)
 	DeleteGithubOrganizationFromWhitelist(signatureID, githubOrganizationID string) ([]models.GithubOrg, error)
 	InvalidateProjectRecord(signatureID string, projectName string) error
+	InvalidateSignature(signatureID string, note string) error
 
 	GetSignature(signatureID string) (*models.Signature, error)
 	GetIndividualSignature(claGroupID, userID string) (*models.Signature, error)
@@ -67,9 +70,14 @@ type SignatureRepository interface {
 	GetUserSignatures(params signatures.GetUserSignaturesParams, pageSize int64) (*models.Signatures, error)
 	ProjectSignatures(projectID string) (*models.Signatures, error)
 	UpdateApprovalList(projectID, companyID string, params *models.ApprovalList) (*models.Signature, error)
+	GetApprovalListExpirationSignatures() ([]*models.Signature, error)
+	GetCCLASignatureACLs() ([]*models.Signature, error)
+	UpdateApprovalListExpirations(signatureID string, expirations []*models.ApprovalListExpiration) error
+	UpdateAutoApprovalRules(signatureID string, rules []*models.AutoApprovalRule) error
 
 	AddCLAManager(signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(signatureID, claManagerID string) (*models.Signature, error)
+	TransferCLAManager(signatureID, fromClaManagerID, toClaManagerID string) (*models.Signature, error)
 
 	removeColumn(signatureID, columnName string) (*models.Signature, error)
 
@@ -1004,9 +1012,12 @@ func (repo repository) ProjectSignatures(projectID string) (*models.Signatures,
 }
 
 func (repo repository) InvalidateProjectRecord(signatureID string, projectName string) error {
-	// Update project signatures for signature_approved and notes attributes
-	signatureTableName := fmt.Sprintf("cla-%s-signatures", repo.stage)
+	note := fmt.Sprintf("Signature invalidated (approved set to false) due to CLA Group/Project: %s deletion", projectName)
+	return repo.InvalidateSignature(signatureID, note)
+}
 
+// InvalidateSignature sets the signature approved flag to false and records the reason in the signature note
+func (repo repository) InvalidateSignature(signatureID string, note string) error {
 	expressionAttributeNames := map[string]*string{}
 	expressionAttributeValues := map[string]*dynamodb.AttributeValue{}
 	updateExpression := "SET " // nolint
@@ -1016,7 +1027,6 @@ func (repo repository) InvalidateProjectRecord(signatureID string, projectName s
 	updateExpression = updateExpression + " #A = :a,"
 
 	expressionAttributeNames["#S"] = aws.String("note")
-	note := fmt.Sprintf("Signature invalidated (approved set to false) due to CLA Group/Project: %s deletion", projectName)
 	expressionAttributeValues[":s"] = &dynamodb.AttributeValue{S: aws.String(note)}
 	updateExpression = updateExpression + " #S = :s"
 
@@ -1029,7 +1039,7 @@ func (repo repository) InvalidateProjectRecord(signatureID string, projectName s
 		ExpressionAttributeNames:  expressionAttributeNames,
 		ExpressionAttributeValues: expressionAttributeValues,
 		UpdateExpression:          &updateExpression,
-		TableName:                 aws.String(signatureTableName),
+		TableName:                 aws.String(repo.signatureTableName),
 	}
 
 	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
@@ -1381,7 +1391,7 @@ func (repo repository) AddCLAManager(signatureID, claManagerID string) (*models.
 
 	for _, manager := range aclEntries {
 		if claManagerID == manager {
-			return nil, errors.New("manager already in signature ACL")
+			return nil, ErrCLAManagerAlreadyInACL
 		}
 	}
 
@@ -1429,6 +1439,8 @@ func (repo repository) AddCLAManager(signatureID, claManagerID string) (*models.
 	return sigModel, nil
 }
 
+// RemoveCLAManager removes the manager from the signature ACL - the removal is rejected with ErrLastCLAManager when
+// the manager is the last CLA Manager of the signature
 func (repo repository) RemoveCLAManager(signatureID, claManagerID string) (*models.Signature, error) {
 	aclEntries, err := repo.GetSignatureACL(signatureID)
 	if err != nil {
@@ -1441,11 +1453,94 @@ func (repo repository) RemoveCLAManager(signatureID, claManagerID string) (*mode
 		return nil, nil
 	}
 
-	// A bit of logic to determine if the manager is listed and to build the new list without the specified manager
+	if removalErr := CheckCLAManagerRemoval(aclEntries, claManagerID); removalErr != nil {
+		log.Warnf("remove CLA manager - unable to remove '%s' from signature ID: %s, error: %v", claManagerID, signatureID, removalErr)
+		return nil, removalErr
+	}
+
+	_, now := utils.CurrentTime()
+
+	// The condition guards against concurrent removals leaving the signature without a manager
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#A": aws.String("signature_acl"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":a": {
+				SS: aws.StringSlice([]string{claManagerID}),
+			},
+			":r": {
+				S: aws.String(claManagerID),
+			},
+			":one": {
+				N: aws.String("1"),
+			},
+			":m": {
+				S: aws.String(now),
+			},
+		},
+		ConditionExpression: aws.String("contains(#A, :r) AND size(#A) > :one"),
+		UpdateExpression:    aws.String("DELETE #A :a SET #M = :m"),
+		TableName:           aws.String(fmt.Sprintf("cla-%s-signatures", repo.stage)),
+	}
+
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		if isConditionalCheckFailed(updateErr) {
+			// The ACL changed since it was read - re-read it to tell why the removal was rejected
+			log.Warnf("remove CLA manager - signature ACL of signature ID: %s changed while removing '%s'", signatureID, claManagerID)
+			aclEntries, err = repo.GetSignatureACL(signatureID)
+			if err != nil {
+				log.Warnf("unable to fetch signature by ID: %s, error: %+v", signatureID, err)
+				return nil, err
+			}
+			if removalErr := CheckCLAManagerRemoval(aclEntries, claManagerID); removalErr != nil {
+				return nil, removalErr
+			}
+			return nil, ErrCLAManagerACLConflict
+		}
+		log.Warnf("remove CLA manager - unable to remove ACL entry of '%s' for signature ID: %s, error: %v",
+			claManagerID, signatureID, updateErr)
+		return nil, updateErr
+	}
+
+	// Load the updated document and return it
+	sigModel, err := repo.GetSignature(signatureID)
+	if err != nil {
+		log.Warnf("unable to fetch signature by ID: %s - record not found", signatureID)
+		return nil, err
+	}
+
+	return sigModel, nil
+}
+
+// TransferCLAManager replaces a CLA Manager of the signature ACL with another user in a single conditional update, the
+// update is rejected with ErrCLAManagerACLConflict if the ACL changed since it was read
+func (repo repository) TransferCLAManager(signatureID, fromClaManagerID, toClaManagerID string) (*models.Signature, error) {
+	aclEntries, err := repo.GetSignatureACL(signatureID)
+	if err != nil {
+		log.Warnf("unable to fetch signature by ID: %s, error: %+v", signatureID, err)
+		return nil, err
+	}
+
+	if aclEntries == nil {
+		log.Warnf("unable to fetch signature by ID: %s - record not found", signatureID)
+		return nil, nil
+	}
+
 	found := false
 	var updateEntries []string
 	for _, manager := range aclEntries {
-		if claManagerID == manager {
+		if toClaManagerID == manager {
+			return nil, NewBadRequestError(fmt.Sprintf("manager ID: %s already in signature ACL", toClaManagerID))
+		}
+		if fromClaManagerID == manager {
 			found = true
 		} else {
 			updateEntries = append(updateEntries, manager)
@@ -1453,8 +1548,9 @@ func (repo repository) RemoveCLAManager(signatureID, claManagerID string) (*mode
 	}
 
 	if !found {
-		return nil, fmt.Errorf("manager ID: %s not found in signature ACL", claManagerID)
+		return nil, NewBadRequestError(fmt.Sprintf("manager ID: %s not found in signature ACL", fromClaManagerID))
 	}
+	updateEntries = append(updateEntries, toClaManagerID)
 
 	_, now := utils.CurrentTime()
 
@@ -1472,18 +1568,33 @@ func (repo repository) RemoveCLAManager(signatureID, claManagerID string) (*mode
 			":a": {
 				SS: aws.StringSlice(updateEntries),
 			},
+			":from": {
+				S: aws.String(fromClaManagerID),
+			},
+			":to": {
+				S: aws.String(toClaManagerID),
+			},
+			":size": {
+				N: aws.String(strconv.Itoa(len(aclEntries))),
+			},
 			":m": {
 				S: aws.String(now),
 			},
 		},
-		UpdateExpression: aws.String("SET #A = :a, #M = :m"),
-		TableName:        aws.String(fmt.Sprintf("cla-%s-signatures", repo.stage)),
+		ConditionExpression: aws.String("contains(#A, :from) AND NOT contains(#A, :to) AND size(#A) = :size"),
+		UpdateExpression:    aws.String("SET #A = :a, #M = :m"),
+		TableName:           aws.String(fmt.Sprintf("cla-%s-signatures", repo.stage)),
 	}
 
 	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
 	if updateErr != nil {
-		log.Warnf("remove CLA manager - unable to remove ACL entry of '%s' for signature ID: %s, error: %v",
-			claManagerID, signatureID, updateErr)
+		if isConditionalCheckFailed(updateErr) {
+			log.Warnf("transfer CLA manager - signature ACL of signature ID: %s changed while transferring '%s' to '%s'",
+				signatureID, fromClaManagerID, toClaManagerID)
+			return nil, ErrCLAManagerACLConflict
+		}
+		log.Warnf("transfer CLA manager - unable to transfer ACL entry of '%s' to '%s' for signature ID: %s, error: %v",
+			fromClaManagerID, toClaManagerID, signatureID, updateErr)
 		return nil, updateErr
 	}
 
@@ -1497,6 +1608,26 @@ func (repo repository) RemoveCLAManager(signatureID, claManagerID string) (*mode
 	return sigModel, nil
 }
 
+// CheckCLAManagerRemoval returns an error if the manager is not in the signature ACL, or ErrLastCLAManager if the
+// manager is the last CLA Manager of the signature
+func CheckCLAManagerRemoval(aclEntries []string, claManagerID string) error {
+	if !utils.StringInSlice(claManagerID, aclEntries) {
+		return fmt.Errorf("manager ID: %s not found in signature ACL", claManagerID)
+	}
+	if len(aclEntries) == 1 {
+		return ErrLastCLAManager
+	}
+	return nil
+}
+
+// isConditionalCheckFailed returns true if the error is the failure of the condition of a conditional update
+func isConditionalCheckFailed(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+	}
+	return false
+}
+
 // UpdateApprovalList updates the specified project/company signature with the updated approval list information
 func (repo repository) UpdateApprovalList(projectID, companyID string, params *models.ApprovalList) (*models.Signature, error) { // nolint
 	log.Debugf("querying database for approval list details using project ID: %s, company ID: %s", projectID, companyID)
@@ -1524,6 +1655,7 @@ func (repo repository) UpdateApprovalList(projectID, companyID string, params *m
 
 	// Just grab and use the first one - need to figure out conflict resolution if more than one
 	sig := sigs.Signatures[0]
+	existingSig := sig
 	expressionAttributeNames := map[string]*string{}
 	expressionAttributeValues := map[string]*dynamodb.AttributeValue{}
 	haveAdditions := false
@@ -1614,6 +1746,30 @@ func (repo repository) UpdateApprovalList(projectID, companyID string, params *m
 		}
 	}
 
+	if expirations, changed := BuildApprovalListExpirations(existingSig, params); changed {
+		columnName := "approval_list_expirations"
+		if len(expirations) == 0 {
+			var rmColErr error
+			sig, rmColErr = repo.removeColumn(sig.SignatureID, columnName)
+			if rmColErr != nil {
+				msg := fmt.Sprintf("unable to remove column %s for signature for company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t",
+					columnName, companyID, projectID, signed, approved)
+				log.Warn(msg)
+				return nil, errors.New(msg)
+			}
+		} else {
+			attrList, marshalErr := dynamodbattribute.MarshalList(buildApprovalListExpirationItems(expirations))
+			if marshalErr != nil {
+				log.Warnf("unable to marshal the approval list expirations for signature ID: %s, error: %v", sig.SignatureID, marshalErr)
+				return nil, marshalErr
+			}
+			haveAdditions = true
+			expressionAttributeNames["#X"] = aws.String(columnName)
+			expressionAttributeValues[":x"] = &dynamodb.AttributeValue{L: attrList}
+			updateExpression = updateExpression + " #X = :x, "
+		}
+	}
+
 	// Ensure at least one value is set for us to update
 	if !haveAdditions {
 		log.Debugf("no updates required to any of the approved list values company ID: %s project ID: %s, type: ccla, signed: %t, approved: %t - expecting at least something to update",
@@ -1709,6 +1865,227 @@ func (repo repository) removeColumn(signatureID, columnName string) (*models.Sig
 	return updatedSig, nil
 }
 
+// GetApprovalListExpirationSignatures returns the signatures with at least one approval list entry which expires -
+// only the signature ID, CLA Group ID, company ID and expirations are loaded
+func (repo repository) GetApprovalListExpirationSignatures() ([]*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName": "GetApprovalListExpirationSignatures",
+		"tableName":    repo.signatureTableName,
+	}
+
+	filter := expression.Name("approval_list_expirations").AttributeExists().
+		And(expression.Name("signature_type").Equal(expression.Value(CCLA)))
+	projection := expression.NamesList(
+		expression.Name("signature_id"),
+		expression.Name("signature_project_id"),
+		expression.Name("signature_reference_id"),
+		expression.Name("approval_list_expirations"),
+	)
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for approval list expirations scan, error: %v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+	}
+
+	var dbSignatures []ItemSignature
+	for {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).Warnf("error scanning for approval list expirations, error: %v", scanErr)
+			return nil, scanErr
+		}
+
+		var pageSignatures []ItemSignature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &pageSignatures)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling signatures from database, error: %v", err)
+			return nil, err
+		}
+		dbSignatures = append(dbSignatures, pageSignatures...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	sigs := make([]*models.Signature, 0, len(dbSignatures))
+	for _, dbSignature := range dbSignatures {
+		sigs = append(sigs, &models.Signature{
+			SignatureID:             dbSignature.SignatureID,
+			ProjectID:               dbSignature.SignatureProjectID,
+			SignatureReferenceID:    dbSignature.SignatureReferenceID,
+			ApprovalListExpirations: buildApprovalListExpirationModels(dbSignature.ApprovalListExpirations),
+		})
+	}
+
+	log.WithFields(f).Debugf("found %d signatures with approval list expirations", len(sigs))
+	return sigs, nil
+}
+
+// GetCCLASignatureACLs returns the signed and approved CCLA signatures with their ACL - the ACL entries only hold the
+// LF username of the CLA Managers
+func (repo repository) GetCCLASignatureACLs() ([]*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName": "GetCCLASignatureACLs",
+		"tableName":    repo.signatureTableName,
+	}
+
+	filter := expression.Name("signature_type").Equal(expression.Value(CCLA)).
+		And(expression.Name("signature_signed").Equal(expression.Value(true))).
+		And(expression.Name("signature_approved").Equal(expression.Value(true)))
+	projection := expression.NamesList(
+		expression.Name("signature_id"),
+		expression.Name("signature_project_id"),
+		expression.Name("signature_reference_id"),
+		expression.Name("signature_acl"),
+	)
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for CCLA signature scan, error: %v", err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.signatureTableName),
+	}
+
+	var dbSignatures []ItemSignature
+	for {
+		results, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).Warnf("error scanning for CCLA signatures, error: %v", scanErr)
+			return nil, scanErr
+		}
+
+		var pageSignatures []ItemSignature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &pageSignatures)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling signatures from database, error: %v", err)
+			return nil, err
+		}
+		dbSignatures = append(dbSignatures, pageSignatures...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	sigs := make([]*models.Signature, 0, len(dbSignatures))
+	for _, dbSignature := range dbSignatures {
+		signatureACL := make([]models.User, 0, len(dbSignature.SignatureACL))
+		for _, userName := range dbSignature.SignatureACL {
+			signatureACL = append(signatureACL, models.User{LfUsername: userName})
+		}
+		sigs = append(sigs, &models.Signature{
+			SignatureID:          dbSignature.SignatureID,
+			ProjectID:            dbSignature.SignatureProjectID,
+			SignatureReferenceID: dbSignature.SignatureReferenceID,
+			SignatureACL:         signatureACL,
+		})
+	}
+
+	log.WithFields(f).Debugf("found %d CCLA signatures", len(sigs))
+	return sigs, nil
+}
+
+// UpdateApprovalListExpirations replaces the approval list expirations of the signature
+func (repo repository) UpdateApprovalListExpirations(signatureID string, expirations []*models.ApprovalListExpiration) error {
+	columnName := "approval_list_expirations"
+	if len(expirations) == 0 {
+		_, err := repo.removeColumn(signatureID, columnName)
+		return err
+	}
+
+	attrList, err := dynamodbattribute.MarshalList(buildApprovalListExpirationItems(expirations))
+	if err != nil {
+		log.Warnf("unable to marshal the approval list expirations for signature ID: %s, error: %v", signatureID, err)
+		return err
+	}
+
+	_, now := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#X": aws.String(columnName),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":x": {L: attrList},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #X = :x, #M = :m"),
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.Warnf("error updating the approval list expirations for signature ID: %s, error: %v", signatureID, err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateAutoApprovalRules replaces the auto-approval rules of the signature
+func (repo repository) UpdateAutoApprovalRules(signatureID string, rules []*models.AutoApprovalRule) error {
+	columnName := "auto_approval_rules"
+	if len(rules) == 0 {
+		_, err := repo.removeColumn(signatureID, columnName)
+		return err
+	}
+
+	attrList, err := dynamodbattribute.MarshalList(buildAutoApprovalRuleItems(rules))
+	if err != nil {
+		log.Warnf("unable to marshal the auto-approval rules for signature ID: %s, error: %v", signatureID, err)
+		return err
+	}
+
+	_, now := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.signatureTableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"signature_id": {
+				S: aws.String(signatureID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#R": aws.String(columnName),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r": {L: attrList},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #R = :r, #M = :m"),
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(input)
+	if err != nil {
+		log.Warnf("error updating the auto-approval rules for signature ID: %s, error: %v", signatureID, err)
+		return err
+	}
+
+	return nil
+}
+
 func (repo repository) AddSigTypeSignedApprovedID(signatureID string, val string) error {
 	input := &dynamodb.UpdateItemInput{
 		TableName: aws.String(repo.signatureTableName),
@@ -1850,6 +2227,7 @@ func (repo repository) buildProjectSignatureModels(results *dynamodb.QueryOutput
 			SignatureMinorVersion:       dbSignature.SignatureDocumentMinorVersion,
 			Version:                     dbSignature.SignatureDocumentMajorVersion + "." + dbSignature.SignatureDocumentMinorVersion,
 			SignatureReferenceType:      dbSignature.SignatureReferenceType,
+			SignatureUserCompanyID:      dbSignature.SignatureUserCompanyID,
 			ProjectID:                   dbSignature.SignatureProjectID,
 			Created:                     dbSignature.DateCreated,
 			Modified:                    dbSignature.DateModified,
@@ -1862,6 +2240,8 @@ func (repo repository) buildProjectSignatureModels(results *dynamodb.QueryOutput
 			UserGHID:                    dbSignature.UserGithubUsername,
 			SignedOn:                    dbSignature.SignedOn,
 			SignatoryName:               dbSignature.SignatoryName,
+			ApprovalListExpirations:     buildApprovalListExpirationModels(dbSignature.ApprovalListExpirations),
+			AutoApprovalRules:           buildAutoApprovalRuleModels(dbSignature.AutoApprovalRules),
 		}
 		sigs = append(sigs, sig)
 		go func(sigModel *models.Signature, signatureUserCompanyID string, sigACL []string) {
@@ -1982,6 +2362,8 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("user_email"),
 		expression.Name("signed_on"),
 		expression.Name("signatory_name"),
+		expression.Name("approval_list_expirations"),
+		expression.Name("auto_approval_rules"),
 	)
 }
 
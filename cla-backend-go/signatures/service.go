@@ -7,10 +7,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/sirupsen/logrus"
 
 	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/github"
 
 	"github.com/communitybridge/easycla/cla-backend-go/users"
 
@@ -44,30 +49,50 @@ type SignatureService interface {
 	AddGithubOrganizationToWhitelist(signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
 	DeleteGithubOrganizationFromWhitelist(signatureID string, whiteListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
 	UpdateApprovalList(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error)
+	PreviewApprovalList(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error)
+	UpdateApprovalListAsSystem(projectModel *models.Project, companyModel *models.Company, params *models.ApprovalList) (*models.Signature, error)
+	UpdateApprovalListForRequests(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, params *models.ApprovalList) (*models.Signature, error)
+	GetApprovalListExpirationSignatures() ([]*models.Signature, error)
+	ProcessApprovalListExpirations(projectModel *models.Project, companyModel *models.Company, sig *models.Signature, now time.Time) error
+	DetachCorporateContributor(authUser *auth.User, projectModel *models.Project, companyModel *models.Company) error
+	ExplainCoverage(claGroupModel *models.Project, githubUsername, githubID, email string) (*models.CoverageExplanation, error)
 
 	AddCLAManager(signatureID, claManagerID string) (*models.Signature, error)
 	RemoveCLAManager(signatureID, claManagerID string) (*models.Signature, error)
+	TransferCLAManager(signatureID, fromClaManagerID, toClaManagerID string) (*models.Signature, error)
 
 	GetClaGroupICLASignatures(claGroupID string, searchTerm *string) (*models.IclaSignatures, error)
 	GetClaGroupCorporateContributors(claGroupID string, companyID *string, searchTerm *string) (*models.CorporateContributorList, error)
+
+	GetClaGroupOutdatedSignatures(claGroupModel *models.Project, signatureType string) ([]*models.Signature, error)
+	NotifyOutdatedSignatures(lfUsername string, claGroupModel *models.Project) error
+	EnforceResignDeadline(claGroupModel *models.Project, now time.Time) error
 }
 
+// CLA Group resign policies applied when a new document major version is published
+const (
+	ResignPolicyGrandfather = "grandfather"
+	ResignPolicyResign      = "resign"
+)
+
 type service struct {
-	repo                SignatureRepository
-	companyService      company.IService
-	usersService        users.Service
-	eventsService       events.Service
-	githubOrgValidation bool
+	repo                          SignatureRepository
+	companyService                company.IService
+	usersService                  users.Service
+	eventsService                 events.Service
+	githubOrgValidation           bool
+	domainVerificationEnforcement bool
 }
 
 // NewService creates a new whitelist service
-func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool) SignatureService {
+func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, domainVerificationEnforcement bool) SignatureService {
 	return service{
 		repo,
 		companyService,
 		usersService,
 		eventsService,
 		githubOrgValidation,
+		domainVerificationEnforcement,
 	}
 }
 
@@ -375,6 +400,148 @@ func (s service) DeleteGithubOrganizationFromWhitelist(signatureID string, white
 
 // UpdateApprovalList service method
 func (s service) UpdateApprovalList(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+	return s.updateApprovalList(authUser, projectModel, companyModel, claGroupID, params, true)
+}
+
+// UpdateApprovalListForRequests adds the contributors of approved access requests to the approval lists of the
+// company CCLA - the update is validated, logged and notified to the CLA Managers like UpdateApprovalList, the
+// contributors are notified of the request decision by the caller
+func (s service) UpdateApprovalListForRequests(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, params *models.ApprovalList) (*models.Signature, error) {
+	return s.updateApprovalList(authUser, projectModel, companyModel, projectModel.ProjectID, params, false)
+}
+
+// updateApprovalList validates and applies the approval list update of a CLA Manager
+func (s service) updateApprovalList(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string, params *models.ApprovalList, notifyContributors bool) (*models.Signature, error) {
+	sigModel, err := s.getApprovalListSignature(authUser, projectModel, companyModel, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store the expiry date of the added entries in a consistent format
+	if params.ExpiryDate != "" {
+		expiryDate, parseErr := utils.ParseDateTime(params.ExpiryDate)
+		if parseErr != nil {
+			return nil, NewBadRequestError(fmt.Sprintf("invalid approval list expiry date: %s - %v", params.ExpiryDate, parseErr))
+		}
+		params.ExpiryDate = utils.TimeToString(expiryDate)
+	}
+
+	err = checkDomainApprovalListRemovals(sigModel, params)
+	if err != nil {
+		return nil, err
+	}
+
+	unverifiedDomains, err := s.checkApprovalListDomains(companyModel, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lookup the user making the request
+	userModel, userErr := s.usersService.GetUserByUserName(authUser.UserName, true)
+	if userErr != nil {
+		return nil, userErr
+	}
+
+	updatedSig, err := s.applyApprovalListUpdate(authUser, userModel, projectModel, companyModel, claGroupID, sigModel, params, notifyContributors)
+	if err != nil {
+		return updatedSig, err
+	}
+	updatedSig.UnverifiedDomains = unverifiedDomains
+	return updatedSig, nil
+}
+
+// checkDomainApprovalListRemovals returns a bad request error when a removed domain is neither a valid rule nor an
+// existing entry of the approval list
+func checkDomainApprovalListRemovals(sigModel *models.Signature, params *models.ApprovalList) error {
+	invalidRemovals := InvalidDomainApprovalListRemovals(sigModel.DomainApprovalList, params.RemoveDomainApprovalList)
+	if len(invalidRemovals) > 0 {
+		return NewBadRequestError(fmt.Sprintf("invalid remove approval list domains %s - the domains are not valid rules and are not in the approval list",
+			strings.Join(invalidRemovals, ", ")))
+	}
+	return nil
+}
+
+// checkApprovalListDomains returns the added domain approval list entries which are not verified for the company,
+// returns an error instead when the domain verification is enforced
+func (s service) checkApprovalListDomains(companyModel *models.Company, params *models.ApprovalList) ([]string, error) {
+	unverifiedDomains := utils.UnverifiedDomainRules(params.AddDomainApprovalList, company.VerifiedDomains(companyModel))
+	if len(unverifiedDomains) == 0 {
+		return nil, nil
+	}
+
+	if s.domainVerificationEnforcement {
+		return nil, NewBadRequestError(fmt.Sprintf("the domains %s are not verified for company: %s - verify the domains with a DNS TXT record before adding them to the approval list",
+			strings.Join(unverifiedDomains, ", "), companyModel.CompanyName))
+	}
+	log.Warnf("adding domains %+v which are not verified for company: %s (%s) to the approval list",
+		unverifiedDomains, companyModel.CompanyName, companyModel.CompanyID)
+	return unverifiedDomains, nil
+}
+
+// applyApprovalListUpdate updates the approval lists of the company CCLA, invalidates the employee signatures which
+// are no longer covered, logs the events and notifies the CLA Managers and, optionally, the contributors
+func (s service) applyApprovalListUpdate(authUser *auth.User, userModel *models.User, projectModel *models.Project, companyModel *models.Company, claGroupID string, sigModel *models.Signature, params *models.ApprovalList, notifyContributors bool) (*models.Signature, error) {
+	claManagers := sigModel.SignatureACL
+	updatedSig, err := s.repo.UpdateApprovalList(projectModel.ProjectID, companyModel.CompanyID, params)
+	if err != nil {
+		return updatedSig, err
+	}
+
+	// Invalidate the employee signatures which are no longer covered by the approval list
+	if hasApprovalListRemovals(params) {
+		updatedSig.InvalidatedContributors = s.invalidateUncoveredEmployeeSignatures(companyModel, projectModel, userModel, claGroupID, sigModel, updatedSig)
+	}
+
+	// Log Events
+	s.createEventLogEntries(companyModel, projectModel, userModel, params)
+
+	// Send an email to the CLA Managers
+	for _, claManager := range claManagers {
+		claManagerEmail := getBestEmail(claManager)
+		s.sendApprovalListUpdateEmailToCLAManagers(companyModel, projectModel, claManager.Username, claManagerEmail, params)
+	}
+
+	// Send emails to contributors if email or GH username as added/removed
+	if notifyContributors {
+		s.sendRequestAccessEmailToContributors(authUser, companyModel, projectModel, params)
+	}
+
+	return updatedSig, nil
+}
+
+// UpdateApprovalListAsSystem updates the approval lists of the company CCLA on behalf of EasyCLA itself, such as
+// for the auto-approved contributor requests - the update is logged and notified like the updates of a CLA Manager
+func (s service) UpdateApprovalListAsSystem(projectModel *models.Project, companyModel *models.Company, params *models.ApprovalList) (*models.Signature, error) {
+	pageSize := int64(1)
+	signed, approved := true, true
+	sigModel, err := s.GetProjectCompanySignature(companyModel.CompanyID, projectModel.ProjectID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if sigModel == nil {
+		return nil, NewBadRequestError(fmt.Sprintf("unable to locate signature for company ID: %s CLA Group ID: %s, type: ccla, signed: %t, approved: %t",
+			companyModel.CompanyID, projectModel.ProjectID, signed, approved))
+	}
+
+	return s.applySystemApprovalListUpdate(projectModel, companyModel, sigModel, params)
+}
+
+// applySystemApprovalListUpdate validates the removals and applies the approval list update on behalf of EasyCLA
+// itself - the update is logged and notified like the updates of a CLA Manager
+func (s service) applySystemApprovalListUpdate(projectModel *models.Project, companyModel *models.Company, sigModel *models.Signature, params *models.ApprovalList) (*models.Signature, error) {
+	err := checkDomainApprovalListRemovals(sigModel, params)
+	if err != nil {
+		return nil, err
+	}
+
+	systemUser := &models.User{Username: easyCLASystemUserName, LfUsername: easyCLASystemLFUsername}
+	return s.applyApprovalListUpdate(&auth.User{UserName: easyCLASystemUserName}, systemUser,
+		projectModel, companyModel, projectModel.ProjectID, sigModel, params, true)
+}
+
+// getApprovalListSignature returns the company CCLA of the CLA Group, returns an error if the authenticated user
+// is not one of the CLA Managers of the signature
+func (s service) getApprovalListSignature(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string) (*models.Signature, error) {
 	pageSize := int64(1)
 	signed, approved := true, true
 	sigModel, sigErr := s.GetProjectCompanySignature(companyModel.CompanyID, claGroupID, &signed, &approved, nil, &pageSize)
@@ -401,30 +568,531 @@ func (s service) UpdateApprovalList(authUser *auth.User, projectModel *models.Pr
 		return nil, NewForbiddenError(msg)
 	}
 
-	// Lookup the user making the request
-	userModel, userErr := s.usersService.GetUserByUserName(authUser.UserName, true)
-	if userErr != nil {
-		return nil, userErr
+	return sigModel, nil
+}
+
+// PreviewApprovalList returns the company CCLA with the approval lists as they would be after the update and the
+// corporate contributors who would gain or lose coverage - nothing is written and no email is sent
+func (s service) PreviewApprovalList(authUser *auth.User, projectModel *models.Project, companyModel *models.Company, claGroupID string, params *models.ApprovalList) (*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName": "PreviewApprovalList",
+		"claGroupID":   claGroupID,
+		"companyID":    companyModel.CompanyID,
+		"companyName":  companyModel.CompanyName,
 	}
 
-	updatedSig, err := s.repo.UpdateApprovalList(projectModel.ProjectID, companyModel.CompanyID, params)
+	sigModel, err := s.getApprovalListSignature(authUser, projectModel, companyModel, claGroupID)
 	if err != nil {
-		return updatedSig, err
+		return nil, err
+	}
+	err = checkDomainApprovalListRemovals(sigModel, params)
+	if err != nil {
+		return nil, err
+	}
+	unverifiedDomains, err := s.checkApprovalListDomains(companyModel, params)
+	if err != nil {
+		return nil, err
 	}
+	previewSig := ApplyApprovalListChanges(sigModel, params)
+	previewSig.UnverifiedDomains = unverifiedDomains
 
-	// Log Events
-	s.createEventLogEntries(companyModel, projectModel, userModel, params)
+	contributors, err := s.repo.GetClaGroupCorporateContributors(claGroupID, &companyModel.CompanyID, nil)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the corporate contributors, error: %+v", err)
+		return nil, err
+	}
+	if companyModel.SubsidiaryCclaCoverage {
+		// the approval lists of the CCLA also cover the employees of the subsidiaries without a CCLA
+		subsidiaryContributors, subsidiaryErr := s.getCCLACoveredSubsidiaryContributors(companyModel, claGroupID)
+		if subsidiaryErr != nil {
+			log.WithFields(f).Warnf("unable to load the corporate contributors of the subsidiaries, error: %+v", subsidiaryErr)
+			return nil, subsidiaryErr
+		}
+		contributors.List = append(contributors.List, subsidiaryContributors...)
+	}
 
-	// Send an email to the CLA Managers
-	for _, claManager := range claManagers {
-		claManagerEmail := getBestEmail(claManager)
-		s.sendApprovalListUpdateEmailToCLAManagers(companyModel, projectModel, claManager.Username, claManagerEmail, params)
+	preview := &models.ApprovalListPreview{
+		GainedCoverage: []*models.CorporateContributor{},
+		LostCoverage:   []*models.CorporateContributor{},
 	}
+	checkGitHubOrgs := len(sigModel.GithubOrgApprovalList) > 0 || len(previewSig.GithubOrgApprovalList) > 0
+	for _, contributor := range contributors.List {
+		var emails []string
+		if contributor.Email != "" {
+			emails = append(emails, contributor.Email)
+		}
+		var githubOrgs []string
+		if checkGitHubOrgs && contributor.GithubID != "" {
+			githubOrgs, err = github.GetUserOrganizations(contributor.GithubID)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to lookup the GitHub organizations of user: %s, error: %+v", contributor.GithubID, err)
+			}
+		}
 
-	// Send emails to contributors if email or GH username as added/removed
-	s.sendRequestAccessEmailToContributors(authUser, companyModel, projectModel, params)
+		coveredBefore := GetApprovalListCoverage(sigModel, emails, contributor.GithubID, githubOrgs) != ""
+		coveredAfter := GetApprovalListCoverage(previewSig, emails, contributor.GithubID, githubOrgs) != ""
+		if coveredBefore != coveredAfter {
+			// the approval list entry only changes the coverage of the contributor under the contribution policy of
+			// the CLA Group, such as the default policy which covers the contributors with an ICLA anyway
+			iclaSig := s.getCorporateContributorICLA(projectModel.ProjectID, contributor)
+			coveredBefore = corporateContributorCovered(projectModel, companyModel, contributor, iclaSig, coveredBefore)
+			coveredAfter = corporateContributorCovered(projectModel, companyModel, contributor, iclaSig, coveredAfter)
+		}
+		switch {
+		case !coveredBefore && coveredAfter:
+			preview.GainedCoverage = append(preview.GainedCoverage, contributor)
+		case coveredBefore && !coveredAfter:
+			preview.LostCoverage = append(preview.LostCoverage, contributor)
+		}
+	}
 
-	return updatedSig, nil
+	log.WithFields(f).Debugf("approval list update would grant coverage to %d and remove coverage from %d corporate contributors",
+		len(preview.GainedCoverage), len(preview.LostCoverage))
+	previewSig.ApprovalListPreview = preview
+	return previewSig, nil
+}
+
+// getCorporateContributorICLA returns the ICLA of the corporate contributor for the CLA Group - nil when the contributor
+// has no ICLA or the user record of the contributor can not be located
+func (s service) getCorporateContributorICLA(claGroupID string, contributor *models.CorporateContributor) *models.Signature {
+	var userModel *models.User
+	if contributor.LinuxFoundationID != "" {
+		userModel, _ = s.usersService.GetUserByLFUserName(contributor.LinuxFoundationID)
+	}
+	if userModel == nil && contributor.GithubID != "" {
+		userModel, _ = s.usersService.GetUserByGitHubUsername(contributor.GithubID)
+	}
+	if userModel == nil && contributor.Email != "" {
+		userModel, _ = s.usersService.GetUserByEmail(contributor.Email)
+	}
+	if userModel == nil {
+		return nil
+	}
+
+	iclaSig, err := s.repo.GetIndividualSignature(claGroupID, userModel.UserID)
+	if err != nil {
+		log.Warnf("unable to load the individual signature of user: %s, error: %+v", userModel.UserID, err)
+		return nil
+	}
+	return iclaSig
+}
+
+// corporateContributorCovered returns whether the corporate contributor of the company is covered under the contribution
+// policy of the CLA Group, with or without the approval list coverage of the company CCLA - the employee
+// acknowledgement of the contributor is for the company, or for a subsidiary covered by the CCLA of the company
+func corporateContributorCovered(claGroupModel *models.Project, companyModel *models.Company, contributor *models.CorporateContributor, iclaSig *models.Signature, listed bool) bool {
+	facts := &ContributionPolicyFacts{
+		IndividualSignature: iclaSig,
+		EmployeeSignatures: []*models.Signature{{
+			SignatureUserCompanyID: companyModel.CompanyID,
+			SignatureCreated:       contributor.Timestamp,
+		}},
+		UserCompanyID: companyModel.CompanyID,
+	}
+	if listed {
+		facts.CorporateSignatures = []*models.Signature{{SignatureReferenceID: companyModel.CompanyID}}
+	}
+	covered, _ := EvaluateContributionPolicy(claGroupModel, facts, time.Now())
+	return covered
+}
+
+// DetachCorporateContributor ends the employee signature of the authenticated contributor for the company and CLA Group,
+// removes the contributor email addresses and GitHub username from the CCLA approval lists and notifies the CLA Managers
+func (s service) DetachCorporateContributor(authUser *auth.User, projectModel *models.Project, companyModel *models.Company) error {
+	f := logrus.Fields{
+		"functionName": "DetachCorporateContributor",
+		"claGroupID":   projectModel.ProjectID,
+		"claGroupName": projectModel.ProjectName,
+		"companyID":    companyModel.CompanyID,
+		"companyName":  companyModel.CompanyName,
+		"userName":     authUser.UserName,
+	}
+
+	userModel, userErr := s.usersService.GetUserByLFUserName(authUser.UserName)
+	if userErr != nil || userModel == nil {
+		msg := fmt.Sprintf("unable to locate user by LF username: %s", authUser.UserName)
+		log.WithFields(f).Warn(msg)
+		return NewNotFoundError(msg)
+	}
+
+	employeeSignatures, err := s.repo.GetProjectCompanyEmployeeSignatures(signatures.GetProjectCompanyEmployeeSignaturesParams{
+		CompanyID: companyModel.CompanyID,
+		ProjectID: projectModel.ProjectID,
+	}, HugePageSize)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the employee signatures, error: %+v", err)
+		return err
+	}
+
+	var employeeSig *models.Signature
+	for _, sig := range employeeSignatures.Signatures {
+		if sig.SignatureReferenceID == userModel.UserID {
+			employeeSig = sig
+			break
+		}
+	}
+	if employeeSig == nil {
+		msg := fmt.Sprintf("unable to locate an employee signature for user: %s with company: %s and CLA Group: %s",
+			authUser.UserName, companyModel.CompanyName, projectModel.ProjectName)
+		log.WithFields(f).Warn(msg)
+		return NewNotFoundError(msg)
+	}
+
+	pageSize := int64(1)
+	signed, approved := true, true
+	cclaSig, err := s.repo.GetProjectCompanySignature(companyModel.CompanyID, projectModel.ProjectID, &signed, &approved, nil, &pageSize)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the corporate signature, error: %+v", err)
+		return err
+	}
+
+	// Remove the contributor entries from the approval lists through the regular update, which logs the approval list
+	// events and notifies the CLA Managers
+	approvalListChanges := &models.ApprovalList{}
+	if cclaSig != nil {
+		approvalListChanges = DetachApprovalListChanges(cclaSig, userModel)
+		if hasApprovalListRemovals(approvalListChanges) {
+			err = checkDomainApprovalListRemovals(cclaSig, approvalListChanges)
+			if err != nil {
+				return err
+			}
+			_, err = s.applyApprovalListUpdate(authUser, userModel, projectModel, companyModel, projectModel.ProjectID, cclaSig, approvalListChanges, false)
+			if err != nil {
+				log.WithFields(f).Warnf("unable to remove the contributor from the approval list, error: %+v", err)
+				return err
+			}
+		}
+	}
+
+	note := fmt.Sprintf("Signature invalidated (approved set to false) - contributor %s detached from the corporate CLA of company: %s",
+		authUser.UserName, companyModel.CompanyName)
+	err = s.repo.InvalidateSignature(employeeSig.SignatureID, note)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to invalidate employee signature: %s, error: %+v", employeeSig.SignatureID, err)
+		return err
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:         events.CorporateContributorDetached,
+		ProjectID:         projectModel.ProjectID,
+		ProjectModel:      projectModel,
+		CompanyID:         companyModel.CompanyID,
+		CompanyModel:      companyModel,
+		LfUsername:        userModel.LfUsername,
+		UserID:            userModel.UserID,
+		UserModel:         userModel,
+		ExternalProjectID: projectModel.ProjectExternalID,
+		EventData: &events.CorporateContributorDetachedEventData{
+			SignatureID:            employeeSig.SignatureID,
+			RemovedEmails:          approvalListChanges.RemoveEmailApprovalList,
+			RemovedGitHubUsernames: approvalListChanges.RemoveGithubUsernameApprovalList,
+		},
+	})
+
+	if cclaSig != nil {
+		for _, claManager := range cclaSig.SignatureACL {
+			sendCorporateContributorDetachedEmail(companyModel, projectModel, userModel, claManager.Username, getBestEmail(claManager), approvalListChanges)
+		}
+	}
+
+	return nil
+}
+
+// ExplainCoverage returns whether the contributor identity is covered by the CLA Group and the reasons - the ICLA and
+// employee acknowledgements of the matching user record and the approval list entries of each CCLA which match the
+// identity. The identity is covered when the reasons satisfy the contribution policy of the CLA Group.
+func (s service) ExplainCoverage(claGroupModel *models.Project, githubUsername, githubID, email string) (*models.CoverageExplanation, error) {
+	f := logrus.Fields{
+		"functionName":   "ExplainCoverage",
+		"claGroupID":     claGroupModel.ProjectID,
+		"claGroupName":   claGroupModel.ProjectName,
+		"githubUsername": githubUsername,
+		"githubID":       githubID,
+		"email":          email,
+	}
+
+	if githubUsername == "" && githubID == "" && email == "" {
+		return nil, NewBadRequestError("at least one of the GitHub username, GitHub ID or email address is required")
+	}
+
+	explanation := &models.CoverageExplanation{
+		ClaGroupID:         claGroupModel.ProjectID,
+		GithubUsername:     githubUsername,
+		GithubID:           githubID,
+		Email:              email,
+		ContributionPolicy: GetContributionPolicy(claGroupModel),
+	}
+
+	// Locate the user record - the GitHub ID is the most reliable identifier, followed by the GitHub username and email
+	var userModel *models.User
+	if githubID != "" {
+		userModel, _ = s.usersService.GetUserByUserName(fmt.Sprintf("github:%s", githubID), true)
+	}
+	if userModel == nil && githubUsername != "" {
+		userModel, _ = s.usersService.GetUserByGitHubUsername(githubUsername)
+	}
+	if userModel == nil && email != "" {
+		userModel, _ = s.usersService.GetUserByEmail(email)
+	}
+
+	var emails []string
+	if email != "" {
+		emails = append(emails, email)
+	}
+	var iclaSig *models.Signature
+	var userSignatures []*models.Signature
+	if userModel != nil {
+		log.WithFields(f).Debugf("identity matches user: %s", userModel.UserID)
+		explanation.UserID = userModel.UserID
+		for _, userEmail := range GetUserEmails(userModel) {
+			if !ContainsFold(emails, userEmail) {
+				emails = append(emails, userEmail)
+			}
+		}
+		if githubUsername == "" {
+			githubUsername = userModel.GithubUsername
+		}
+
+		var err error
+		iclaSig, err = s.repo.GetIndividualSignature(claGroupModel.ProjectID, userModel.UserID)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the individual signature of user: %s, error: %+v", userModel.UserID, err)
+			return nil, err
+		}
+
+		userSigs, err := s.repo.GetUserSignatures(signatures.GetUserSignaturesParams{
+			UserID:   userModel.UserID,
+			UserName: &userModel.Username,
+		}, HugePageSize)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the signatures of user: %s, error: %+v", userModel.UserID, err)
+			return nil, err
+		}
+		userSignatures = userSigs.Signatures
+	}
+
+	signatureType := CCLA
+	cclaSignatures, err := s.repo.GetProjectSignatures(signatures.GetProjectSignaturesParams{
+		ProjectID:     claGroupModel.ProjectID,
+		SignatureType: &signatureType,
+	}, HugePageSize)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the corporate signatures, error: %+v", err)
+		return nil, err
+	}
+
+	// Only query GitHub for the organizations of the user when one of the CCLAs has a GitHub organization approval
+	// list - when GitHub can not be reached, the explanation reports the check as incomplete
+	var githubOrgs []string
+	if githubUsername != "" {
+		for _, cclaSig := range cclaSignatures.Signatures {
+			if len(cclaSig.GithubOrgApprovalList) > 0 {
+				githubOrgs, err = github.GetUserOrganizations(githubUsername)
+				if err != nil {
+					log.WithFields(f).Warnf("unable to load the GitHub organizations of user: %s, error: %+v", githubUsername, err)
+					explanation.IncompleteChecks = append(explanation.IncompleteChecks, CoverageCheckGitHubOrgs)
+				}
+				break
+			}
+		}
+	}
+
+	var facts *ContributionPolicyFacts
+	explanation.Reasons, facts = BuildCoverageReasons(claGroupModel.ProjectID, userModel, iclaSig, userSignatures,
+		cclaSignatures.Signatures, emails, githubUsername, githubOrgs)
+
+	// The CCLA of a parent company covers the employees of the subsidiaries without a CCLA when the parent company opted in
+	cclaByCompany := signedCorporateSignaturesByCompany(cclaSignatures.Signatures)
+	for _, eclaSig := range facts.EmployeeSignatures {
+		if _, ok := cclaByCompany[eclaSig.SignatureUserCompanyID]; ok {
+			continue
+		}
+		parentCompany, parentCCLASig, parentErr := s.getParentCompanyCCLA(eclaSig.SignatureUserCompanyID, cclaByCompany)
+		if parentErr != nil {
+			log.WithFields(f).Warnf("unable to load the parent companies of company: %s, error: %+v", eclaSig.SignatureUserCompanyID, parentErr)
+			continue
+		}
+		if parentCompany == nil {
+			continue
+		}
+		if facts.ParentCompanyIDs == nil {
+			facts.ParentCompanyIDs = make(map[string]string)
+		}
+		facts.ParentCompanyIDs[eclaSig.SignatureUserCompanyID] = parentCompany.CompanyID
+		explanation.Reasons = append(explanation.Reasons, &models.CoverageReason{
+			Reason:        CoverageReasonParentCCLA,
+			SignatureID:   parentCCLASig.SignatureID,
+			SignatureType: CCLA,
+			CompanyID:     parentCompany.CompanyID,
+			CompanyName:   parentCompany.CompanyName,
+			MatchedValue:  eclaSig.SignatureUserCompanyID,
+		})
+	}
+
+	explanation.Covered, explanation.PolicyDetail = EvaluateContributionPolicy(claGroupModel, facts, time.Now())
+	if !explanation.Covered && len(explanation.IncompleteChecks) > 0 {
+		explanation.PolicyDetail = fmt.Sprintf("%s - the identity may still be covered, the %s checks could not run",
+			explanation.PolicyDetail, strings.Join(explanation.IncompleteChecks, ", "))
+	}
+	log.WithFields(f).Debugf("identity covered: %t with %d reasons under contribution policy: %s",
+		explanation.Covered, len(explanation.Reasons), explanation.ContributionPolicy)
+	return explanation, nil
+}
+
+// getParentCompanyCCLA returns the nearest parent company whose CCLA covers the employees of the company and its CCLA -
+// nil when none of the parent companies covers the company
+func (s service) getParentCompanyCCLA(companyID string, cclaByCompany map[string]*models.Signature) (*models.Company, *models.Signature, error) {
+	companyModel, err := s.companyService.GetCompany(companyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	coveringCompanies, err := s.companyService.GetCCLACoveringCompanies(companyModel)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, coveringCompany := range coveringCompanies {
+		if cclaSig, ok := cclaByCompany[coveringCompany.CompanyID]; ok {
+			return coveringCompany, cclaSig, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// getCCLACoveredSubsidiaryContributors returns the corporate contributors of the subsidiaries whose employees are
+// covered by the CCLA of the company
+func (s service) getCCLACoveredSubsidiaryContributors(companyModel *models.Company, claGroupID string) ([]*models.CorporateContributor, error) {
+	signatureType := CCLA
+	cclaSignatures, err := s.repo.GetProjectSignatures(signatures.GetProjectSignaturesParams{
+		ProjectID:     claGroupID,
+		SignatureType: &signatureType,
+	}, HugePageSize)
+	if err != nil {
+		return nil, err
+	}
+	cclaByCompany := signedCorporateSignaturesByCompany(cclaSignatures.Signatures)
+
+	subsidiaries, err := s.companyService.GetCCLACoveredSubsidiaries(companyModel, func(companyID string) bool {
+		_, ok := cclaByCompany[companyID]
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var contributors []*models.CorporateContributor
+	for _, subsidiary := range subsidiaries {
+		subsidiaryContributors, err := s.repo.GetClaGroupCorporateContributors(claGroupID, &subsidiary.CompanyID, nil)
+		if err != nil {
+			return nil, err
+		}
+		contributors = append(contributors, subsidiaryContributors.List...)
+	}
+	return contributors, nil
+}
+
+// signedCorporateSignaturesByCompany returns the signed and approved corporate signatures by company ID
+func signedCorporateSignaturesByCompany(cclaSignatures []*models.Signature) map[string]*models.Signature {
+	cclaByCompany := make(map[string]*models.Signature)
+	for _, cclaSig := range cclaSignatures {
+		if cclaSig.SignatureSigned && cclaSig.SignatureApproved {
+			cclaByCompany[cclaSig.SignatureReferenceID] = cclaSig
+		}
+	}
+	return cclaByCompany
+}
+
+// hasApprovalListRemovals returns true if the approval list update removes one or more entries
+func hasApprovalListRemovals(params *models.ApprovalList) bool {
+	return len(params.RemoveEmailApprovalList) > 0 || len(params.RemoveDomainApprovalList) > 0 ||
+		len(params.RemoveGithubUsernameApprovalList) > 0 || len(params.RemoveGithubOrgApprovalList) > 0
+}
+
+// invalidateUncoveredEmployeeSignatures invalidates the employee signatures of the company which were covered by the
+// approval list before the update but are no longer covered after the update - returns the affected contributors
+func (s service) invalidateUncoveredEmployeeSignatures(companyModel *models.Company, projectModel *models.Project, userModel *models.User, claGroupID string, previousSig, updatedSig *models.Signature) []*models.InvalidatedContributor {
+	f := logrus.Fields{
+		"functionName": "invalidateUncoveredEmployeeSignatures",
+		"claGroupID":   claGroupID,
+		"companyID":    companyModel.CompanyID,
+		"companyName":  companyModel.CompanyName,
+	}
+
+	invalidatedContributors := make([]*models.InvalidatedContributor, 0)
+	employeeSignatures, err := s.repo.GetProjectCompanyEmployeeSignatures(signatures.GetProjectCompanyEmployeeSignaturesParams{
+		CompanyID: companyModel.CompanyID,
+		ProjectID: claGroupID,
+	}, HugePageSize)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the employee signatures, error: %+v", err)
+		return invalidatedContributors
+	}
+
+	for _, employeeSig := range employeeSignatures.Signatures {
+		employeeModel, userErr := s.usersService.GetUser(employeeSig.SignatureReferenceID)
+		if userErr != nil || employeeModel == nil {
+			log.WithFields(f).Warnf("unable to lookup user by id: %s for employee signature: %s, error: %+v",
+				employeeSig.SignatureReferenceID, employeeSig.SignatureID, userErr)
+			continue
+		}
+
+		emails := GetUserEmails(employeeModel)
+		var githubOrgs []string
+		if len(previousSig.GithubOrgApprovalList) > 0 && employeeModel.GithubUsername != "" {
+			githubOrgs, err = github.GetUserOrganizations(employeeModel.GithubUsername)
+			if err != nil {
+				// Without the organization membership we can't tell if the contributor is still covered - leave as is
+				log.WithFields(f).Warnf("unable to lookup the GitHub organizations of user: %s - skipping employee signature: %s, error: %+v",
+					employeeModel.GithubUsername, employeeSig.SignatureID, err)
+				continue
+			}
+		}
+
+		if GetApprovalListCoverage(previousSig, emails, employeeModel.GithubUsername, githubOrgs) == "" ||
+			GetApprovalListCoverage(updatedSig, emails, employeeModel.GithubUsername, githubOrgs) != "" {
+			continue
+		}
+
+		note := fmt.Sprintf("Signature invalidated (approved set to false) due to removal from the approval list of company: %s by %s",
+			companyModel.CompanyName, userModel.LfUsername)
+		err = s.repo.InvalidateSignature(employeeSig.SignatureID, note)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to invalidate employee signature: %s, error: %+v", employeeSig.SignatureID, err)
+			continue
+		}
+
+		employeeEmail := getBestEmail(*employeeModel)
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:         events.InvalidatedSignature,
+			ProjectID:         projectModel.ProjectID,
+			ProjectModel:      projectModel,
+			CompanyID:         companyModel.CompanyID,
+			CompanyModel:      companyModel,
+			LfUsername:        userModel.LfUsername,
+			UserID:            userModel.UserID,
+			UserModel:         userModel,
+			ExternalProjectID: projectModel.ProjectExternalID,
+			EventData: &events.SignatureApprovalListInvalidatedEventData{
+				SignatureID: employeeSig.SignatureID,
+				UserName:    employeeModel.Username,
+				UserLFID:    employeeModel.LfUsername,
+				UserEmail:   employeeEmail,
+			},
+		})
+
+		sendEmployeeSignatureInvalidatedEmail(companyModel, projectModel, employeeModel.Username, employeeEmail)
+
+		invalidatedContributors = append(invalidatedContributors, &models.InvalidatedContributor{
+			SignatureID:    employeeSig.SignatureID,
+			UserID:         employeeModel.UserID,
+			UserName:       employeeModel.Username,
+			LfUsername:     employeeModel.LfUsername,
+			GithubUsername: employeeModel.GithubUsername,
+			UserEmail:      employeeEmail,
+		})
+	}
+
+	log.WithFields(f).Debugf("invalidated %d employee signatures", len(invalidatedContributors))
+	return invalidatedContributors
 }
 
 // Disassociate project signatures
@@ -456,6 +1124,11 @@ func (s service) RemoveCLAManager(signatureID, claManagerID string) (*models.Sig
 	return s.repo.RemoveCLAManager(signatureID, claManagerID)
 }
 
+// TransferCLAManager replaces the specified manager with another user in the signature ACL list
+func (s service) TransferCLAManager(signatureID, fromClaManagerID, toClaManagerID string) (*models.Signature, error) {
+	return s.repo.TransferCLAManager(signatureID, fromClaManagerID, toClaManagerID)
+}
+
 // appendList is a helper function to generate the email content of the Approval List changes
 func appendList(approvalList []string, message string) string {
 	approvalListSummary := ""
@@ -515,7 +1188,15 @@ the EasyCLA system.</p>
 		recipientName, projectName, companyName, projectName, buildApprovalListSummary(approvalListChanges), projectName,
 		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
 
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    recipientName,
+		RecipientAddress: recipientAddress,
+		CompanyName:      companyName,
+		ClaGroupName:     projectName,
+		Summary:          buildApprovalListSummary(approvalListChanges),
+	})
 	if err != nil {
 		log.WithFields(f).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
@@ -772,6 +1453,270 @@ func (s service) GetClaGroupCorporateContributors(claGroupID string, companyID *
 	return s.repo.GetClaGroupCorporateContributors(claGroupID, companyID, searchTerm)
 }
 
+// GetClaGroupOutdatedSignatures returns the signed and approved signatures of the CLA Group which were signed against
+// an older document major version - the signature type filter is optional and is one of icla, ecla or ccla
+func (s service) GetClaGroupOutdatedSignatures(claGroupModel *models.Project, signatureType string) ([]*models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":  "GetClaGroupOutdatedSignatures",
+		"claGroupID":    claGroupModel.ProjectID,
+		"claGroupName":  claGroupModel.ProjectName,
+		"signatureType": signatureType,
+	}
+
+	outdatedSignatures := make([]*models.Signature, 0)
+	var nextKey *string
+	for {
+		claGroupSignatures, err := s.repo.GetProjectSignatures(signatures.GetProjectSignaturesParams{
+			ProjectID: claGroupModel.ProjectID,
+			NextKey:   nextKey,
+		}, HugePageSize)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the CLA Group signatures, error: %+v", err)
+			return nil, err
+		}
+
+		for _, sig := range claGroupSignatures.Signatures {
+			if signatureType != "" && signatureType != GetSignatureClass(sig) {
+				continue
+			}
+			if IsOutdatedSignature(claGroupModel, sig) {
+				outdatedSignatures = append(outdatedSignatures, sig)
+			}
+		}
+
+		if claGroupSignatures.LastKeyScanned == "" {
+			break
+		}
+		nextKey = aws.String(claGroupSignatures.LastKeyScanned)
+	}
+
+	log.WithFields(f).Debugf("found %d outdated signatures", len(outdatedSignatures))
+	return outdatedSignatures, nil
+}
+
+// NotifyOutdatedSignatures logs an event and notifies the contributors (ICLA/ECLA) or the CLA Managers (CCLA) of each
+// outdated signature when the CLA Group requires a re-sign - nothing is done for grandfathered signatures. The
+// notifications are sent by the resign lambda, the user is the one who published the new document major version.
+func (s service) NotifyOutdatedSignatures(lfUsername string, claGroupModel *models.Project) error {
+	f := logrus.Fields{
+		"functionName":   "NotifyOutdatedSignatures",
+		"claGroupID":     claGroupModel.ProjectID,
+		"claGroupName":   claGroupModel.ProjectName,
+		"resignPolicy":   claGroupModel.ProjectResignPolicy,
+		"resignDeadline": claGroupModel.ProjectResignDeadline,
+	}
+
+	if claGroupModel.ProjectResignPolicy != ResignPolicyResign {
+		log.WithFields(f).Debug("existing signatures are grandfathered - no re-sign required")
+		return nil
+	}
+
+	outdatedSignatures, err := s.GetClaGroupOutdatedSignatures(claGroupModel, "")
+	if err != nil {
+		return err
+	}
+
+	for _, sig := range outdatedSignatures {
+		signatureClass := GetSignatureClass(sig)
+		currentMajorVersion := GetClaGroupDocumentMajorVersion(claGroupModel, signatureClass)
+
+		companyID := sig.SignatureUserCompanyID
+		if signatureClass == CCLA {
+			companyID = sig.SignatureReferenceID
+		}
+
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:         events.SignatureResignRequired,
+			ProjectID:         claGroupModel.ProjectID,
+			ProjectModel:      claGroupModel,
+			CompanyID:         companyID,
+			LfUsername:        lfUsername,
+			ExternalProjectID: claGroupModel.ProjectExternalID,
+			EventData: &events.SignatureResignRequiredEventData{
+				SignatureID:           sig.SignatureID,
+				SignatureType:         signatureClass,
+				SignatureMajorVersion: sig.SignatureMajorVersion,
+				CurrentMajorVersion:   currentMajorVersion,
+				ResignDeadline:        claGroupModel.ProjectResignDeadline,
+			},
+		})
+
+		if signatureClass == CCLA {
+			for _, claManager := range sig.SignatureACL {
+				sendResignRequiredEmail(claGroupModel, sig, signatureClass, currentMajorVersion, claManager.Username, getBestEmail(claManager))
+			}
+			continue
+		}
+
+		userModel, userErr := s.usersService.GetUser(sig.SignatureReferenceID)
+		if userErr != nil || userModel == nil {
+			log.WithFields(f).Warnf("unable to lookup user by id: %s for signature: %s, error: %+v",
+				sig.SignatureReferenceID, sig.SignatureID, userErr)
+			continue
+		}
+		sendResignRequiredEmail(claGroupModel, sig, signatureClass, currentMajorVersion, userModel.Username, getBestEmail(*userModel))
+	}
+
+	return nil
+}
+
+// EnforceResignDeadline invalidates the outdated signatures of the CLA Group once the re-sign deadline has passed, so
+// the contributors are no longer covered by a signature of an older document major version
+func (s service) EnforceResignDeadline(claGroupModel *models.Project, now time.Time) error {
+	f := logrus.Fields{
+		"functionName":   "EnforceResignDeadline",
+		"claGroupID":     claGroupModel.ProjectID,
+		"claGroupName":   claGroupModel.ProjectName,
+		"resignDeadline": claGroupModel.ProjectResignDeadline,
+	}
+
+	if !IsResignOverdue(claGroupModel, now) {
+		return nil
+	}
+
+	outdatedSignatures, err := s.GetClaGroupOutdatedSignatures(claGroupModel, "")
+	if err != nil {
+		return err
+	}
+
+	for _, sig := range outdatedSignatures {
+		signatureClass := GetSignatureClass(sig)
+		note := fmt.Sprintf("Signature invalidated (approved set to false) as it was not re-signed against version %d by the re-sign deadline: %s",
+			GetClaGroupDocumentMajorVersion(claGroupModel, signatureClass), claGroupModel.ProjectResignDeadline)
+		err = s.repo.InvalidateSignature(sig.SignatureID, note)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to invalidate outdated signature: %s, error: %+v", sig.SignatureID, err)
+			continue
+		}
+
+		companyID := sig.SignatureUserCompanyID
+		if signatureClass == CCLA {
+			companyID = sig.SignatureReferenceID
+		}
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:         events.SignatureResignOverdueInvalidated,
+			ProjectID:         claGroupModel.ProjectID,
+			ProjectModel:      claGroupModel,
+			CompanyID:         companyID,
+			LfUsername:        easyCLASystemLFUsername,
+			ExternalProjectID: claGroupModel.ProjectExternalID,
+			EventData: &events.SignatureResignOverdueInvalidatedEventData{
+				SignatureID:           sig.SignatureID,
+				SignatureType:         signatureClass,
+				SignatureMajorVersion: sig.SignatureMajorVersion,
+				ResignDeadline:        claGroupModel.ProjectResignDeadline,
+			},
+		})
+	}
+
+	log.WithFields(f).Debugf("invalidated %d outdated signatures", len(outdatedSignatures))
+	return nil
+}
+
+// sendResignRequiredEmail sends the re-sign required email for the outdated signature to the specified recipient
+func sendResignRequiredEmail(claGroupModel *models.Project, sig *models.Signature, signatureClass string, currentMajorVersion int, recipientName, recipientAddress string) {
+	f := logrus.Fields{
+		"functionName":     "sendResignRequiredEmail",
+		"claGroupID":       claGroupModel.ProjectID,
+		"signatureID":      sig.SignatureID,
+		"signatureType":    signatureClass,
+		"recipientName":    recipientName,
+		"recipientAddress": recipientAddress,
+	}
+
+	if recipientAddress == "" {
+		log.WithFields(f).Warn("unable to send re-sign email - recipient email address is empty")
+		return
+	}
+
+	projectName := claGroupModel.ProjectName
+	agreement := "Individual Contributor License Agreement"
+	if signatureClass == CCLA {
+		agreement = fmt.Sprintf("Corporate Contributor License Agreement signed on behalf of %s", sig.CompanyName)
+	} else if signatureClass == ECLA {
+		agreement = fmt.Sprintf("Corporate Contributor License Agreement acknowledgement for %s", sig.CompanyName)
+	}
+
+	deadline := "as soon as possible"
+	if claGroupModel.ProjectResignDeadline != "" {
+		deadline = fmt.Sprintf("by %s", claGroupModel.ProjectResignDeadline)
+	}
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: Re-sign Required for %s", projectName)
+	recipients := []string{recipientAddress}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>A new version %d of the %s documents was published. Your %s was signed against version %s.%s.</p>
+<p>Please re-sign the agreement %s. Contributions to %s may be blocked once the re-sign deadline has passed.</p>
+%s
+%s`,
+		recipientName, projectName, currentMajorVersion, projectName, agreement,
+		sig.SignatureMajorVersion, sig.SignatureMinorVersion, deadline, projectName,
+		utils.GetEmailHelpContent(claGroupModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.WithFields(f).Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.WithFields(f).Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
+
+// GetSignatureClass returns the class of the signature - one of icla, ecla or ccla
+func GetSignatureClass(sig *models.Signature) string {
+	if sig.SignatureType == CCLA {
+		return CCLA
+	}
+	if sig.SignatureUserCompanyID != "" {
+		return ECLA
+	}
+	return ICLA
+}
+
+// GetClaGroupDocumentMajorVersion returns the current document major version the signature class is signed against,
+// ICLAs are signed against the individual documents, ECLAs and CCLAs against the corporate documents
+func GetClaGroupDocumentMajorVersion(claGroupModel *models.Project, signatureClass string) int {
+	documents := claGroupModel.ProjectCorporateDocuments
+	if signatureClass == ICLA {
+		documents = claGroupModel.ProjectIndividualDocuments
+	}
+
+	majorVersion := 0
+	for _, document := range documents {
+		documentMajorVersion, err := strconv.Atoi(document.DocumentMajorVersion)
+		if err == nil && documentMajorVersion > majorVersion {
+			majorVersion = documentMajorVersion
+		}
+	}
+	return majorVersion
+}
+
+// IsOutdatedSignature returns true if the signature was signed against an older document major version of the CLA Group
+func IsOutdatedSignature(claGroupModel *models.Project, sig *models.Signature) bool {
+	signatureMajorVersion, err := strconv.Atoi(sig.SignatureMajorVersion)
+	if err != nil {
+		log.Warnf("unable to parse major version: %s of signature: %s - skipping", sig.SignatureMajorVersion, sig.SignatureID)
+		return false
+	}
+	return signatureMajorVersion < GetClaGroupDocumentMajorVersion(claGroupModel, GetSignatureClass(sig))
+}
+
+// IsResignOverdue returns true if the CLA Group requires a re-sign of outdated signatures and the deadline has passed
+func IsResignOverdue(claGroupModel *models.Project, now time.Time) bool {
+	if claGroupModel.ProjectResignPolicy != ResignPolicyResign || claGroupModel.ProjectResignDeadline == "" {
+		return false
+	}
+	deadline, err := utils.ParseDateTime(claGroupModel.ProjectResignDeadline)
+	if err != nil {
+		log.Warnf("unable to parse resign deadline: %s of CLA Group: %s, error: %+v",
+			claGroupModel.ProjectResignDeadline, claGroupModel.ProjectID, err)
+		return false
+	}
+	return now.After(deadline)
+}
+
 // sendRequestAccessEmailToContributors sends the request access email to the specified contributors
 func sendRequestAccessEmailToContributorRecipient(authUser *auth.User, companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress, addRemove, toFrom, authorizedString string) {
 	companyName := companyModel.CompanyName
@@ -800,6 +1745,77 @@ close and re-open the pull request to force a recheck by the EasyCLA system.</p>
 	}
 }
 
+// sendEmployeeSignatureInvalidatedEmail notifies the contributor that their employee signature was invalidated
+func sendEmployeeSignatureInvalidatedEmail(companyModel *models.Company, projectModel *models.Project, recipientName, recipientAddress string) {
+	if recipientAddress == "" {
+		log.Warnf("unable to send employee signature invalidated email to %s - recipient email address is empty", recipientName)
+		return
+	}
+
+	companyName := companyModel.CompanyName
+	projectName := projectModel.ProjectName
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: Employee Acknowledgement Invalidated for %s on %s", companyName, projectName)
+	recipients := []string{recipientAddress}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>You were removed from the Approval List of %s for %s and are no longer covered by the %s Corporate CLA. Your
+employee acknowledgement was invalidated and you are no longer authorized to contribute to %s on behalf of %s.</p>
+<p>If you believe this is in error, please contact the CLA Managers of %s. You can also sign the CLA with another
+company or as an individual.</p>
+%s
+%s`,
+		recipientName, projectName, companyName, projectName, companyName, projectName, companyName, companyName,
+		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
+
+// sendCorporateContributorDetachedEmail notifies the CLA Manager that a contributor detached from the company CCLA
+func sendCorporateContributorDetachedEmail(companyModel *models.Company, projectModel *models.Project, contributorModel *models.User, recipientName, recipientAddress string, approvalListChanges *models.ApprovalList) {
+	if recipientAddress == "" {
+		log.Warnf("unable to send corporate contributor detached email to %s - recipient email address is empty", recipientName)
+		return
+	}
+
+	companyName := companyModel.CompanyName
+	projectName := projectModel.ProjectName
+	contributorName := contributorModel.Username
+	if contributorName == "" {
+		contributorName = contributorModel.LfUsername
+	}
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: Contributor Detached from %s on %s", companyName, projectName)
+	recipients := []string{recipientAddress}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>The contributor %s (%s) ended their employee acknowledgement of the %s Corporate CLA for %s and is no longer
+authorized to contribute on behalf of %s.</p>
+<p>The Approval List of %s for %s was modified as follows:</p>
+%s
+%s
+%s`,
+		recipientName, projectName, contributorName, contributorModel.LfUsername, companyName, projectName, companyName,
+		companyName, projectName, buildApprovalListSummary(approvalListChanges),
+		utils.GetEmailHelpContent(projectModel.Version == utils.V2), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+	} else {
+		log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+	}
+}
+
 // getBestEmail is a helper function to return the best email address for the user model
 func getBestEmail(claManager models.User) string {
 	if claManager.LfEmail != "" {
@@ -18,6 +18,9 @@ type DBProjectModel struct {
 	ProjectIndividualDocuments       []DBProjectDocumentModel `dynamodbav:"project_individual_documents"`
 	ProjectMemberDocuments           []DBProjectDocumentModel `dynamodbav:"project_member_documents"`
 	ProjectACL                       []string                 `dynamodbav:"project_acl"`
+	ProjectTemplateID                string                   `dynamodbav:"project_template_id"`
+	ProjectTemplateMetaFields        map[string]string        `dynamodbav:"project_template_meta_fields"`
+	ProjectTemplateContentHash       string                   `dynamodbav:"project_template_content_hash"`
 }
 
 // DBProjectDocumentModel is a data model for the CLA Group Project documents
@@ -33,3 +36,9 @@ type DBProjectDocumentModel struct {
 	DocumentMinorVersion    string `dynamodbav:"document_minor_version"`
 	DocumentCreationDate    string `dynamodbav:"document_creation_date"`
 }
+
+// DocumentVersion is the major/minor version of the CLA Group documents
+type DocumentVersion struct {
+	MajorVersion int
+	MinorVersion int
+}
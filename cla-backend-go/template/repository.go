@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
 )
 
 var (
@@ -29,12 +31,24 @@ var (
 	ApacheStyleTemplateID = "fb4cc144-a76c-4c17-8a52-c648f158fded"
 )
 
+// claGroupDocumentAttributes are the attributes of a CLA Group holding the document lists
+var claGroupDocumentAttributes = []string{
+	"project_corporate_documents",
+	"project_individual_documents",
+	"project_member_documents",
+}
+
 // Repository interface functions
 type Repository interface {
 	GetTemplates() ([]models.Template, error)
 	GetTemplate(templateID string) (models.Template, error)
 	GetCLAGroup(claGroupID string) (*models.Project, error)
-	UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool) error
+	GetCLAGroupDocumentVersion(claGroupID string) (*DocumentVersion, string, error)
+	UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool, documentVersion DocumentVersion) error
+	GetCLAGroupDocumentAttributes(claGroupID string) (map[string]*dynamodb.AttributeValue, error)
+	UpdateCLAGroupDocumentAttributes(claGroupID string, documents map[string]*dynamodb.AttributeValue) error
+	GetCLAGroupTemplateFields(claGroupID string) (string, map[string]string, error)
+	UpdateCLAGroupTemplateFields(claGroupID string, templateID string, metaFields map[string]string, contentHash string) error
 }
 
 type repository struct {
@@ -153,6 +167,215 @@ func (r repository) GetCLAGroup(claGroupID string) (*models.Project, error) {
 	return r.buildProjectModel(dbModel), nil
 }
 
+// GetCLAGroupDocumentVersion returns the highest document version across the individual and corporate documents
+// of the CLA Group and the content hash of the documents, returns nil if the CLA Group does not have any documents yet
+func (r repository) GetCLAGroupDocumentVersion(claGroupID string) (*DocumentVersion, string, error) {
+	var dbModel DBProjectModel
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	result, err := r.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("error getting CLAGroup: %s, error: %+v", claGroupID, err)
+		return nil, "", err
+	}
+
+	err = dynamodbattribute.UnmarshalMap(result.Item, &dbModel)
+	if err != nil {
+		log.Warnf("error unmarshalling db project model, error: %+v", err)
+		return nil, "", err
+	}
+
+	var documentVersion *DocumentVersion
+	documents := append(dbModel.ProjectIndividualDocuments, dbModel.ProjectCorporateDocuments...)
+	for _, document := range documents {
+		majorVersion, majorErr := strconv.Atoi(document.DocumentMajorVersion)
+		minorVersion, minorErr := strconv.Atoi(document.DocumentMinorVersion)
+		if majorErr != nil || minorErr != nil {
+			log.Warnf("unable to parse document version %s.%s for CLA Group: %s - skipping",
+				document.DocumentMajorVersion, document.DocumentMinorVersion, claGroupID)
+			continue
+		}
+		if documentVersion == nil || majorVersion > documentVersion.MajorVersion ||
+			(majorVersion == documentVersion.MajorVersion && minorVersion > documentVersion.MinorVersion) {
+			documentVersion = &DocumentVersion{MajorVersion: majorVersion, MinorVersion: minorVersion}
+		}
+	}
+
+	return documentVersion, dbModel.ProjectTemplateContentHash, nil
+}
+
+// GetCLAGroupDocumentAttributes returns the raw document list attributes of the CLA Group - the raw values keep
+// the document tabs and any attributes the API models do not map
+func (r repository) GetCLAGroupDocumentAttributes(claGroupID string) (map[string]*dynamodb.AttributeValue, error) {
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	result, err := r.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("error getting CLAGroup: %s, error: %+v", claGroupID, err)
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, fmt.Errorf("cla group: %s not found", claGroupID)
+	}
+
+	documents := map[string]*dynamodb.AttributeValue{}
+	for _, attribute := range claGroupDocumentAttributes {
+		if value, ok := result.Item[attribute]; ok && value.L != nil {
+			documents[attribute] = value
+		}
+	}
+	return documents, nil
+}
+
+// UpdateCLAGroupDocumentAttributes replaces the document list attributes of the CLA Group with the raw values
+func (r repository) UpdateCLAGroupDocumentAttributes(claGroupID string, documents map[string]*dynamodb.AttributeValue) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	// The raw values are set as is - the expression builder would marshal them as structs
+	_, now := utils.CurrentTime()
+	expressionAttributeNames := map[string]*string{"#M": aws.String("date_modified")}
+	expressionAttributeValues := map[string]*dynamodb.AttributeValue{":m": {S: aws.String(now)}}
+	updateExpression := "SET #M = :m"
+	for i, attribute := range claGroupDocumentAttributes {
+		value, ok := documents[attribute]
+		if !ok {
+			continue
+		}
+		expressionAttributeNames[fmt.Sprintf("#D%d", i)] = aws.String(attribute)
+		expressionAttributeValues[fmt.Sprintf(":d%d", i)] = value
+		updateExpression = fmt.Sprintf("%s, #D%d = :d%d", updateExpression, i, i)
+	}
+
+	_, err := r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames:  expressionAttributeNames,
+		ExpressionAttributeValues: expressionAttributeValues,
+		UpdateExpression:          aws.String(updateExpression),
+		TableName:                 aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("error updating the documents of CLA Group: %s, error: %+v", claGroupID, err)
+		return err
+	}
+	return nil
+}
+
+// GetCLAGroupTemplateFields returns the template ID and the template meta field values, keyed by template variable,
+// the documents of the CLA Group were last generated with. CLA Groups created before the fields were recorded only
+// return the template ID of their latest document.
+func (r repository) GetCLAGroupTemplateFields(claGroupID string) (string, map[string]string, error) {
+	var dbModel DBProjectModel
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	result, err := r.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("error getting CLAGroup: %s, error: %+v", claGroupID, err)
+		return "", nil, err
+	}
+	if len(result.Item) == 0 {
+		return "", nil, fmt.Errorf("cla group: %s not found", claGroupID)
+	}
+
+	err = dynamodbattribute.UnmarshalMap(result.Item, &dbModel)
+	if err != nil {
+		log.Warnf("error unmarshalling db project model, error: %+v", err)
+		return "", nil, err
+	}
+
+	metaFields := dbModel.ProjectTemplateMetaFields
+	if metaFields == nil {
+		metaFields = map[string]string{}
+	}
+	if dbModel.ProjectTemplateID != "" {
+		return dbModel.ProjectTemplateID, metaFields, nil
+	}
+
+	var templateID string
+	var latestVersion *DocumentVersion
+	documents := append(dbModel.ProjectIndividualDocuments, dbModel.ProjectCorporateDocuments...)
+	for _, document := range documents {
+		majorVersion, majorErr := strconv.Atoi(document.DocumentMajorVersion)
+		minorVersion, minorErr := strconv.Atoi(document.DocumentMinorVersion)
+		if majorErr != nil || minorErr != nil {
+			continue
+		}
+		if latestVersion == nil || majorVersion > latestVersion.MajorVersion ||
+			(majorVersion == latestVersion.MajorVersion && minorVersion > latestVersion.MinorVersion) {
+			latestVersion = &DocumentVersion{MajorVersion: majorVersion, MinorVersion: minorVersion}
+			templateID = document.DocumentFileID
+		}
+	}
+	return templateID, metaFields, nil
+}
+
+// UpdateCLAGroupTemplateFields records the template ID and the template meta field values, keyed by template
+// variable, the documents of the CLA Group were generated with
+func (r repository) UpdateCLAGroupTemplateFields(claGroupID string, templateID string, metaFields map[string]string, contentHash string) error {
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	metaFieldsValue, err := dynamodbattribute.Marshal(metaFields)
+	if err != nil {
+		log.Warnf("error marshalling the template meta fields of CLA Group: %s, error: %+v", claGroupID, err)
+		return err
+	}
+
+	_, now := utils.CurrentTime()
+	_, err = r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		ExpressionAttributeNames: map[string]*string{
+			"#T": aws.String("project_template_id"),
+			"#F": aws.String("project_template_meta_fields"),
+			"#H": aws.String("project_template_content_hash"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":t": {S: aws.String(templateID)},
+			":f": metaFieldsValue,
+			":h": {S: aws.String(contentHash)},
+			":m": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #T = :t, #F = :f, #H = :h, #M = :m"),
+		TableName:        aws.String(tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+	})
+	if err != nil {
+		log.Warnf("error updating the template fields of CLA Group: %s, error: %+v", claGroupID, err)
+		return err
+	}
+	return nil
+}
+
 // buildProjectModel maps the database model to the API response model
 func (r repository) buildProjectModel(dbModel DBProjectModel) *models.Project {
 	return &models.Project{
@@ -170,7 +393,7 @@ func (r repository) buildProjectModel(dbModel DBProjectModel) *models.Project {
 }
 
 // UpdateDynamoContractGroupTemplates updates the templates in the data store
-func (r repository) UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool) error {
+func (r repository) UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool, documentVersion DocumentVersion) error {
 	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
 	// Find Contract Group to update the Templates on
 	key := map[string]*dynamodb.AttributeValue{
@@ -211,8 +434,8 @@ func (r repository) UpdateDynamoContractGroupTemplates(ctx context.Context, Cont
 			DocumentName:            template.Name,
 			DocumentFileID:          template.ID,
 			DocumentContentType:     "storage+pdf",
-			DocumentMajorVersion:    2,
-			DocumentMinorVersion:    0,
+			DocumentMajorVersion:    documentVersion.MajorVersion,
+			DocumentMinorVersion:    documentVersion.MinorVersion,
 			DocumentCreationDate:    currentTime,
 			DocumentPreamble:        template.Name,
 			DocumentLegalEntityName: template.Name,
@@ -282,8 +505,8 @@ func (r repository) UpdateDynamoContractGroupTemplates(ctx context.Context, Cont
 			DocumentName:            template.Name,
 			DocumentFileID:          template.ID,
 			DocumentContentType:     "storage+pdf",
-			DocumentMajorVersion:    2,
-			DocumentMinorVersion:    0,
+			DocumentMajorVersion:    documentVersion.MajorVersion,
+			DocumentMinorVersion:    documentVersion.MinorVersion,
 			DocumentCreationDate:    currentTime,
 			DocumentPreamble:        template.Name,
 			DocumentLegalEntityName: template.Name,
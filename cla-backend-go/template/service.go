@@ -5,10 +5,14 @@ package template
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
+	"strings"
 
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 
@@ -17,6 +21,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/aymerick/raymond"
 )
@@ -26,6 +32,8 @@ type Service interface {
 	GetTemplates(ctx context.Context) ([]models.Template, error)
 	CreateCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate) (models.TemplatePdfs, error)
 	CreateTemplatePreview(claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error)
+	CopyCLAGroupTemplate(ctx context.Context, sourceCLAGroupID, claGroupID string) error
+	GetCLAGroupTemplateFields(claGroupID string) (*models.CreateClaGroupTemplate, error)
 }
 
 type service struct {
@@ -197,16 +205,185 @@ func (s service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		}
 	}
 
+	// Determine the version of the new documents based on the current documents of the CLA Group - the version is
+	// only bumped when the generated documents differ from the current ones
+	currentVersion, currentContentHash, err := s.templateRepo.GetCLAGroupDocumentVersion(claGroupID)
+	if err != nil {
+		log.Warnf("Problem loading the current document version for CLA Group: %s, error: %v - returning empty template PDFs", claGroupID, err)
+		return models.TemplatePdfs{}, err
+	}
+	contentHash := TemplateContentHash(iclaTemplateHTML, cclaTemplateHTML, claGroup.ProjectICLAEnabled, claGroup.ProjectCCLAEnabled)
+	documentVersion := NextDocumentVersion(currentVersion, claGroupFields.NewMajorVersion, contentHash != currentContentHash)
+	log.Debugf("Saving CLA Group: %s templates with document version: %d.%d",
+		claGroupID, documentVersion.MajorVersion, documentVersion.MinorVersion)
+
 	// Save Template to DynamoDB
-	err = s.templateRepo.UpdateDynamoContractGroupTemplates(ctx, claGroupID, template, pdfUrls, claGroup.ProjectCCLAEnabled, claGroup.ProjectICLAEnabled)
+	err = s.templateRepo.UpdateDynamoContractGroupTemplates(ctx, claGroupID, template, pdfUrls, claGroup.ProjectCCLAEnabled, claGroup.ProjectICLAEnabled, documentVersion)
 	if err != nil {
 		log.Warnf("Problem updating the database with ICLA/CCLA new PDF details, error: %v - returning empty template PDFs", err)
 		return models.TemplatePdfs{}, err
 	}
 
+	// Record the fields the documents were generated with, so the CLA Group configuration can be exported
+	metaFieldValues := map[string]string{}
+	for _, metaField := range claGroupFields.MetaFields {
+		metaFieldValues[metaField.TemplateVariable] = metaField.Value
+	}
+	err = s.templateRepo.UpdateCLAGroupTemplateFields(claGroupID, claGroupFields.TemplateID, metaFieldValues, contentHash)
+	if err != nil {
+		log.Warnf("Problem recording the template fields of CLA Group: %s, error: %v - returning empty template PDFs", claGroupID, err)
+		return models.TemplatePdfs{}, err
+	}
+
 	return pdfUrls, nil
 }
 
+// GetCLAGroupTemplateFields returns the template and the meta field values the documents of the CLA Group were last
+// generated with - the meta fields are empty for CLA Groups created before the values were recorded
+func (s service) GetCLAGroupTemplateFields(claGroupID string) (*models.CreateClaGroupTemplate, error) {
+	templateID, metaFieldValues, err := s.templateRepo.GetCLAGroupTemplateFields(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.CreateClaGroupTemplate{
+		TemplateID: templateID,
+		MetaFields: []*models.MetaField{},
+	}
+	if templateID == "" {
+		return result, nil
+	}
+
+	template, err := s.templateRepo.GetTemplate(templateID)
+	if err != nil {
+		log.Warnf("Unable to fetch template: %s of CLA Group: %s, error: %v", templateID, claGroupID, err)
+		return nil, err
+	}
+	for _, field := range template.MetaFields {
+		value, ok := metaFieldValues[field.TemplateVariable]
+		if !ok {
+			continue
+		}
+		result.MetaFields = append(result.MetaFields, &models.MetaField{
+			Name:             field.Name,
+			Description:      field.Description,
+			TemplateVariable: field.TemplateVariable,
+			Value:            value,
+		})
+	}
+	return result, nil
+}
+
+// CopyCLAGroupTemplate copies the documents of the source CLA Group to the CLA Group. The PDFs stored under the
+// source CLA Group are copied as well, so new templates of the source CLA Group do not change the copied documents.
+func (s service) CopyCLAGroupTemplate(ctx context.Context, sourceCLAGroupID, claGroupID string) error {
+	documents, err := s.templateRepo.GetCLAGroupDocumentAttributes(sourceCLAGroupID)
+	if err != nil {
+		log.Warnf("Unable to load the documents of the source CLA Group: %s, error: %v", sourceCLAGroupID, err)
+		return err
+	}
+
+	err = RewriteDocumentS3URLs(documents, func(s3URL string) (string, error) {
+		return s.copyCLAGroupDocumentFile(s3URL, sourceCLAGroupID, claGroupID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.templateRepo.UpdateCLAGroupDocumentAttributes(claGroupID, documents)
+}
+
+// RewriteDocumentS3URLs replaces the S3 URL of each document of the raw document list attributes with the URL
+// returned by copyFile - copyFile is called once for each distinct URL
+func RewriteDocumentS3URLs(documents map[string]*dynamodb.AttributeValue, copyFile func(s3URL string) (string, error)) error {
+	copiedURLs := map[string]string{}
+	for _, documentList := range documents {
+		for _, document := range documentList.L {
+			s3URL, ok := document.M["document_s3_url"]
+			if !ok || s3URL.S == nil || *s3URL.S == "" {
+				continue
+			}
+			copiedURL, copied := copiedURLs[*s3URL.S]
+			if !copied {
+				var err error
+				copiedURL, err = copyFile(*s3URL.S)
+				if err != nil {
+					return err
+				}
+				copiedURLs[*s3URL.S] = copiedURL
+			}
+			document.M["document_s3_url"] = &dynamodb.AttributeValue{S: aws.String(copiedURL)}
+		}
+	}
+	return nil
+}
+
+// copyCLAGroupDocumentFile copies the S3 document file of the source CLA Group to the same path under the CLA Group
+// and returns the URL of the copy - files outside of the source CLA Group path are shared and not copied
+func (s service) copyCLAGroupDocumentFile(s3URL, sourceCLAGroupID, claGroupID string) (string, error) {
+	sourceKey, key, copiedURL, ok := CloneDocumentS3Key(s3URL, sourceCLAGroupID, claGroupID)
+	if !ok {
+		log.Debugf("document file: %s is not stored under the source CLA Group: %s - sharing the file", s3URL, sourceCLAGroupID)
+		return s3URL, nil
+	}
+
+	bucket := fmt.Sprintf("cla-signature-files-%s", s.stage)
+	_, err := s.s3Client.S3.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String(bucket),
+		CopySource: aws.String(url.PathEscape(bucket + "/" + sourceKey)),
+		Key:        aws.String(key),
+		ACL:        aws.String("public-read"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to copy file: %s to %s in S3 Bucket: %s, %v", sourceKey, key, bucket, err)
+	}
+
+	return copiedURL, nil
+}
+
+// CloneDocumentS3Key returns the S3 key of the document file of the source CLA Group, the key of its copy under the
+// CLA Group and the URL of the copy - ok is false when the file is not stored under the source CLA Group
+func CloneDocumentS3Key(s3URL, sourceCLAGroupID, claGroupID string) (sourceKey, key, copiedURL string, ok bool) {
+	sourcePrefix := fmt.Sprintf("contract-group/%s/", sourceCLAGroupID)
+	index := strings.Index(s3URL, sourcePrefix)
+	if index < 0 {
+		return "", "", "", false
+	}
+
+	sourceKey = s3URL[index:]
+	key = fmt.Sprintf("contract-group/%s/%s", claGroupID, strings.TrimPrefix(sourceKey, sourcePrefix))
+	return sourceKey, key, s3URL[:index] + key, true
+}
+
+// NextDocumentVersion returns the version of the documents generated for a CLA Group. New CLA Groups start with
+// version 2.0. Otherwise the major version is bumped when requested, and the minor version is bumped only when the
+// content of the documents changed - regenerating the same documents keeps the current version.
+func NextDocumentVersion(currentVersion *DocumentVersion, newMajorVersion, contentChanged bool) DocumentVersion {
+	if currentVersion == nil {
+		return DocumentVersion{MajorVersion: 2, MinorVersion: 0}
+	}
+	if newMajorVersion {
+		return DocumentVersion{MajorVersion: currentVersion.MajorVersion + 1, MinorVersion: 0}
+	}
+	if !contentChanged {
+		return *currentVersion
+	}
+	return DocumentVersion{MajorVersion: currentVersion.MajorVersion, MinorVersion: currentVersion.MinorVersion + 1}
+}
+
+// TemplateContentHash returns the hash of the documents generated for a CLA Group - it changes with the template
+// content, the template field values and the enabled signature types
+func TemplateContentHash(iclaHTML, cclaHTML string, iclaEnabled, cclaEnabled bool) string {
+	hash := sha256.New()
+	if iclaEnabled {
+		hash.Write([]byte("icla:" + iclaHTML))
+	}
+	hash.Write([]byte{0})
+	if cclaEnabled {
+		hash.Write([]byte("ccla:" + cclaHTML))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 // InjectProjectInformationIntoTemplate
 func (s service) InjectProjectInformationIntoTemplate(template models.Template, metaFields []*models.MetaField) (string, string, error) {
 	lookupMap := map[string]models.MetaField{}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/v2/acl_reconciler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffRoleScopes(t *testing.T) {
+	scopes := map[string]map[string]string{
+		"project-1": {"manager-one": "scope-1", "former-manager": "scope-2"},
+		"project-2": {"manager-one": "scope-3", "manager-two": "scope-4"},
+	}
+
+	var testCases = []struct {
+		name         string
+		aclUsernames []string
+		projectSFIDs []string
+		drifts       []acl_reconciler.Drift
+	}{
+		{"in sync", []string{"manager-one", "manager-two"}, []string{"project-2"}, nil},
+		{"missing and stale scopes", []string{"manager-two", "manager-one"}, []string{"project-1"}, []acl_reconciler.Drift{
+			{DriftType: acl_reconciler.DriftMissingScope, ProjectSFID: "project-1", LfUsername: "manager-two"},
+			{DriftType: acl_reconciler.DriftStaleScope, ProjectSFID: "project-1", LfUsername: "former-manager", ScopeID: "scope-2"},
+		}},
+		{"project without scopes", []string{"manager-one"}, []string{"project-3"}, []acl_reconciler.Drift{
+			{DriftType: acl_reconciler.DriftMissingScope, ProjectSFID: "project-3", LfUsername: "manager-one"},
+		}},
+		{"every project of the CLA Group is compared", []string{"manager-one"}, []string{"project-1", "project-2"}, []acl_reconciler.Drift{
+			{DriftType: acl_reconciler.DriftStaleScope, ProjectSFID: "project-1", LfUsername: "former-manager", ScopeID: "scope-2"},
+			{DriftType: acl_reconciler.DriftStaleScope, ProjectSFID: "project-2", LfUsername: "manager-two", ScopeID: "scope-4"},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var drifts []acl_reconciler.Drift
+			for _, drift := range acl_reconciler.DiffRoleScopes(tc.aclUsernames, tc.projectSFIDs, scopes) {
+				drifts = append(drifts, *drift)
+			}
+			assert.Equal(t, tc.drifts, drifts)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBulkApprovalList(t *testing.T) {
+	requests := []*approval_list.CLARequestModel{
+		{RequestID: "request-1", UserEmails: []string{"user1@example.com", "user1@example.org"}, UserGithubUsername: "user-one"},
+		{RequestID: "request-2", UserEmails: []string{"user2@example.com"}},
+		{RequestID: "request-3", UserGithubUsername: "user-three"},
+	}
+
+	approvalList := approval_list.BuildBulkApprovalList(requests)
+	assert.Equal(t, []string{"user1@example.com", "user2@example.com"}, approvalList.AddEmailApprovalList, "the first email of each request is added")
+	assert.Equal(t, []string{"user-one", "user-three"}, approvalList.AddGithubUsernameApprovalList)
+	assert.Empty(t, approvalList.RemoveEmailApprovalList)
+	assert.Empty(t, approval_list.BuildBulkApprovalList(nil).AddEmailApprovalList)
+}
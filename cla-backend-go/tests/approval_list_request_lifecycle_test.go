@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPendingRequests(t *testing.T) {
+	now := time.Date(2020, 10, 31, 0, 0, 0, 0, time.UTC)
+	reminderAge := 7 * 24 * time.Hour
+	expiryAge := 30 * 24 * time.Hour
+	requests := []models.CclaWhitelistRequest{
+		{RequestID: "expired", CompanyID: "company-1", ProjectID: "project-1", DateCreated: "2020-10-01T00:00:00Z"},
+		{RequestID: "new", CompanyID: "company-1", ProjectID: "project-1", DateCreated: "2020-10-30T00:00:00Z"},
+		{RequestID: "stale-1", CompanyID: "company-1", ProjectID: "project-1", DateCreated: "2020-10-20T00:00:00Z"},
+		{RequestID: "other-project", CompanyID: "company-1", ProjectID: "project-2", DateCreated: "2020-10-20T00:00:00Z"},
+		{RequestID: "recently-reminded", CompanyID: "company-1", ProjectID: "project-1", DateCreated: "2020-10-10T00:00:00Z",
+			DateReminderSent: "2020-10-28T00:00:00Z"},
+		{RequestID: "reminded-long-ago", CompanyID: "company-1", ProjectID: "project-1", DateCreated: "2020-10-10T00:00:00Z",
+			DateReminderSent: "2020-10-20T00:00:00Z"},
+		{RequestID: "invalid-date", CompanyID: "company-1", ProjectID: "project-1", DateCreated: "yesterday"},
+	}
+
+	expired, digests := approval_list.SelectPendingRequests(requests, now, reminderAge, expiryAge)
+	assert.Equal(t, []string{"expired"}, requestIDs(expired))
+	if assert.Len(t, digests, 2) {
+		assert.Equal(t, []string{"stale-1", "reminded-long-ago"}, requestIDs(digests[0]))
+		assert.Equal(t, []string{"other-project"}, requestIDs(digests[1]))
+	}
+
+	expired, digests = approval_list.SelectPendingRequests(requests, now, reminderAge, 0)
+	assert.Empty(t, expired, "an expiry age of zero disables the expiry")
+	if assert.Len(t, digests, 2) {
+		assert.Equal(t, []string{"expired", "stale-1", "reminded-long-ago"}, requestIDs(digests[0]))
+	}
+}
+
+func requestIDs(requests []models.CclaWhitelistRequest) []string {
+	var ids []string
+	for _, request := range requests {
+		ids = append(ids, request.RequestID)
+	}
+	return ids
+}
@@ -0,0 +1,124 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectPurgeableCLAGroups(t *testing.T) {
+	now := time.Date(2020, 10, 31, 0, 0, 0, 0, time.UTC)
+	claGroups := []v1Models.Project{
+		{ProjectID: "archived-long-ago", ProjectArchived: true, ProjectDateArchived: "2020-09-01T00:00:00Z"},
+		{ProjectID: "archived-at-retention", ProjectArchived: true, ProjectDateArchived: "2020-10-01T00:00:00Z"},
+		{ProjectID: "archived-recently", ProjectArchived: true, ProjectDateArchived: "2020-10-20T00:00:00Z"},
+		{ProjectID: "invalid-date", ProjectArchived: true, ProjectDateArchived: "yesterday"},
+	}
+
+	var purgeableIDs []string
+	for _, claGroup := range cla_groups.SelectPurgeableCLAGroups(claGroups, now, 30*24*time.Hour) {
+		purgeableIDs = append(purgeableIDs, claGroup.ProjectID)
+	}
+	assert.Equal(t, []string{"archived-long-ago", "archived-at-retention"}, purgeableIDs)
+	assert.Empty(t, cla_groups.SelectPurgeableCLAGroups(claGroups, now, 365*24*time.Hour))
+}
+
+// purgeSteps records the purge steps run by the fakes below, the other methods of the fakes are not implemented
+type purgeSteps struct {
+	steps       []string
+	archivedErr error
+	deleteErr   error
+}
+
+type purgeProjectRepo struct {
+	project.ProjectRepository
+	*purgeSteps
+}
+
+func (repo purgeProjectRepo) GetArchivedCLAGroups() ([]v1Models.Project, error) {
+	return []v1Models.Project{{ProjectID: "cla-group-1", ProjectArchived: true, ProjectDateArchived: "2020-01-01T00:00:00Z"}}, nil
+}
+
+func (repo purgeProjectRepo) GetArchivedCLAGroup(claGroupID string) (*v1Models.Project, error) {
+	repo.steps = append(repo.steps, "load archived cla group")
+	return &v1Models.Project{ProjectID: claGroupID, ProjectArchived: true}, repo.archivedErr
+}
+
+func (repo purgeProjectRepo) DeleteCLAGroup(projectID string) error {
+	repo.steps = append(repo.steps, "delete cla group")
+	return repo.deleteErr
+}
+
+type purgeGerritService struct {
+	gerrits.Service
+	*purgeSteps
+}
+
+func (s purgeGerritService) DeleteClaGroupGerrits(claGroupID string) error {
+	s.steps = append(s.steps, "delete gerrits")
+	return errors.New("gerrit instances not deleted")
+}
+
+type purgeRepositoriesService struct {
+	repositories.Service
+	*purgeSteps
+}
+
+func (s purgeRepositoriesService) DeleteProject(projectID string) error {
+	s.steps = append(s.steps, "delete repositories")
+	return nil
+}
+
+type purgeSignatureService struct {
+	signatures.SignatureService
+	*purgeSteps
+}
+
+func (s purgeSignatureService) InvalidateProjectRecords(projectID string, projectName string) error {
+	s.steps = append(s.steps, "invalidate signatures")
+	return nil
+}
+
+type purgeEventsService struct {
+	events.Service
+}
+
+func (s purgeEventsService) LogEvent(args *events.LogEventArgs) {}
+
+func TestPurgeArchivedCLAGroups(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		archivedErr error
+		deleteErr   error
+		steps       []string
+	}{
+		{"the cla group is deleted before the irreversible steps, which all run", nil, nil,
+			[]string{"load archived cla group", "delete cla group", "delete gerrits", "delete repositories", "invalidate signatures"}},
+		{"a restored cla group is skipped", project.ErrCLAGroupNotArchived, nil,
+			[]string{"load archived cla group"}},
+		{"a cla group which fails to delete keeps its gerrits, repositories and signatures", nil, errors.New("delete failed"),
+			[]string{"load archived cla group", "delete cla group"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			steps := &purgeSteps{archivedErr: tc.archivedErr, deleteErr: tc.deleteErr}
+			archiveService := cla_groups.NewArchiveService(purgeProjectRepo{purgeSteps: steps}, nil, purgeGerritService{purgeSteps: steps},
+				purgeRepositoriesService{purgeSteps: steps}, purgeSignatureService{purgeSteps: steps}, purgeEventsService{})
+			assert.NoError(t, archiveService.PurgeArchivedCLAGroups(24*time.Hour))
+			assert.Equal(t, tc.steps, steps.steps)
+		})
+	}
+}
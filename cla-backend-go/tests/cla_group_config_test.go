@@ -0,0 +1,122 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_group_config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaGroupConfigDocumentRoundTrip(t *testing.T) {
+	config := &models.ClaGroupConfig{
+		ClaGroupName:        "OpenCue",
+		ClaGroupDescription: "CLA group created for OpenCue project",
+		FoundationSfid:      "a09410000182dD2AAI",
+		IclaEnabled:         true,
+		CclaEnabled:         true,
+		Template: &models.ClaGroupConfigTemplate{
+			TemplateID: "fb4cc144-a76c-4c17-8a52-c648f158fded",
+			MetaFields: map[string]string{"PROJECT_NAME": "OpenCue", "CONTACT_EMAIL": "cla@opencue.io"},
+		},
+		ProjectSfidList: []string{"a09410000182dD2AAI"},
+		GithubRepositories: []*models.ClaGroupConfigGithubRepository{
+			{ProjectSfid: "a09410000182dD2AAI", GithubOrganizationName: "opencue", RepositoryGithubID: "20580498", RepositoryName: "opencue/OpenCue"},
+		},
+	}
+
+	for _, format := range []string{cla_group_config.FormatJSON, cla_group_config.FormatYAML} {
+		document, err := cla_group_config.MarshalConfig(config, format)
+		assert.NoError(t, err)
+		parsed, err := cla_group_config.UnmarshalConfig(document)
+		assert.NoError(t, err)
+		assert.Equal(t, config, parsed, "the %s document reads back as the same configuration", format)
+	}
+}
+
+func TestClaGroupConfigDocumentErrors(t *testing.T) {
+	_, err := cla_group_config.UnmarshalConfig([]byte(""))
+	assert.Equal(t, cla_group_config.ErrEmptyDocument, err)
+
+	_, err = cla_group_config.UnmarshalConfig([]byte("cla_group_name: OpenCue\nicla_enabeld: true\n"))
+	assert.Error(t, err, "a misspelled field is rejected")
+
+	config, err := cla_group_config.UnmarshalConfig([]byte("cla_group_name: OpenCue\nicla_enabled: true\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "OpenCue", config.ClaGroupName)
+	assert.True(t, config.IclaEnabled)
+}
+
+// liveClaGroupConfig returns a configuration as exported from a cla group
+func liveClaGroupConfig() *models.ClaGroupConfig {
+	return &models.ClaGroupConfig{
+		ClaGroupName:        "OpenCue",
+		ClaGroupDescription: "CLA group created for OpenCue project",
+		FoundationSfid:      "a09410000182dD2AAI",
+		IclaEnabled:         true,
+		CclaEnabled:         true,
+		ContributionPolicy:  "icla_or_ccla",
+		Template: &models.ClaGroupConfigTemplate{
+			TemplateID: "fb4cc144-a76c-4c17-8a52-c648f158fded",
+			MetaFields: map[string]string{"PROJECT_NAME": "OpenCue", "CONTACT_EMAIL": "cla@opencue.io"},
+		},
+		ProjectSfidList: []string{"a09410000182dD2AAI"},
+		GithubOrganizations: []*models.ClaGroupConfigGithubOrganization{
+			{ProjectSfid: "a09410000182dD2AAI", GithubOrganizationName: "opencue"},
+		},
+		GithubRepositories: []*models.ClaGroupConfigGithubRepository{
+			{ProjectSfid: "a09410000182dD2AAI", GithubOrganizationName: "opencue", RepositoryGithubID: "20580498", RepositoryName: "opencue/OpenCue"},
+		},
+		GerritInstances: []*models.ClaGroupConfigGerritInstance{
+			{ProjectSfid: "a09410000182dD2AAI", GerritName: "opencue-gerrit", GerritURL: "https://gerrit.opencue.io", GroupIDIcla: "1"},
+		},
+	}
+}
+
+func TestPlanConfigChangesExportedConfig(t *testing.T) {
+	for _, format := range []string{cla_group_config.FormatJSON, cla_group_config.FormatYAML} {
+		document, err := cla_group_config.MarshalConfig(liveClaGroupConfig(), format)
+		assert.NoError(t, err)
+		config, err := cla_group_config.UnmarshalConfig(document)
+		assert.NoError(t, err)
+		assert.Empty(t, cla_group_config.PlanConfigChanges(liveClaGroupConfig(), config), "the exported %s configuration has no changes", format)
+	}
+
+	config := liveClaGroupConfig()
+	config.ClaGroupDescription = ""
+	config.ContributionPolicy = ""
+	assert.Empty(t, cla_group_config.PlanConfigChanges(liveClaGroupConfig(), config), "an omitted description and contribution policy have no changes")
+}
+
+func TestPlanConfigChangesOrder(t *testing.T) {
+	config := liveClaGroupConfig()
+	config.ClaGroupDescription = "CLA group of OpenCue"
+	config.ProjectSfidList = []string{"a0941000005ouJFAAY"}
+	config.GithubOrganizations = []*models.ClaGroupConfigGithubOrganization{
+		{ProjectSfid: "a0941000005ouJFAAY", GithubOrganizationName: "opencue"},
+	}
+	config.GithubRepositories = []*models.ClaGroupConfigGithubRepository{
+		{ProjectSfid: "a0941000005ouJFAAY", GithubOrganizationName: "opencue", RepositoryGithubID: "20580498"},
+	}
+	config.GerritInstances = []*models.ClaGroupConfigGerritInstance{
+		{ProjectSfid: "a09410000182dD2AAI", GerritName: "opencue-gerrit", GerritURL: "https://gerrit.opencue.io", GroupIDIcla: "2"},
+	}
+
+	var changes []string
+	for _, change := range cla_group_config.PlanConfigChanges(liveClaGroupConfig(), config) {
+		changes = append(changes, change.Change.Action+" "+change.Change.ResourceType+" "+change.Change.ProjectSfid)
+	}
+	assert.Equal(t, []string{
+		"update cla_group ",
+		"create project a0941000005ouJFAAY",
+		"create github_organization a0941000005ouJFAAY",
+		"create github_repository a0941000005ouJFAAY",
+		"update gerrit_instance a09410000182dD2AAI",
+		"delete github_repository a09410000182dD2AAI",
+		"delete github_organization a09410000182dD2AAI",
+		"delete project a09410000182dD2AAI",
+	}, changes, "the records are created before the records are deleted")
+}
@@ -0,0 +1,52 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	v2ClaManager "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectDesigneeGrants(t *testing.T) {
+	now := time.Date(2020, 10, 31, 0, 0, 0, 0, time.UTC)
+	ttl := 30 * 24 * time.Hour
+	reminderBefore := 7 * 24 * time.Hour
+	grants := []*v2ClaManager.DesigneeGrant{
+		{DesigneeID: "expired", DateCreated: "2020-10-01T00:00:00Z"},
+		{DesigneeID: "expired-after-reminder", DateCreated: "2020-09-01T00:00:00Z", DateReminderSent: "2020-09-25T00:00:00Z"},
+		{DesigneeID: "expiring", DateCreated: "2020-10-05T00:00:00Z"},
+		{DesigneeID: "reminded", DateCreated: "2020-10-05T00:00:00Z", DateReminderSent: "2020-10-29T00:00:00Z"},
+		{DesigneeID: "recent", DateCreated: "2020-10-20T00:00:00Z"},
+		{DesigneeID: "invalid-date", DateCreated: "last week"},
+	}
+
+	var testCases = []struct {
+		name           string
+		reminderBefore time.Duration
+		expired        []string
+		reminders      []string
+	}{
+		{"expired grants and reminders", reminderBefore, []string{"expired", "expired-after-reminder"}, []string{"expiring"}},
+		{"a reminder of zero disables the reminders", 0, []string{"expired", "expired-after-reminder"}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expired, reminders := v2ClaManager.SelectDesigneeGrants(grants, now, ttl, tc.reminderBefore)
+			assert.Equal(t, tc.expired, designeeIDs(expired))
+			assert.Equal(t, tc.reminders, designeeIDs(reminders))
+		})
+	}
+}
+
+func designeeIDs(grants []*v2ClaManager.DesigneeGrant) []string {
+	var ids []string
+	for _, grant := range grants {
+		ids = append(ids, grant.DesigneeID)
+	}
+	return ids
+}
@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	v2ClaManager "github.com/communitybridge/easycla/cla-backend-go/v2/cla_manager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLastCLAManager(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		claManagers []string
+		lfUsername  string
+		last        bool
+	}{
+		{"only manager", []string{"manager-one"}, "manager-one", true},
+		{"one of several managers", []string{"manager-one", "manager-two"}, "manager-one", false},
+		{"not a manager", []string{"manager-one"}, "manager-two", false},
+		{"no managers", nil, "manager-one", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var claManagers []*models.CompanyClaManager
+			for _, lfUsername := range tc.claManagers {
+				claManagers = append(claManagers, &models.CompanyClaManager{LfUsername: lfUsername})
+			}
+			assert.Equal(t, tc.last, v2ClaManager.IsLastCLAManager(claManagers, tc.lfUsername))
+		})
+	}
+}
+
+func TestCheckCLAManagerRemoval(t *testing.T) {
+	assert.NoError(t, signatures.CheckCLAManagerRemoval([]string{"manager-one", "manager-two"}, "manager-one"))
+	assert.Equal(t, signatures.ErrLastCLAManager, signatures.CheckCLAManagerRemoval([]string{"manager-one"}, "manager-one"))
+
+	err := signatures.CheckCLAManagerRemoval([]string{"manager-two"}, "manager-one")
+	assert.Error(t, err)
+	assert.NotEqual(t, signatures.ErrLastCLAManager, err, "a manager removed concurrently is not reported as the last CLA Manager")
+}
+
+func TestGroupProjectsByCLAGroup(t *testing.T) {
+	projectCLAGroups := []*projects_cla_groups.ProjectClaGroup{
+		{ProjectSFID: "project-1", ClaGroupID: "cla-group-2"},
+		{ProjectSFID: "project-2", ClaGroupID: "cla-group-1"},
+		{ProjectSFID: "project-3", ClaGroupID: "cla-group-2"},
+	}
+
+	claGroupIDs, claGroupProjects := v2ClaManager.GroupProjectsByCLAGroup(projectCLAGroups)
+	assert.Equal(t, []string{"cla-group-2", "cla-group-1"}, claGroupIDs, "the CLA Groups are kept in the order of their first project")
+	assert.Equal(t, []*projects_cla_groups.ProjectClaGroup{projectCLAGroups[0], projectCLAGroups[2]}, claGroupProjects["cla-group-2"])
+	assert.Equal(t, []*projects_cla_groups.ProjectClaGroup{projectCLAGroups[1]}, claGroupProjects["cla-group-1"])
+
+	claGroupIDs, _ = v2ClaManager.GroupProjectsByCLAGroup(nil)
+	assert.Empty(t, claGroupIDs)
+}
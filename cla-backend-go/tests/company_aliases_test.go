@@ -0,0 +1,71 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	v2Company "github.com/communitybridge/easycla/cla-backend-go/v2/company"
+	"github.com/stretchr/testify/assert"
+)
+
+// aliasesCompanyRepo is an in-memory company repository holding a single company - the other repository methods
+// are not implemented
+type aliasesCompanyRepo struct {
+	company.IRepository
+	companyModel *v1Models.Company
+}
+
+func (repo *aliasesCompanyRepo) UpdateCompanyAliases(companyID string, aliases, formerNames []string) error {
+	repo.companyModel.CompanyAliases = aliases
+	repo.companyModel.CompanyFormerNames = formerNames
+	return nil
+}
+
+func (repo *aliasesCompanyRepo) GetCompany(companyID string) (*v1Models.Company, error) {
+	return repo.companyModel, nil
+}
+
+func TestCleanCompanyNames(t *testing.T) {
+	var testCases = []struct {
+		name   string
+		names  []string
+		result []string
+	}{
+		{"no names", nil, nil},
+		{"trimmed names", []string{" Acme Labs ", "ACME"}, []string{"Acme Labs", "ACME"}},
+		{"duplicates are ignored case insensitively", []string{"Acme Labs", "acme labs"}, []string{"Acme Labs"}},
+		{"the company name and empty names are ignored", []string{"acme corp", " ", ""}, nil},
+		{"invalid names are ignored", []string{"A", "Acme Labs"}, []string{"Acme Labs"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.result, v2Company.CleanCompanyNames("Acme Corp", tc.names))
+		})
+	}
+}
+
+func TestUpdateCompanyAliases(t *testing.T) {
+	companyModel := &v1Models.Company{
+		CompanyID:          "company-1",
+		CompanyName:        "Acme Corp",
+		CompanyAliases:     []string{"Old Alias"},
+		CompanyFormerNames: []string{"Acme Labs"},
+	}
+	service := v2Company.NewService(nil, nil, nil, nil, &aliasesCompanyRepo{companyModel: companyModel}, nil)
+
+	result, err := service.UpdateCompanyAliases(companyModel, &models.CompanyAliasesInput{
+		CompanyAliases:     []string{" ACME ", "acme", "Acme Corp"},
+		CompanyFormerNames: nil,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ACME"}, companyModel.CompanyAliases, "the aliases are replaced with the cleaned names")
+	assert.Nil(t, companyModel.CompanyFormerNames, "the former names are cleared")
+	assert.Equal(t, "company-1", result.CompanyID)
+	assert.Equal(t, []string{"ACME"}, result.CompanyAliases)
+}
@@ -0,0 +1,50 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCCLACoveredSubsidiaries(t *testing.T) {
+	// parent
+	// ├── subsidiary-1
+	// │   └── subsidiary-1a
+	// ├── signed-subsidiary (own CCLA, covers its subsidiaries)
+	// │   └── subsidiary-2a
+	// └── signed-subsidiary-without-coverage (own CCLA)
+	//     └── subsidiary-3a
+	subsidiaries := map[string][]*models.Company{
+		"parent": {
+			{CompanyID: "subsidiary-1"},
+			{CompanyID: "signed-subsidiary", SubsidiaryCclaCoverage: true},
+			{CompanyID: "signed-subsidiary-without-coverage"},
+		},
+		"subsidiary-1":                       {{CompanyID: "subsidiary-1a"}},
+		"signed-subsidiary":                  {{CompanyID: "subsidiary-2a"}},
+		"signed-subsidiary-without-coverage": {{CompanyID: "subsidiary-3a"}, {CompanyID: "parent"}},
+	}
+	getSubsidiaries := func(parentCompanyID string) ([]*models.Company, error) {
+		return subsidiaries[parentCompanyID], nil
+	}
+	hasCCLA := func(companyID string) bool {
+		return companyID == "signed-subsidiary" || companyID == "signed-subsidiary-without-coverage"
+	}
+
+	covered, err := company.GetCCLACoveredSubsidiaries(getSubsidiaries, &models.Company{CompanyID: "parent", SubsidiaryCclaCoverage: true}, hasCCLA)
+	assert.NoError(t, err)
+	var coveredIDs []string
+	for _, companyModel := range covered {
+		coveredIDs = append(coveredIDs, companyModel.CompanyID)
+	}
+	assert.Equal(t, []string{"subsidiary-1", "subsidiary-1a", "subsidiary-3a"}, coveredIDs)
+
+	covered, err = company.GetCCLACoveredSubsidiaries(getSubsidiaries, &models.Company{CompanyID: "parent"}, hasCCLA)
+	assert.NoError(t, err)
+	assert.Empty(t, covered, "the parent company did not opt in to cover its subsidiaries")
+}
@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/company_merge"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeCompanyExternalIDs(t *testing.T) {
+	var testCases = []struct {
+		name               string
+		survivorExternalID string
+		duplicateIDs       []string
+		externalID         string
+		conflicts          int
+	}{
+		{"no external IDs", "", []string{"", ""}, "", 0},
+		{"external ID of the survivor", "org-1", []string{"", "org-1"}, "org-1", 0},
+		{"external ID of a duplicate", "", []string{"", "org-2"}, "org-2", 0},
+		{"different external IDs", "org-1", []string{"org-2", "org-1", "org-3"}, "org-1", 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var duplicates []*v1Models.Company
+			for _, externalID := range tc.duplicateIDs {
+				duplicates = append(duplicates, &v1Models.Company{CompanyID: "duplicate", CompanyExternalID: externalID})
+			}
+			externalID, conflicts := company_merge.MergeCompanyExternalIDs(tc.survivorExternalID, duplicates)
+			assert.Equal(t, tc.externalID, externalID)
+			assert.Len(t, conflicts, tc.conflicts)
+		})
+	}
+}
+
+func TestCCLASignatureConflicts(t *testing.T) {
+	cclaCompanyByProject := map[string]string{}
+	survivorSignatures := []*company_merge.CompanySignature{
+		{SignatureID: "sig-1", SignatureType: "ccla", SignatureProjectID: "cla-group-1"},
+		{SignatureID: "sig-2", SignatureType: "ccla", SignatureProjectID: "cla-group-1"},
+	}
+	assert.Empty(t, company_merge.CCLASignatureConflicts(cclaCompanyByProject, "survivor", survivorSignatures),
+		"the signatures of the same company do not conflict")
+
+	duplicateSignatures := []*company_merge.CompanySignature{
+		{SignatureID: "sig-3", SignatureType: "ccla", SignatureProjectID: "cla-group-1"},
+		{SignatureID: "sig-4", SignatureType: "ccla", SignatureProjectID: "cla-group-2"},
+		{SignatureID: "sig-5", SignatureType: "cla", SignatureProjectID: "cla-group-3"},
+	}
+	conflicts := company_merge.CCLASignatureConflicts(cclaCompanyByProject, "duplicate-1", duplicateSignatures)
+	assert.Equal(t, []string{"companies survivor and duplicate-1 both have a CCLA signature for CLA Group cla-group-1"}, conflicts)
+	assert.Equal(t, map[string]string{"cla-group-1": "survivor", "cla-group-2": "duplicate-1"}, cclaCompanyByProject)
+
+	conflicts = company_merge.CCLASignatureConflicts(cclaCompanyByProject, "duplicate-2", []*company_merge.CompanySignature{
+		{SignatureID: "sig-6", SignatureType: "ccla", SignatureProjectID: "cla-group-2"},
+	})
+	assert.Len(t, conflicts, 1, "the duplicates are compared with each other")
+}
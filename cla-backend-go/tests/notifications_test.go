@@ -0,0 +1,42 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestFrequencies(t *testing.T) {
+	monday := time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{notifications.FrequencyDaily, notifications.FrequencyWeekly}, notifications.DigestFrequencies(monday))
+	assert.Equal(t, []string{notifications.FrequencyDaily}, notifications.DigestFrequencies(monday.AddDate(0, 0, 1)))
+}
+
+func TestBuildDigestEmail(t *testing.T) {
+	queued := []*notifications.QueuedNotification{
+		{RecipientName: "Manager", CompanyName: "Company B", ClaGroupName: "CLA Group", Subject: "EasyCLA: Approval List Update",
+			Summary: "third", DateCreated: "2020-10-01T00:00:00Z"},
+		{RecipientName: "Manager", CompanyName: "Company A", ClaGroupName: "CLA Group <2>", Subject: "EasyCLA: Request",
+			Summary: "second", DateCreated: "2020-10-02T00:00:00Z"},
+		{RecipientName: "Manager", CompanyName: "Company A", ClaGroupName: "CLA Group <2>", Subject: "EasyCLA: Request",
+			Summary: "first", DateCreated: "2020-10-01T00:00:00Z"},
+	}
+
+	subject, body := notifications.BuildDigestEmail(notifications.FrequencyDaily, queued)
+	assert.Equal(t, "EasyCLA: Your daily CLA Manager Digest", subject)
+	assert.Contains(t, body, "Hello Manager,")
+	assert.Equal(t, 2, strings.Count(body, "<ul>"), "one section for each company and CLA Group")
+	assert.Contains(t, body, "<p><b>Company A - CLA Group &lt;2&gt;</b></p>", "the section titles are escaped")
+	assert.Contains(t, body, "<li>2020-10-01T00:00:00Z: Request first</li>", "the EasyCLA prefix of the subject is removed")
+
+	first := strings.Index(body, "Request first")
+	second := strings.Index(body, "Request second")
+	third := strings.Index(body, "Approval List Update third")
+	assert.True(t, first < second && second < third, "the notifications are sorted by company, CLA Group and date")
+}
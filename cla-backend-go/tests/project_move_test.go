@@ -0,0 +1,55 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/v2/project_move"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureCoverageKey(t *testing.T) {
+	var testCases = []struct {
+		name string
+		sig  *project_move.CLAGroupSignature
+		key  string
+	}{
+		{"icla", &project_move.CLAGroupSignature{SignatureReferenceID: "user-1", SignatureReferenceType: "user"}, "icla:user-1"},
+		{"ecla", &project_move.CLAGroupSignature{SignatureReferenceID: "user-1", SignatureReferenceType: "user", SignatureUserCompanyID: "company-1"}, "ecla:user-1:company-1"},
+		{"ccla", &project_move.CLAGroupSignature{SignatureReferenceID: "company-1", SignatureReferenceType: "company"}, "ccla:company-1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.key, project_move.SignatureCoverageKey(tc.sig))
+		})
+	}
+}
+
+func TestUncoveredSignatures(t *testing.T) {
+	fromSignatures := []*project_move.CLAGroupSignature{
+		{SignatureID: "icla-1", SignatureReferenceID: "user-1", SignatureReferenceType: "user"},
+		{SignatureID: "icla-2", SignatureReferenceID: "user-2", SignatureReferenceType: "user"},
+		{SignatureID: "ecla-1", SignatureReferenceID: "user-3", SignatureReferenceType: "user", SignatureUserCompanyID: "company-1"},
+		{SignatureID: "ecla-2", SignatureReferenceID: "user-3", SignatureReferenceType: "user", SignatureUserCompanyID: "company-2"},
+		{SignatureID: "ccla-1", SignatureReferenceID: "company-1", SignatureReferenceType: "company"},
+	}
+	toSignatures := []*project_move.CLAGroupSignature{
+		{SignatureID: "other-icla-1", SignatureReferenceID: "user-1", SignatureReferenceType: "user"},
+		{SignatureID: "other-ecla-1", SignatureReferenceID: "user-3", SignatureReferenceType: "user", SignatureUserCompanyID: "company-1"},
+		{SignatureID: "other-ecla-2", SignatureReferenceID: "user-2", SignatureReferenceType: "user", SignatureUserCompanyID: "company-1"},
+	}
+
+	var uncoveredIDs, uncoveredTypes []string
+	for _, sig := range project_move.UncoveredSignatures(fromSignatures, toSignatures) {
+		uncoveredIDs = append(uncoveredIDs, sig.SignatureID)
+		uncoveredTypes = append(uncoveredTypes, sig.SignatureType)
+	}
+	// an employee acknowledgement does not cover an ICLA and the acknowledgement is only equivalent for the same company
+	assert.Equal(t, []string{"icla-2", "ecla-2", "ccla-1"}, uncoveredIDs)
+	assert.Equal(t, []string{"icla", "ecla", "ccla"}, uncoveredTypes)
+
+	assert.Empty(t, project_move.UncoveredSignatures(nil, toSignatures))
+}
@@ -0,0 +1,99 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	v2Signatures "github.com/communitybridge/easycla/cla-backend-go/v2/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApprovalListCsv(t *testing.T) {
+	document := "Email Address,Domain,GitHub Username\n" +
+		"user1@example.com,example.com,user-one\n" +
+		"USER1@example.com,,\n" +
+		" user2@example.com ,*.example.org,\n"
+
+	entries, rowCount, rowErrors := v2Signatures.ParseApprovalListCsv(strings.NewReader(document), nil)
+	assert.Empty(t, rowErrors)
+	assert.Equal(t, int64(3), rowCount)
+	assert.Equal(t, []string{"user1@example.com", "user2@example.com"}, entries[v2Signatures.ApprovalListCsvEmailColumn], "duplicate entries are ignored case insensitively")
+	assert.Equal(t, []string{"example.com", "*.example.org"}, entries[v2Signatures.ApprovalListCsvDomainColumn])
+	assert.Equal(t, []string{"user-one"}, entries[v2Signatures.ApprovalListCsvGitHubUsernameColumn])
+	_, present := entries[v2Signatures.ApprovalListCsvGitHubOrgColumn]
+	assert.False(t, present, "columns missing from the header have no entry")
+}
+
+func TestParseApprovalListCsvErrors(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		document string
+		rows     []int64
+	}{
+		{"empty document", "", []int64{1}},
+		{"unknown column", "email,phone\nuser@example.com,555\n", []int64{1}},
+		{"invalid values", "email,domain\nnot-an-email,example.com\nuser@example.com,ex*ample.com\n", []int64{2, 3}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, rowErrors := v2Signatures.ParseApprovalListCsv(strings.NewReader(tc.document), nil)
+			var rows []int64
+			for _, rowError := range rowErrors {
+				rows = append(rows, rowError.Row)
+			}
+			assert.Equal(t, tc.rows, rows)
+		})
+	}
+}
+
+func TestBuildApprovalListChanges(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"user1@example.com", "old@example.com"},
+		DomainApprovalList:         []string{"example.com"},
+		GithubUsernameApprovalList: []string{"user-one"},
+	}
+	entries := v2Signatures.ApprovalListEntries{
+		v2Signatures.ApprovalListCsvEmailColumn:  {"USER1@example.com", "new@example.com"},
+		v2Signatures.ApprovalListCsvDomainColumn: {},
+	}
+
+	changes := v2Signatures.BuildApprovalListChanges(sig, entries, v2Signatures.ApprovalListImportModeMerge)
+	assert.Equal(t, []string{"new@example.com"}, changes.AddEmailApprovalList)
+	assert.Empty(t, changes.RemoveEmailApprovalList, "merge mode never removes entries")
+	assert.Empty(t, changes.RemoveDomainApprovalList)
+
+	changes = v2Signatures.BuildApprovalListChanges(sig, entries, v2Signatures.ApprovalListImportModeReplace)
+	assert.Equal(t, []string{"new@example.com"}, changes.AddEmailApprovalList)
+	assert.Equal(t, []string{"old@example.com"}, changes.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"example.com"}, changes.RemoveDomainApprovalList, "a present column without values empties the approval list")
+	assert.Empty(t, changes.RemoveGithubUsernameApprovalList, "approval lists without a column are left unchanged")
+	assert.Empty(t, changes.AddGithubUsernameApprovalList)
+}
+
+func TestApprovalListCsvRoundTrip(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"user1@example.com", "legacy user@example.com"},
+		DomainApprovalList:         []string{"example.com", "*.example.org", "*bar.com", ".foo.com", "my_domain.example.com", "!contractors.example.com"},
+		GithubUsernameApprovalList: []string{"user-one"},
+		GithubOrgApprovalList:      []string{"example-org"},
+	}
+
+	document, err := v2Signatures.BuildApprovalListCsv(sig)
+	assert.NoError(t, err)
+	entries, rowCount, rowErrors := v2Signatures.ParseApprovalListCsv(bytes.NewReader(document), sig)
+	assert.Empty(t, rowErrors, "the entries saved before the values were validated are accepted as stored")
+	assert.Equal(t, int64(len(sig.DomainApprovalList)), rowCount)
+	assert.Equal(t, sig.DomainApprovalList, entries[v2Signatures.ApprovalListCsvDomainColumn])
+
+	changes := v2Signatures.BuildApprovalListChanges(sig, entries, v2Signatures.ApprovalListImportModeReplace)
+	assert.Equal(t, &models.ApprovalList{}, changes, "importing the exported document changes nothing")
+
+	_, _, rowErrors = v2Signatures.ParseApprovalListCsv(bytes.NewReader(document), &models.Signature{})
+	assert.Len(t, rowErrors, 2, "the invalid entries are rejected when they are not on the approval list")
+}
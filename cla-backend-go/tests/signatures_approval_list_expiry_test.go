@@ -0,0 +1,86 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildApprovalListExpirations(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"user1@example.com", "permanent@example.com"},
+		DomainApprovalList:         []string{"example.com"},
+		GithubUsernameApprovalList: []string{"user-two"},
+		ApprovalListExpirations: []*models.ApprovalListExpiration{
+			{EntryType: signatures.ApprovalListEntryEmail, Value: "user1@example.com", ExpiryDate: "2020-10-01T00:00:00Z"},
+			{EntryType: signatures.ApprovalListEntryDomain, Value: "example.com", ExpiryDate: "2020-10-01T00:00:00Z"},
+		},
+	}
+
+	var testCases = []struct {
+		name        string
+		params      *models.ApprovalList
+		changed     bool
+		expirations []string
+	}{
+		{"unrelated update", &models.ApprovalList{AddEmailApprovalList: []string{"user2@example.com"}},
+			false, []string{"user1@example.com", "example.com"}},
+		{"removed entry drops its expiration", &models.ApprovalList{RemoveEmailApprovalList: []string{"USER1@example.com"}},
+			true, []string{"example.com"}},
+		{"entry added again without an expiry date drops its expiration", &models.ApprovalList{AddDomainApprovalList: []string{"example.com"}},
+			true, []string{"user1@example.com"}},
+		{"added entries get the expiry date of the update", &models.ApprovalList{AddEmailApprovalList: []string{"user1@example.com", " user2@example.com"},
+			ExpiryDate: "2021-01-01T00:00:00Z"},
+			true, []string{"example.com", "user1@example.com", "user2@example.com"}},
+		{"entries added and removed in the same update get no expiration", &models.ApprovalList{AddGithubUsernameApprovalList: []string{"user-one"},
+			RemoveGithubUsernameApprovalList: []string{"user-one"}, ExpiryDate: "2021-01-01T00:00:00Z"},
+			false, []string{"user1@example.com", "example.com"}},
+		{"existing permanent entries added again stay permanent", &models.ApprovalList{AddEmailApprovalList: []string{"Permanent@example.com"},
+			AddGithubUsernameApprovalList: []string{"user-two"}, ExpiryDate: "2021-01-01T00:00:00Z"},
+			false, []string{"user1@example.com", "example.com"}},
+		{"existing permanent entries expire when asked", &models.ApprovalList{AddEmailApprovalList: []string{"permanent@example.com"},
+			ExpiryDate: "2021-01-01T00:00:00Z", ExpireExistingEntries: true},
+			true, []string{"user1@example.com", "example.com", "permanent@example.com"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expirations, changed := signatures.BuildApprovalListExpirations(sig, tc.params)
+			assert.Equal(t, tc.changed, changed)
+			var values []string
+			for _, expiration := range expirations {
+				values = append(values, expiration.Value)
+				if tc.params.ExpiryDate != "" && signatures.ContainsFold(tc.params.AddEmailApprovalList, expiration.Value) {
+					assert.Equal(t, tc.params.ExpiryDate, expiration.ExpiryDate)
+				}
+			}
+			assert.Equal(t, tc.expirations, values)
+		})
+	}
+}
+
+func TestSelectApprovalListExpirations(t *testing.T) {
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	expirations := []*models.ApprovalListExpiration{
+		{EntryType: signatures.ApprovalListEntryEmail, Value: "expired@example.com", ExpiryDate: "2020-09-30T00:00:00Z"},
+		{EntryType: signatures.ApprovalListEntryDomain, Value: "expired.example.com", ExpiryDate: "2020-10-01T00:00:00Z"},
+		{EntryType: signatures.ApprovalListEntryGitHubUsername, Value: "expiring-user", ExpiryDate: "2020-10-05T00:00:00Z"},
+		{EntryType: signatures.ApprovalListEntryEmail, Value: "reminded@example.com", ExpiryDate: "2020-10-05T00:00:00Z", ReminderSent: true},
+		{EntryType: signatures.ApprovalListEntryEmail, Value: "later@example.com", ExpiryDate: "2020-11-01T00:00:00Z"},
+		{EntryType: signatures.ApprovalListEntryEmail, Value: "invalid@example.com", ExpiryDate: "soon"},
+	}
+
+	expired, expiring := signatures.SelectApprovalListExpirations(expirations, now)
+	assert.Equal(t, []string{"expired@example.com"}, expired.RemoveEmailApprovalList)
+	assert.Equal(t, []string{"expired.example.com"}, expired.RemoveDomainApprovalList, "entries expire at the expiry date")
+	assert.Empty(t, expired.RemoveGithubUsernameApprovalList)
+	if assert.Len(t, expiring, 1) {
+		assert.Equal(t, "expiring-user", expiring[0].Value)
+	}
+}
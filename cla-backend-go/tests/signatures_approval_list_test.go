@@ -0,0 +1,110 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidDomainApprovalListRemovals(t *testing.T) {
+	existingList := []string{"example.com", "*example.com", " .example.org "}
+
+	var testCases = []struct {
+		name          string
+		removeEntries []string
+		invalid       []string
+	}{
+		{"valid rules are always accepted", []string{"*.example.com", "!dev.example.com", "missing.org"}, nil},
+		{"existing legacy entries can be removed", []string{"*example.com", ".example.org"}, nil},
+		{"invalid rules which are not in the approval list", []string{"/example.com", "*example.org"}, []string{"/example.com", "*example.org"}},
+		{"legacy entries are matched exactly", []string{"*EXAMPLE.com"}, []string{"*EXAMPLE.com"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.invalid, signatures.InvalidDomainApprovalListRemovals(existingList, tc.removeEntries))
+		})
+	}
+}
+
+func TestApplyApprovalListChanges(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:  []string{"user1@example.com", " user2@example.com"},
+		DomainApprovalList: []string{"example.com"},
+	}
+	changes := &models.ApprovalList{
+		AddEmailApprovalList:     []string{"user3@example.com", "user1@example.com"},
+		RemoveEmailApprovalList:  []string{"user2@example.com"},
+		RemoveDomainApprovalList: []string{"example.com"},
+		AddGithubOrgApprovalList: []string{"example-org"},
+	}
+
+	previewSig := signatures.ApplyApprovalListChanges(sig, changes)
+	assert.Equal(t, []string{"user1@example.com", "user3@example.com"}, previewSig.EmailApprovalList)
+	assert.Empty(t, previewSig.DomainApprovalList)
+	assert.Equal(t, []string{"example-org"}, previewSig.GithubOrgApprovalList)
+	assert.Equal(t, []string{"user1@example.com", " user2@example.com"}, sig.EmailApprovalList, "the signature is left unchanged")
+}
+
+func TestGetApprovalListCoverage(t *testing.T) {
+	sig := &models.Signature{
+		EmailApprovalList:          []string{"User1@example.com"},
+		DomainApprovalList:         []string{"*.example.org", "!contractors.example.org"},
+		GithubUsernameApprovalList: []string{"user-one"},
+		GithubOrgApprovalList:      []string{"Example-Org"},
+	}
+
+	var testCases = []struct {
+		name           string
+		emails         []string
+		githubUsername string
+		githubOrgs     []string
+		reason         string
+	}{
+		{"email entry", []string{"user1@EXAMPLE.com"}, "", nil, signatures.CoverageReasonEmail},
+		{"domain rule", []string{"user@dev.example.org"}, "", nil, signatures.CoverageReasonDomain},
+		{"excluded domain", []string{"user@contractors.example.org"}, "", nil, ""},
+		{"github username", nil, "user-one", nil, signatures.CoverageReasonGitHubUsername},
+		{"github organization", nil, "user-two", []string{"other-org", "example-org"}, signatures.CoverageReasonGitHubOrg},
+		{"email entries first", []string{"user@dev.example.org", "user1@example.com"}, "user-one", nil, signatures.CoverageReasonEmail},
+		{"not covered", []string{"user@example.net"}, "user-two", []string{"other-org"}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.reason, signatures.GetApprovalListCoverage(sig, tc.emails, tc.githubUsername, tc.githubOrgs))
+		})
+	}
+}
+
+func TestDetachApprovalListChanges(t *testing.T) {
+	cclaSig := &models.Signature{
+		EmailApprovalList:          []string{"Contributor@Example.com", "other@example.com"},
+		DomainApprovalList:         []string{"example.com"},
+		GithubUsernameApprovalList: []string{" contributor ", "other"},
+		GithubOrgApprovalList:      []string{"example-org"},
+	}
+
+	var testCases = []struct {
+		name    string
+		user    *models.User
+		changes *models.ApprovalList
+	}{
+		{"email and GitHub username entries", &models.User{LfEmail: "contributor@example.com", Emails: []string{"personal@example.org"}, GithubUsername: "Contributor"},
+			&models.ApprovalList{RemoveEmailApprovalList: []string{"Contributor@Example.com"}, RemoveGithubUsernameApprovalList: []string{" contributor "}}},
+		{"secondary email entry", &models.User{Emails: []string{"other@example.com"}},
+			&models.ApprovalList{RemoveEmailApprovalList: []string{"other@example.com"}}},
+		{"no entries of the contributor", &models.User{LfEmail: "new@example.com"}, &models.ApprovalList{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.changes, signatures.DetachApprovalListChanges(cclaSig, tc.user))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateAutoApprovalRules(t *testing.T) {
+	rules := []*models.AutoApprovalRule{
+		{RuleType: signatures.AutoApprovalRuleEmailDomain, Value: "*.example.com"},
+		{RuleType: signatures.AutoApprovalRuleGitHubOrg, Value: "example-org"},
+	}
+
+	evaluation := signatures.EvaluateAutoApprovalRules(rules, []string{"dev@other.com", "dev@eng.example.com"}, nil)
+	assert.True(t, evaluation.Approved, "wildcard email domain rule approves the request")
+	assert.Equal(t, signatures.AutoApprovalRuleEmailDomain, evaluation.MatchedRuleType)
+	assert.Equal(t, "dev@eng.example.com", evaluation.MatchedEmail, "the matching email address of the user is reported")
+	assert.Equal(t, 1, evaluation.RulesEvaluated)
+
+	evaluation = signatures.EvaluateAutoApprovalRules(rules, []string{"dev@other.com"}, []string{"Example-Org"})
+	assert.True(t, evaluation.Approved, "GitHub organization rule approves the request")
+	assert.Equal(t, "example-org", evaluation.MatchedRuleValue)
+	assert.Equal(t, 2, evaluation.RulesEvaluated)
+
+	assert.Empty(t, evaluation.MatchedEmail)
+
+	evaluation = signatures.EvaluateAutoApprovalRules(rules, []string{"dev@example.com"}, []string{"other-org"})
+	assert.False(t, evaluation.Approved, "no rule matches the request")
+	assert.Equal(t, 2, evaluation.RulesEvaluated)
+
+	evaluation = signatures.EvaluateAutoApprovalRules(rules, nil, nil)
+	assert.False(t, evaluation.Approved, "a user without email addresses matches no email domain rule")
+}
+
+func TestGetUserEmails(t *testing.T) {
+	userModel := &models.User{LfEmail: "Dev@example.com", Emails: []string{"dev@example.com", " other@example.org ", ""}}
+	assert.Equal(t, []string{"Dev@example.com", "other@example.org"}, signatures.GetUserEmails(userModel))
+}
+
+func TestValidAutoApprovalRule(t *testing.T) {
+	_, valid := signatures.ValidAutoApprovalRule(&models.AutoApprovalRule{RuleType: signatures.AutoApprovalRuleEmailDomain, Value: "example.com"})
+	assert.True(t, valid)
+	_, valid = signatures.ValidAutoApprovalRule(&models.AutoApprovalRule{RuleType: signatures.AutoApprovalRuleEmailDomain, Value: "!example.com"})
+	assert.False(t, valid, "exclusion rules are not auto-approval rules")
+	_, valid = signatures.ValidAutoApprovalRule(&models.AutoApprovalRule{RuleType: "email", Value: "dev@example.com"})
+	assert.False(t, valid, "unsupported rule type")
+}
@@ -0,0 +1,133 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateContributionPolicy(t *testing.T) {
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	icla := &models.Signature{SignatureID: "icla", SignedOn: "2016-06-01T00:00:00Z"}
+	ccla := &models.Signature{SignatureID: "ccla", SignatureReferenceID: "company-1"}
+	ecla := &models.Signature{SignatureID: "ecla", SignatureUserCompanyID: "company-1", SignatureCreated: "2019-03-01T00:00:00Z"}
+
+	claGroup := &models.Project{}
+	covered, _ := signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla}, now)
+	assert.True(t, covered, "the default policy accepts an ICLA")
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{CorporateSignatures: []*models.Signature{ccla}}, now)
+	assert.False(t, covered, "a CCLA approval list entry is not enough without the employee acknowledgement")
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{CorporateSignatures: []*models.Signature{ccla},
+		EmployeeSignatures: []*models.Signature{ecla}}, now)
+	assert.False(t, covered, "the employee acknowledgement is not for the company of the user record")
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{CorporateSignatures: []*models.Signature{ccla},
+		EmployeeSignatures: []*models.Signature{ecla}, UserCompanyID: "company-1"}, now)
+	assert.True(t, covered, "the default policy accepts a CCLA approval list entry with the employee acknowledgement")
+	claGroup.ProjectCCLARequiresICLA = true
+	covered, detail := signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{CorporateSignatures: []*models.Signature{ccla},
+		EmployeeSignatures: []*models.Signature{ecla}, UserCompanyID: "company-1"}, now)
+	assert.False(t, covered, "the CCLA is not enough when the CLA Group requires an ICLA")
+	assert.NotEmpty(t, detail)
+
+	claGroup = &models.Project{ProjectContributionPolicy: signatures.ContributionPolicyCCLARequired}
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla}, now)
+	assert.False(t, covered, "an ICLA is not enough when a CCLA is required")
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla, CorporateSignatures: []*models.Signature{ccla},
+		EmployeeSignatures: []*models.Signature{ecla}, UserCompanyID: "company-1"}, now)
+	assert.True(t, covered)
+
+	claGroup = &models.Project{ProjectContributionPolicy: signatures.ContributionPolicyICLAAndECLA}
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla, CorporateSignatures: []*models.Signature{ccla}}, now)
+	assert.False(t, covered, "the employee acknowledgement is missing")
+	otherECLA := &models.Signature{SignatureID: "other", SignatureUserCompanyID: "company-2"}
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla,
+		CorporateSignatures: []*models.Signature{ccla}, EmployeeSignatures: []*models.Signature{otherECLA}, UserCompanyID: "company-2"}, now)
+	assert.False(t, covered, "the employee acknowledgement is for a company whose CCLA does not list the contributor")
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla,
+		CorporateSignatures: []*models.Signature{ccla}, EmployeeSignatures: []*models.Signature{ecla}, UserCompanyID: "company-1"}, now)
+	assert.True(t, covered)
+	subsidiaryECLA := &models.Signature{SignatureID: "subsidiary", SignatureUserCompanyID: "subsidiary-1"}
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla,
+		CorporateSignatures: []*models.Signature{ccla}, EmployeeSignatures: []*models.Signature{subsidiaryECLA}, UserCompanyID: "subsidiary-1"}, now)
+	assert.False(t, covered, "the employee acknowledgement is for a subsidiary which is not covered by the CCLA")
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla,
+		CorporateSignatures: []*models.Signature{ccla}, EmployeeSignatures: []*models.Signature{subsidiaryECLA},
+		UserCompanyID: "subsidiary-1", ParentCompanyIDs: map[string]string{"subsidiary-1": "company-1"}}, now)
+	assert.True(t, covered, "the CCLA of the parent company covers the employees of the subsidiary")
+
+	claGroup = &models.Project{ProjectContributionPolicy: signatures.ContributionPolicyRecentSignature, ProjectContributionPolicyYears: 3}
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla}, now)
+	assert.False(t, covered, "the ICLA is older than three years")
+	covered, _ = signatures.EvaluateContributionPolicy(claGroup, &signatures.ContributionPolicyFacts{IndividualSignature: icla,
+		CorporateSignatures: []*models.Signature{ccla}, EmployeeSignatures: []*models.Signature{ecla}, UserCompanyID: "company-1"}, now)
+	assert.True(t, covered, "the employee acknowledgement was signed within three years")
+}
+
+// contributionPolicyTestVectors are the contribution policy test vectors shared with the python backend tests
+type contributionPolicyTestVectors struct {
+	Now   string `json:"now"`
+	Cases []struct {
+		Name             string `json:"name"`
+		Policy           string `json:"policy"`
+		Years            int64  `json:"years"`
+		CCLARequiresICLA bool   `json:"ccla_requires_icla"`
+		ICLASignedOn     string `json:"icla_signed_on"`
+		EmployeeSignedOn string `json:"employee_signed_on"`
+		Covered          bool   `json:"covered"`
+	} `json:"cases"`
+}
+
+func TestEvaluateContributionPolicyVectors(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/contribution_policy.json")
+	if err != nil {
+		t.Fatalf("unable to read the contribution policy test vectors, error: %+v", err)
+	}
+	var vectors contributionPolicyTestVectors
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatalf("unable to parse the contribution policy test vectors, error: %+v", err)
+	}
+	now, err := time.Parse(time.RFC3339, vectors.Now)
+	if err != nil {
+		t.Fatalf("unable to parse the time of the contribution policy test vectors, error: %+v", err)
+	}
+
+	for _, tc := range vectors.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			claGroup := &models.Project{
+				ProjectContributionPolicy:      tc.Policy,
+				ProjectContributionPolicyYears: tc.Years,
+				ProjectCCLARequiresICLA:        tc.CCLARequiresICLA,
+			}
+			facts := &signatures.ContributionPolicyFacts{UserCompanyID: "company-1"}
+			if tc.ICLASignedOn != "" {
+				facts.IndividualSignature = &models.Signature{SignatureID: "icla", SignedOn: tc.ICLASignedOn}
+			}
+			if tc.EmployeeSignedOn != "" {
+				facts.CorporateSignatures = []*models.Signature{{SignatureID: "ccla", SignatureReferenceID: "company-1"}}
+				facts.EmployeeSignatures = []*models.Signature{{SignatureID: "ecla", SignatureUserCompanyID: "company-1", SignedOn: tc.EmployeeSignedOn}}
+			}
+			covered, _ := signatures.EvaluateContributionPolicy(claGroup, facts, now)
+			assert.Equal(t, tc.Covered, covered)
+		})
+	}
+}
+
+func TestValidateContributionPolicy(t *testing.T) {
+	assert.NoError(t, signatures.ValidateContributionPolicy("", 0, true, false))
+	assert.NoError(t, signatures.ValidateContributionPolicy(signatures.ContributionPolicyICLAOrCCLA, 0, true, true))
+	assert.NoError(t, signatures.ValidateContributionPolicy(signatures.ContributionPolicyRecentSignature, 5, true, false))
+	assert.Error(t, signatures.ValidateContributionPolicy("ccla_only", 0, true, true), "unknown policy")
+	assert.Error(t, signatures.ValidateContributionPolicy(signatures.ContributionPolicyCCLARequired, 0, true, false), "the CCLA is disabled")
+	assert.Error(t, signatures.ValidateContributionPolicy(signatures.ContributionPolicyICLAAndECLA, 0, false, true), "the ICLA is disabled")
+	assert.Error(t, signatures.ValidateContributionPolicy(signatures.ContributionPolicyRecentSignature, 0, true, true), "the years are missing")
+	assert.Error(t, signatures.ValidateContributionPolicy(signatures.ContributionPolicyRecentSignature, 25, true, true), "too many years")
+	assert.Error(t, signatures.ValidateContributionPolicy(signatures.ContributionPolicyICLAOrCCLA, 2, true, true), "years without the recent_signature policy")
+}
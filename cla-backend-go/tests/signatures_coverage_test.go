@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCoverageReasons(t *testing.T) {
+	userModel := &models.User{UserID: "user-1", CompanyID: "company-1"}
+	icla := &models.Signature{SignatureID: "icla"}
+	ecla := &models.Signature{SignatureID: "ecla", ProjectID: "cla-group-1", SignatureUserCompanyID: "company-1", CompanyName: "Company 1",
+		SignatureSigned: true, SignatureApproved: true}
+	unsignedECLA := &models.Signature{SignatureID: "unsigned", ProjectID: "cla-group-1", SignatureUserCompanyID: "company-1", SignatureApproved: true}
+	otherGroupECLA := &models.Signature{SignatureID: "other-group", ProjectID: "cla-group-2", SignatureUserCompanyID: "company-1",
+		SignatureSigned: true, SignatureApproved: true}
+	ccla := &models.Signature{SignatureID: "ccla", SignatureReferenceID: "company-1", CompanyName: "Company 1",
+		EmailApprovalList: []string{"dev@example.com"}, GithubOrgApprovalList: []string{"example-org"}}
+	unmatchedCCLA := &models.Signature{SignatureID: "unmatched", SignatureReferenceID: "company-2", EmailApprovalList: []string{"other@example.com"}}
+
+	var testCases = []struct {
+		name       string
+		userModel  *models.User
+		iclaSig    *models.Signature
+		userSigs   []*models.Signature
+		githubOrgs []string
+		reasons    []string
+		covered    bool
+	}{
+		{"no user record - approval list entries only", nil, nil, nil, nil, []string{"ccla_email"}, false},
+		{"ICLA", userModel, icla, nil, nil, []string{"icla", "ccla_email"}, true},
+		{"only the signed and approved employee acknowledgements of the CLA Group", userModel, nil,
+			[]*models.Signature{ecla, unsignedECLA, otherGroupECLA}, nil, []string{"ecla", "ccla_email"}, true},
+		{"GitHub organization entry", userModel, nil, []*models.Signature{ecla}, []string{"Example-Org"},
+			[]string{"ecla", "ccla_email", "ccla_github_org"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reasons, facts := signatures.BuildCoverageReasons("cla-group-1", tc.userModel, tc.iclaSig, tc.userSigs,
+				[]*models.Signature{ccla, unmatchedCCLA}, []string{"dev@example.com"}, "", tc.githubOrgs)
+			var reasonNames []string
+			for _, reason := range reasons {
+				reasonNames = append(reasonNames, reason.Reason)
+			}
+			assert.Equal(t, tc.reasons, reasonNames)
+			assert.Equal(t, []*models.Signature{ccla}, facts.CorporateSignatures, "only the matching CCLAs are facts")
+			covered, _ := signatures.EvaluateContributionPolicy(&models.Project{}, facts, time.Now())
+			assert.Equal(t, tc.covered, covered)
+		})
+	}
+}
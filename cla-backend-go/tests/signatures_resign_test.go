@@ -0,0 +1,62 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOutdatedSignature(t *testing.T) {
+	claGroup := &models.Project{
+		ProjectIndividualDocuments: []models.ProjectDocument{{DocumentMajorVersion: "1"}, {DocumentMajorVersion: "3"}},
+		ProjectCorporateDocuments:  []models.ProjectDocument{{DocumentMajorVersion: "2"}},
+	}
+
+	var testCases = []struct {
+		name      string
+		signature *models.Signature
+		outdated  bool
+	}{
+		{"icla on the current version", &models.Signature{SignatureType: "cla", SignatureMajorVersion: "3"}, false},
+		{"icla on an older version", &models.Signature{SignatureType: "cla", SignatureMajorVersion: "2"}, true},
+		{"ecla checked against the corporate documents", &models.Signature{SignatureType: "cla", SignatureUserCompanyID: "company-1", SignatureMajorVersion: "2"}, false},
+		{"ccla on an older version", &models.Signature{SignatureType: signatures.CCLA, SignatureMajorVersion: "1"}, true},
+		{"invalid major version", &models.Signature{SignatureType: "cla", SignatureMajorVersion: "v1"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.outdated, signatures.IsOutdatedSignature(claGroup, tc.signature))
+		})
+	}
+}
+
+func TestIsResignOverdue(t *testing.T) {
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var testCases = []struct {
+		name     string
+		policy   string
+		deadline string
+		overdue  bool
+	}{
+		{"deadline passed", signatures.ResignPolicyResign, "2020-09-30T00:00:00Z", true},
+		{"deadline in the future", signatures.ResignPolicyResign, "2020-10-02T00:00:00Z", false},
+		{"no deadline", signatures.ResignPolicyResign, "", false},
+		{"grandfathered signatures", signatures.ResignPolicyGrandfather, "2020-09-30T00:00:00Z", false},
+		{"invalid deadline", signatures.ResignPolicyResign, "yesterday", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claGroup := &models.Project{ProjectResignPolicy: tc.policy, ProjectResignDeadline: tc.deadline}
+			assert.Equal(t, tc.overdue, signatures.IsResignOverdue(claGroup, now))
+		})
+	}
+}
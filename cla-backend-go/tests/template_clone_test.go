@@ -0,0 +1,76 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/communitybridge/easycla/cla-backend-go/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloneDocumentS3Key(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		s3URL     string
+		sourceKey string
+		key       string
+		copiedURL string
+		ok        bool
+	}{
+		{"document of the source CLA Group",
+			"https://cla-signature-files-dev.s3.amazonaws.com/contract-group/source-id/template/icla.pdf",
+			"contract-group/source-id/template/icla.pdf",
+			"contract-group/clone-id/template/icla.pdf",
+			"https://cla-signature-files-dev.s3.amazonaws.com/contract-group/clone-id/template/icla.pdf", true},
+		{"shared document of another CLA Group",
+			"https://cla-signature-files-dev.s3.amazonaws.com/contract-group/other-id/template/icla.pdf", "", "", "", false},
+		{"source CLA Group ID prefix of another CLA Group",
+			"https://cla-signature-files-dev.s3.amazonaws.com/contract-group/source-id-2/template/icla.pdf", "", "", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sourceKey, key, copiedURL, ok := template.CloneDocumentS3Key(tc.s3URL, "source-id", "clone-id")
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.sourceKey, sourceKey)
+			assert.Equal(t, tc.key, key)
+			assert.Equal(t, tc.copiedURL, copiedURL)
+		})
+	}
+}
+
+func TestRewriteDocumentS3URLs(t *testing.T) {
+	document := func(s3URL string) *dynamodb.AttributeValue {
+		item := map[string]*dynamodb.AttributeValue{"document_name": {S: aws.String("document")}}
+		if s3URL != "" {
+			item["document_s3_url"] = &dynamodb.AttributeValue{S: aws.String(s3URL)}
+		}
+		return &dynamodb.AttributeValue{M: item}
+	}
+	documents := map[string]*dynamodb.AttributeValue{
+		"project_individual_documents": {L: []*dynamodb.AttributeValue{document("s3://icla.pdf"), document("")}},
+		"project_corporate_documents":  {L: []*dynamodb.AttributeValue{document("s3://ccla.pdf"), document("s3://icla.pdf")}},
+	}
+
+	copies := map[string]int{}
+	err := template.RewriteDocumentS3URLs(documents, func(s3URL string) (string, error) {
+		copies[s3URL]++
+		return s3URL + ".copy", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"s3://icla.pdf": 1, "s3://ccla.pdf": 1}, copies, "each file is copied once")
+	assert.Equal(t, "s3://icla.pdf.copy", *documents["project_individual_documents"].L[0].M["document_s3_url"].S)
+	assert.NotContains(t, documents["project_individual_documents"].L[1].M, "document_s3_url", "documents without a file are left as is")
+	assert.Equal(t, "s3://ccla.pdf.copy", *documents["project_corporate_documents"].L[0].M["document_s3_url"].S)
+	assert.Equal(t, "s3://icla.pdf.copy", *documents["project_corporate_documents"].L[1].M["document_s3_url"].S)
+
+	err = template.RewriteDocumentS3URLs(documents, func(s3URL string) (string, error) {
+		return "", errors.New("copy failed")
+	})
+	assert.Error(t, err)
+}
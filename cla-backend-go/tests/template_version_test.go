@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDocumentVersion(t *testing.T) {
+	current := &template.DocumentVersion{MajorVersion: 2, MinorVersion: 3}
+
+	var testCases = []struct {
+		name            string
+		currentVersion  *template.DocumentVersion
+		newMajorVersion bool
+		contentChanged  bool
+		version         template.DocumentVersion
+	}{
+		{"new CLA Group", nil, false, true, template.DocumentVersion{MajorVersion: 2, MinorVersion: 0}},
+		{"unchanged documents keep the version", current, false, false, *current},
+		{"changed documents bump the minor version", current, false, true, template.DocumentVersion{MajorVersion: 2, MinorVersion: 4}},
+		{"new major version", current, true, false, template.DocumentVersion{MajorVersion: 3, MinorVersion: 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.version, template.NextDocumentVersion(tc.currentVersion, tc.newMajorVersion, tc.contentChanged))
+		})
+	}
+}
+
+func TestTemplateContentHash(t *testing.T) {
+	hash := template.TemplateContentHash("<p>icla</p>", "<p>ccla</p>", true, true)
+	assert.Equal(t, hash, template.TemplateContentHash("<p>icla</p>", "<p>ccla</p>", true, true))
+	assert.NotEqual(t, hash, template.TemplateContentHash("<p>icla v2</p>", "<p>ccla</p>", true, true), "the template content changed")
+	assert.NotEqual(t, hash, template.TemplateContentHash("<p>icla</p>", "<p>ccla</p>", true, false), "the CCLA was disabled")
+	assert.Equal(t, template.TemplateContentHash("<p>icla</p>", "<p>ccla</p>", true, false),
+		template.TemplateContentHash("<p>icla</p>", "<p>other ccla</p>", true, false), "the content of a disabled document is ignored")
+	assert.NotEqual(t, template.TemplateContentHash("<p>same</p>", "", true, false),
+		template.TemplateContentHash("", "<p>same</p>", false, true), "the ICLA and CCLA content are kept apart")
+}
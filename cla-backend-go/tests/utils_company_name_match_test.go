@@ -0,0 +1,37 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeCompanyName(t *testing.T) {
+	assert.Equal(t, "acme", utils.NormalizeCompanyName("Acme, Inc."))
+	assert.Equal(t, "red hat", utils.NormalizeCompanyName("  Red   Hat LLC "))
+	assert.Equal(t, "company", utils.NormalizeCompanyName("Company"), "a single word is never removed as a suffix")
+	assert.Equal(t, "", utils.NormalizeCompanyName(" ,. "))
+}
+
+func TestCompanyNameMatchScore(t *testing.T) {
+	assert.Equal(t, 1.0, utils.CompanyNameMatchScore("acme inc", "ACME, Inc."))
+
+	// acronyms, typos and partial names are relevant
+	assert.GreaterOrEqual(t, utils.CompanyNameMatchScore("IBM", "International Business Machines"), utils.ProbableDuplicateCompanyScore)
+	assert.GreaterOrEqual(t, utils.CompanyNameMatchScore("Micrsoft", "Microsoft Corporation"), utils.ProbableDuplicateCompanyScore)
+	assert.GreaterOrEqual(t, utils.CompanyNameMatchScore("Red Hat", "Redhat"), utils.ProbableDuplicateCompanyScore)
+	assert.GreaterOrEqual(t, utils.CompanyNameMatchScore("linux", "The Linux Foundation"), utils.MinCompanySearchScore)
+
+	// unrelated names are not
+	assert.Less(t, utils.CompanyNameMatchScore("Intel", "IBM"), utils.MinCompanySearchScore)
+	assert.Less(t, utils.CompanyNameMatchScore("Google", "Alphabet Inc."), utils.MinCompanySearchScore)
+	assert.Equal(t, 0.0, utils.CompanyNameMatchScore("", "Acme"))
+
+	// aliases and former names match as well as the name
+	assert.Equal(t, 1.0, utils.BestCompanyNameMatchScore("Google", "Alphabet Inc.", "Google LLC"))
+	assert.Equal(t, 0.0, utils.BestCompanyNameMatchScore("Google"))
+}
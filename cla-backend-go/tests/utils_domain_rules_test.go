@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// domainRuleTestVectors are the domain approval list rule test vectors shared with the python backend tests
+type domainRuleTestVectors struct {
+	ValidRules   []string `json:"valid_rules"`
+	InvalidRules []string `json:"invalid_rules"`
+	Matches      []struct {
+		Name        string   `json:"name"`
+		Rules       []string `json:"rules"`
+		Domain      string   `json:"domain"`
+		Covered     bool     `json:"covered"`
+		MatchedRule string   `json:"matched_rule"`
+	} `json:"matches"`
+}
+
+func loadDomainRuleTestVectors(t *testing.T) *domainRuleTestVectors {
+	data, err := ioutil.ReadFile("testdata/domain_rules.json")
+	if err != nil {
+		t.Fatalf("unable to read the domain rule test vectors, error: %+v", err)
+	}
+	var vectors domainRuleTestVectors
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatalf("unable to parse the domain rule test vectors, error: %+v", err)
+	}
+	return &vectors
+}
+
+func TestValidDomainRule(t *testing.T) {
+	vectors := loadDomainRuleTestVectors(t)
+
+	for _, rule := range vectors.ValidRules {
+		msg, valid := utils.ValidDomainRule(rule)
+		assert.True(t, valid, fmt.Sprintf("ValidDomainRule - %s - %s", rule, msg))
+	}
+
+	for _, rule := range vectors.InvalidRules {
+		_, valid := utils.ValidDomainRule(rule)
+		assert.False(t, valid, fmt.Sprintf("ValidDomainRule - %s", rule))
+	}
+}
+
+func TestMatchDomainRules(t *testing.T) {
+	vectors := loadDomainRuleTestVectors(t)
+
+	for _, tc := range vectors.Matches {
+		t.Run(tc.Name, func(t *testing.T) {
+			matchedRule, covered := utils.MatchDomainRules(tc.Rules, tc.Domain)
+			assert.Equal(t, tc.Covered, covered)
+			assert.Equal(t, tc.MatchedRule, matchedRule)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// localTXTResolver is a local stand-in for the DNS resolver
+type localTXTResolver map[string][]string
+
+func (r localTXTResolver) LookupTXT(domain string) ([]string, error) {
+	if domain == "broken.example" {
+		return nil, errors.New("server misbehaving")
+	}
+	records, ok := r[domain]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: domain, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestHasDomainVerificationRecord(t *testing.T) {
+	resolver := localTXTResolver{
+		"example.com":   {"v=spf1 -all", " " + utils.DomainVerificationRecord("token-1") + " "},
+		"example.org":   {utils.DomainVerificationRecord("token-2")},
+		"empty.example": {},
+	}
+
+	found, err := utils.HasDomainVerificationRecord(resolver, "example.com", "token-1")
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	found, err = utils.HasDomainVerificationRecord(resolver, "example.org", "token-1")
+	assert.Nil(t, err)
+	assert.False(t, found, "the record of another token does not verify the domain")
+
+	found, err = utils.HasDomainVerificationRecord(resolver, "empty.example", "token-1")
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	found, err = utils.HasDomainVerificationRecord(resolver, "missing.example", "token-1")
+	assert.Nil(t, err, "a domain without DNS records is not verified")
+	assert.False(t, found)
+
+	_, err = utils.HasDomainVerificationRecord(resolver, "broken.example", "token-1")
+	assert.NotNil(t, err)
+}
+
+func TestUnverifiedDomainRules(t *testing.T) {
+	verifiedDomains := []string{"Example.com", "bücher.example"}
+
+	assert.Nil(t, utils.UnverifiedDomainRules([]string{
+		"example.com",
+		"EXAMPLE.COM.",
+		"*.example.com",
+		"dev.example.com",
+		"*.dev.example.com",
+		"!contractors.example.com",
+		"!gmail.com",
+		"xn--bcher-kva.example",
+	}, verifiedDomains))
+
+	assert.Equal(t, []string{"gmail.com", "*.gmail.com", "example.com.evil.org", "notexample.com"},
+		utils.UnverifiedDomainRules([]string{"gmail.com", "*.gmail.com", "example.com.evil.org", "notexample.com"}, verifiedDomains))
+
+	assert.Equal(t, []string{"example.com"}, utils.UnverifiedDomainRules([]string{"example.com"}, nil))
+	assert.Equal(t, []string{"*example.com"}, utils.UnverifiedDomainRules([]string{".example.com", "*example.com"}, verifiedDomains),
+		"a suffix rule also matches the domains of other owners")
+}
+
+func TestToPendingDomainModels(t *testing.T) {
+	domains := company.ToPendingDomainModels([]company.DomainDBModel{
+		{Domain: "example.com", VerificationToken: "token-1", Verified: true},
+		{Domain: "example.org", VerificationToken: "token-2"},
+	})
+	assert.Len(t, domains, 2)
+	assert.Empty(t, domains[0].VerificationRecord, "the record of a verified domain is not returned")
+	assert.Equal(t, utils.DomainVerificationRecord("token-2"), domains[1].VerificationRecord, "the record of a pending domain is returned")
+}
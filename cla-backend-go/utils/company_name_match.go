@@ -0,0 +1,171 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// company name match scores - the relevance score of a company name match is a value between 0 and 1
+const (
+	// MinCompanySearchScore is the minimum relevance score of a company search result
+	MinCompanySearchScore = 0.6
+	// ProbableDuplicateCompanyScore is the minimum relevance score of a probable duplicate company
+	ProbableDuplicateCompanyScore = 0.85
+
+	companyNamePrefixScore   = 0.9
+	companyNameAcronymScore  = 0.9
+	companyNameContainsScore = 0.8
+)
+
+// companyNameSuffixes are the legal entity suffixes ignored when comparing company names
+var companyNameSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "gmbh": true, "ag": true, "sa": true, "plc": true, "bv": true, "srl": true, "pty": true,
+	"lp": true, "llp": true,
+}
+
+// companyNameStopWords are the words skipped when building the acronym of a company name
+var companyNameStopWords = map[string]bool{"of": true, "and": true, "the": true, "for": true}
+
+// NormalizeCompanyName returns the lower case company name without punctuation and legal entity suffixes,
+// e.g. "Acme, Inc." becomes "acme"
+func NormalizeCompanyName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for len(words) > 1 && companyNameSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// CompanyNameMatchScore returns the relevance score of the company name for the search term - 1 is an exact match
+// of the normalized names, 0 is no similarity at all. The score is the best of a prefix, contains, acronym, trigram
+// and edit distance match, so "IBM" matches "International Business Machines" and "Micrsoft" matches "Microsoft".
+func CompanyNameMatchScore(searchTerm, companyName string) float64 {
+	term := NormalizeCompanyName(searchTerm)
+	name := NormalizeCompanyName(companyName)
+	if term == "" || name == "" {
+		return 0
+	}
+	if term == name {
+		return 1
+	}
+
+	score := trigramSimilarity(term, name)
+	if editScore := editSimilarity(term, name); editScore > score {
+		score = editScore
+	}
+
+	// short search terms match too many names by prefix or contains
+	if len([]rune(term)) >= 3 {
+		if strings.HasPrefix(name, term) && score < companyNamePrefixScore {
+			score = companyNamePrefixScore
+		} else if strings.Contains(name, term) && score < companyNameContainsScore {
+			score = companyNameContainsScore
+		}
+	}
+
+	if len([]rune(term)) >= 2 && (term == companyNameAcronym(name) || name == companyNameAcronym(term)) && score < companyNameAcronymScore {
+		score = companyNameAcronymScore
+	}
+	return score
+}
+
+// BestCompanyNameMatchScore returns the best relevance score of the company names, e.g. the name and the aliases
+// of a company
+func BestCompanyNameMatchScore(searchTerm string, companyNames ...string) float64 {
+	var best float64
+	for _, companyName := range companyNames {
+		if score := CompanyNameMatchScore(searchTerm, companyName); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// companyNameAcronym returns the acronym of a normalized company name with two or more words, otherwise an empty string
+func companyNameAcronym(name string) string {
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return ""
+	}
+	var acronym strings.Builder
+	for _, word := range words {
+		if companyNameStopWords[word] {
+			continue
+		}
+		acronym.WriteRune([]rune(word)[0])
+	}
+	return acronym.String()
+}
+
+// trigramSimilarity returns the Sørensen–Dice coefficient of the trigrams of the two values
+func trigramSimilarity(a, b string) float64 {
+	trigramsA, trigramsB := trigrams(a), trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+	common := 0
+	for trigram := range trigramsA {
+		if trigramsB[trigram] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(trigramsA)+len(trigramsB))
+}
+
+// trigrams returns the set of trigrams of the value padded with spaces
+func trigrams(value string) map[string]bool {
+	runes := []rune("  " + value + " ")
+	result := make(map[string]bool)
+	for i := 0; i+3 <= len(runes); i++ {
+		result[string(runes[i:i+3])] = true
+	}
+	return result
+}
+
+// editSimilarity returns 1 minus the Levenshtein distance of the two values relative to the longer value
+func editSimilarity(a, b string) float64 {
+	runesA, runesB := []rune(a), []rune(b)
+	longest := len(runesA)
+	if len(runesB) > longest {
+		longest = len(runesB)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshteinDistance(runesA, runesB))/float64(longest)
+}
+
+// levenshteinDistance returns the number of single character edits needed to change a into b
+func levenshteinDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// minInt returns the smaller of the two values
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,148 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Domain approval list rules
+//
+// Each entry of a CCLA domain approval list is a rule using the following grammar:
+//
+//	example.com                - matches example.com only
+//	*.example.com              - matches example.com and any subdomain, such as dev.example.com or a.dev.example.com
+//	.example.com               - the same as *.example.com
+//	*example.com               - matches any domain ending with example.com, such as example.com, dev.example.com
+//	                             or myexample.com
+//	!contractors.example.com   - excludes contractors.example.com
+//	!*.contractors.example.com - excludes contractors.example.com and any subdomain
+//
+// The .example.com and *example.com forms are the ones accepted before the rules were validated and are kept so
+// the stored approval lists keep matching the same contributors.
+//
+// A domain is covered when at least one inclusion rule matches and no exclusion rule matches, regardless of the
+// order of the rules. Domains are compared case insensitively, a trailing period is ignored and internationalized
+// domain names are compared in their ASCII (punycode) form, so bücher.example and xn--bcher-kva.example are equal.
+const (
+	DomainRuleWildcardPrefix       = "*."
+	DomainRuleLegacyWildcardPrefix = "."
+	DomainRuleSuffixPrefix         = "*"
+	DomainRuleExclusionPrefix      = "!"
+)
+
+// DomainRule is a parsed domain approval list rule
+type DomainRule struct {
+	Domain   string
+	Wildcard bool
+	Suffix   bool
+	Exclude  bool
+}
+
+// NormalizeDomain returns the lower case ASCII form of the domain without the trailing period
+func NormalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if domain == "" {
+		return "", errors.New("domain is empty")
+	}
+
+	asciiDomain, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized domain name: %v", err)
+	}
+
+	return strings.ToLower(asciiDomain), nil
+}
+
+// ParseDomainRule parses the domain approval list rule, returns an error if the rule is invalid
+func ParseDomainRule(rule string) (*DomainRule, error) {
+	domainRule := &DomainRule{}
+	value := strings.TrimSpace(rule)
+
+	if strings.HasPrefix(value, DomainRuleExclusionPrefix) {
+		domainRule.Exclude = true
+		value = strings.TrimSpace(strings.TrimPrefix(value, DomainRuleExclusionPrefix))
+	}
+	switch {
+	case strings.HasPrefix(value, DomainRuleWildcardPrefix):
+		domainRule.Wildcard = true
+		value = strings.TrimPrefix(value, DomainRuleWildcardPrefix)
+	case strings.HasPrefix(value, DomainRuleLegacyWildcardPrefix):
+		domainRule.Wildcard = true
+		value = strings.TrimPrefix(value, DomainRuleLegacyWildcardPrefix)
+	case strings.HasPrefix(value, DomainRuleSuffixPrefix):
+		domainRule.Suffix = true
+		value = strings.TrimPrefix(value, DomainRuleSuffixPrefix)
+	}
+	if strings.Contains(value, "*") {
+		return nil, errors.New("wildcard is only supported as the first label, e.g. *.example.com")
+	}
+
+	domain, err := NormalizeDomain(value)
+	if err != nil {
+		return nil, err
+	}
+	if msg, valid := ValidDomain(domain); !valid {
+		return nil, errors.New(msg)
+	}
+
+	domainRule.Domain = domain
+	return domainRule, nil
+}
+
+// Matches returns true if the rule matches the normalized domain
+func (r DomainRule) Matches(domain string) bool {
+	switch {
+	case r.Suffix:
+		return strings.HasSuffix(domain, r.Domain)
+	case r.Wildcard:
+		return domain == r.Domain || strings.HasSuffix(domain, "."+r.Domain)
+	}
+	return domain == r.Domain
+}
+
+// ValidDomainRule tests the specified domain approval list rule, returns true if the rule is valid, returns false and a message otherwise
+func ValidDomainRule(rule string) (string, bool) {
+	if _, err := ParseDomainRule(rule); err != nil {
+		return err.Error(), false
+	}
+	return "", true
+}
+
+// MatchDomainRules returns the first inclusion rule which matches the domain and true, or false when no inclusion
+// rule matches or an exclusion rule matches the domain
+func MatchDomainRules(rules []string, domain string) (string, bool) {
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		return "", false
+	}
+	normalizedDomain, normalizeErr := NormalizeDomain(domain)
+
+	var matchedRule string
+	for _, rule := range rules {
+		domainRule, err := ParseDomainRule(rule)
+		if err != nil || normalizeErr != nil {
+			// entries saved before the rules were validated are compared as plain domains
+			if matchedRule == "" && strings.EqualFold(strings.TrimSpace(rule), domain) {
+				matchedRule = rule
+			}
+			continue
+		}
+		if !domainRule.Matches(normalizedDomain) {
+			continue
+		}
+		if domainRule.Exclude {
+			return "", false
+		}
+		if matchedRule == "" {
+			matchedRule = rule
+		}
+	}
+
+	return matchedRule, matchedRule != ""
+}
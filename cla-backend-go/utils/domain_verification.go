@@ -0,0 +1,95 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"net"
+	"strings"
+)
+
+// DomainVerificationRecordPrefix is the prefix of the DNS TXT record a company publishes on a domain to verify it
+const DomainVerificationRecordPrefix = "easycla-domain-verification="
+
+// TXTResolver looks up the DNS TXT records of a domain - tests can use a local stand-in instead of the DNS
+type TXTResolver interface {
+	LookupTXT(domain string) ([]string, error)
+}
+
+type netTXTResolver struct{}
+
+// NewTXTResolver returns a TXT resolver using the DNS resolver of the system
+func NewTXTResolver() TXTResolver {
+	return netTXTResolver{}
+}
+
+// LookupTXT returns the DNS TXT records of the domain
+func (netTXTResolver) LookupTXT(domain string) ([]string, error) {
+	return net.LookupTXT(domain)
+}
+
+// DomainVerificationRecord returns the DNS TXT record value for the verification token
+func DomainVerificationRecord(token string) string {
+	return DomainVerificationRecordPrefix + token
+}
+
+// HasDomainVerificationRecord returns true if the domain publishes the DNS TXT record of the verification token
+func HasDomainVerificationRecord(resolver TXTResolver, domain, token string) (bool, error) {
+	records, err := resolver.LookupTXT(domain)
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	expected := DomainVerificationRecord(token)
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// UnverifiedDomainRules returns the domain approval list inclusion rules which are not covered by the verified
+// domains - a rule is covered when its domain is one of the verified domains or a subdomain of one. Exclusion rules
+// only narrow the approval list and are never reported. Suffix rules such as *example.com also match domains of other
+// owners, such as myexample.com, and are always reported.
+func UnverifiedDomainRules(rules []string, verifiedDomains []string) []string {
+	var normalizedDomains []string
+	for _, domain := range verifiedDomains {
+		normalized, err := NormalizeDomain(domain)
+		if err == nil {
+			normalizedDomains = append(normalizedDomains, normalized)
+		}
+	}
+
+	var unverified []string
+	for _, rule := range rules {
+		domainRule, err := ParseDomainRule(rule)
+		if err != nil {
+			unverified = append(unverified, rule)
+			continue
+		}
+		if domainRule.Exclude {
+			continue
+		}
+		if domainRule.Suffix {
+			unverified = append(unverified, rule)
+			continue
+		}
+
+		verified := false
+		for _, domain := range normalizedDomains {
+			if domainRule.Domain == domain || strings.HasSuffix(domainRule.Domain, "."+domain) {
+				verified = true
+				break
+			}
+		}
+		if !verified {
+			unverified = append(unverified, rule)
+		}
+	}
+	return unverified
+}
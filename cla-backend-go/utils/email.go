@@ -74,6 +74,39 @@ func SendEmail(subject string, body string, recipients []string) error {
 	return emailSender.SendEmail(subject, body, recipients)
 }
 
+// NotificationEmail is an email sent to a CLA Manager about an activity of a company and CLA Group
+type NotificationEmail struct {
+	Subject          string
+	Body             string
+	RecipientName    string
+	RecipientAddress string
+	CompanyName      string
+	ClaGroupName     string
+	// Summary is the HTML fragment describing the activity in a digest email
+	Summary string
+}
+
+// NotificationSender delivers notification emails according to the preference of the recipient
+type NotificationSender interface {
+	SendNotification(notification *NotificationEmail) error
+}
+
+var notificationSender NotificationSender
+
+// SetNotificationSender sets up the notification sender
+func SetNotificationSender(ns NotificationSender) {
+	notificationSender = ns
+}
+
+// SendNotificationEmail sends the notification email through the notification sender - the email is sent right away
+// when no notification sender is set
+func SendNotificationEmail(notification *NotificationEmail) error {
+	if notificationSender == nil {
+		return SendEmail(notification.Subject, notification.Body, []string{notification.RecipientAddress})
+	}
+	return notificationSender.SendNotification(notification)
+}
+
 // GetCorporateURL returns the corporate URL based on the specified flag
 func GetCorporateURL(isV2Project bool) string {
 	if isV2Project {
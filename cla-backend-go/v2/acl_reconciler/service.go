@@ -0,0 +1,295 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package acl_reconciler
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	v2AcsService "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
+	v2OrgService "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
+	v2UserService "github.com/communitybridge/easycla/cla-backend-go/v2/user-service"
+	"github.com/sirupsen/logrus"
+)
+
+// constants
+const (
+	RoleCLAManager = "cla-manager"
+
+	// DriftMissingScope is reported when a CLA Manager of the signature ACL has no cla-manager role scope
+	DriftMissingScope = "missing_scope"
+	// DriftStaleScope is reported when a cla-manager role scope has no matching entry in the signature ACL
+	DriftStaleScope = "stale_scope"
+)
+
+// ErrNoPrimaryEmail is returned when the role scope can not be created because the user has no primary email
+var ErrNoPrimaryEmail = errors.New("user has no primary email")
+
+// Drift is a difference between a CCLA signature ACL and the cla-manager role scopes of the organization
+type Drift struct {
+	DriftType         string `json:"drift_type"`
+	SignatureID       string `json:"signature_id"`
+	CompanyID         string `json:"company_id"`
+	CompanyExternalID string `json:"company_external_id"`
+	ClaGroupID        string `json:"cla_group_id"`
+	ProjectSFID       string `json:"project_sfid"`
+	LfUsername        string `json:"lf_username"`
+	ScopeID           string `json:"scope_id,omitempty"`
+	Repaired          bool   `json:"repaired"`
+	Error             string `json:"error,omitempty"`
+}
+
+// Report is the outcome of a reconciliation run
+type Report struct {
+	DryRun              bool     `json:"dry_run"`
+	SignaturesChecked   int      `json:"signatures_checked"`
+	SignaturesSkipped   int      `json:"signatures_skipped"`
+	DriftCount          int      `json:"drift_count"`
+	RepairedCount       int      `json:"repaired_count"`
+	RepairFailuresCount int      `json:"repair_failures_count"`
+	Drifts              []*Drift `json:"drifts"`
+}
+
+// Service reconciles the CCLA signature ACLs with the cla-manager role scopes
+type Service interface {
+	Reconcile(dryRun bool) (*Report, error)
+}
+
+type service struct {
+	signatureRepo        signatures.SignatureRepository
+	companyRepo          company.IRepository
+	projectsClaGroupRepo projects_cla_groups.Repository
+}
+
+// NewService creates a new reconciler service
+func NewService(signatureRepo signatures.SignatureRepository, companyRepo company.IRepository, pcgRepo projects_cla_groups.Repository) Service {
+	return &service{
+		signatureRepo:        signatureRepo,
+		companyRepo:          companyRepo,
+		projectsClaGroupRepo: pcgRepo,
+	}
+}
+
+// roleScopes holds the cla-manager role scope IDs of an organization by project SFID and LF username
+type roleScopes map[string]map[string]string
+
+// Reconcile compares the ACL of every CCLA signature with the cla-manager role scopes of the company for each project
+// of the CLA Group and reports the drift - unless dryRun is set, missing scopes are created and stale scopes are deleted
+func (s *service) Reconcile(dryRun bool) (*Report, error) {
+	sigs, err := s.signatureRepo.GetCCLASignatureACLs()
+	if err != nil {
+		log.Warnf("Reconcile - unable to load the CCLA signatures, error: %+v", err)
+		return nil, err
+	}
+
+	var roleID string
+	if !dryRun {
+		roleID, err = v2AcsService.GetClient().GetRoleID(RoleCLAManager)
+		if err != nil {
+			log.Warnf("Reconcile - unable to lookup the role ID of %s, error: %+v", RoleCLAManager, err)
+			return nil, err
+		}
+	}
+
+	report := &Report{DryRun: dryRun, Drifts: []*Drift{}}
+	orgScopes := map[string]roleScopes{}
+	for _, sig := range sigs {
+		drifts, ok := s.reconcileSignature(sig, orgScopes)
+		if !ok {
+			report.SignaturesSkipped++
+			continue
+		}
+		report.SignaturesChecked++
+
+		for _, drift := range drifts {
+			if !dryRun {
+				s.repair(drift, roleID)
+				if drift.Repaired {
+					report.RepairedCount++
+				} else {
+					report.RepairFailuresCount++
+				}
+			}
+			report.Drifts = append(report.Drifts, drift)
+		}
+	}
+	report.DriftCount = len(report.Drifts)
+
+	log.Debugf("Reconcile - checked %d signatures, skipped %d, found %d drifts, repaired %d",
+		report.SignaturesChecked, report.SignaturesSkipped, report.DriftCount, report.RepairedCount)
+	return report, nil
+}
+
+// reconcileSignature returns the drift of the signature, returns false when the signature can not be compared
+func (s *service) reconcileSignature(sig *models.Signature, orgScopes map[string]roleScopes) ([]*Drift, bool) {
+	f := logrus.Fields{
+		"functionName": "reconcileSignature",
+		"signatureID":  sig.SignatureID,
+		"claGroupID":   sig.ProjectID,
+		"companyID":    sig.SignatureReferenceID,
+	}
+
+	companyModel, err := s.companyRepo.GetCompany(sig.SignatureReferenceID)
+	if err != nil || companyModel == nil {
+		log.WithFields(f).Warnf("unable to lookup company, error: %+v - skipping", err)
+		return nil, false
+	}
+	if companyModel.CompanyExternalID == "" {
+		log.WithFields(f).Debug("company has no external ID - skipping")
+		return nil, false
+	}
+
+	projectList, err := s.projectsClaGroupRepo.GetProjectsIdsForClaGroup(sig.ProjectID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to lookup the projects of the CLA Group, error: %+v - skipping", err)
+		return nil, false
+	}
+	if len(projectList) == 0 {
+		log.WithFields(f).Debug("CLA Group has no projects - skipping")
+		return nil, false
+	}
+
+	scopes, ok := orgScopes[companyModel.CompanyExternalID]
+	if !ok {
+		scopes, err = loadRoleScopes(companyModel.CompanyExternalID)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to load the role scopes of organization: %s, error: %+v - skipping",
+				companyModel.CompanyExternalID, err)
+			return nil, false
+		}
+		orgScopes[companyModel.CompanyExternalID] = scopes
+	}
+
+	var aclUsernames []string
+	for _, manager := range sig.SignatureACL {
+		aclUsernames = append(aclUsernames, manager.LfUsername)
+	}
+	var projectSFIDs []string
+	for _, project := range projectList {
+		projectSFIDs = append(projectSFIDs, project.ProjectSFID)
+	}
+
+	drifts := DiffRoleScopes(aclUsernames, projectSFIDs, scopes)
+	for _, drift := range drifts {
+		drift.SignatureID = sig.SignatureID
+		drift.CompanyID = companyModel.CompanyID
+		drift.CompanyExternalID = companyModel.CompanyExternalID
+		drift.ClaGroupID = sig.ProjectID
+	}
+	return drifts, true
+}
+
+// DiffRoleScopes compares the CLA Managers of a signature ACL with the cla-manager role scopes of the organization,
+// held by project SFID and LF username, for each project of the CLA Group - a missing scope is reported for each
+// manager without a scope and a stale scope for each scope of a user who is not a manager
+func DiffRoleScopes(aclUsernames []string, projectSFIDs []string, scopes map[string]map[string]string) []*Drift {
+	acl := utils.NewStringSet()
+	for _, lfUsername := range aclUsernames {
+		acl.Add(lfUsername)
+	}
+	managers := acl.List()
+	sort.Strings(managers)
+
+	var drifts []*Drift
+	for _, projectSFID := range projectSFIDs {
+		projectScopes := scopes[projectSFID]
+		for _, lfUsername := range managers {
+			if _, found := projectScopes[lfUsername]; !found {
+				drifts = append(drifts, &Drift{DriftType: DriftMissingScope, ProjectSFID: projectSFID, LfUsername: lfUsername})
+			}
+		}
+
+		var scopeUsernames []string
+		for lfUsername := range projectScopes {
+			scopeUsernames = append(scopeUsernames, lfUsername)
+		}
+		sort.Strings(scopeUsernames)
+		for _, lfUsername := range scopeUsernames {
+			if !acl.Include(lfUsername) {
+				drifts = append(drifts, &Drift{DriftType: DriftStaleScope, ProjectSFID: projectSFID, LfUsername: lfUsername, ScopeID: projectScopes[lfUsername]})
+			}
+		}
+	}
+	return drifts
+}
+
+// repair creates the missing role scope or deletes the stale role scope of the drift
+func (s *service) repair(drift *Drift, roleID string) {
+	var err error
+	switch drift.DriftType {
+	case DriftMissingScope:
+		var email string
+		email, err = getPrimaryEmail(drift.LfUsername)
+		if err == nil {
+			err = v2OrgService.GetClient().CreateOrgUserRoleOrgScopeProjectOrg(email, drift.ProjectSFID, drift.CompanyExternalID, roleID)
+		}
+	case DriftStaleScope:
+		err = v2OrgService.GetClient().DeleteOrgUserRoleOrgScopeProjectOrg(drift.CompanyExternalID, roleID, drift.ScopeID, nil, nil)
+	}
+
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"org_id":       drift.CompanyExternalID,
+			"project_sfid": drift.ProjectSFID,
+			"lf_username":  drift.LfUsername,
+			"drift_type":   drift.DriftType,
+		}).Warnf("unable to repair cla-manager scope. error = %s", err)
+		drift.Error = err.Error()
+		return
+	}
+	drift.Repaired = true
+}
+
+// loadRoleScopes returns the cla-manager project|organization role scopes of the organization
+func loadRoleScopes(organizationID string) (roleScopes, error) {
+	result, err := v2OrgService.GetClient().ListOrgUserScopes(organizationID, []string{RoleCLAManager})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := roleScopes{}
+	for _, userRole := range result.Userroles {
+		if userRole.Contact.Username == "" {
+			continue
+		}
+		for _, roleScope := range userRole.RoleScopes {
+			if roleScope.RoleName != RoleCLAManager {
+				continue
+			}
+			for _, scope := range roleScope.Scopes {
+				objectList := strings.Split(scope.ObjectID, "|")
+				if len(objectList) != 2 {
+					continue
+				}
+				projectSFID := objectList[0]
+				if _, ok := scopes[projectSFID]; !ok {
+					scopes[projectSFID] = map[string]string{}
+				}
+				scopes[projectSFID][userRole.Contact.Username] = scope.ScopeID
+			}
+		}
+	}
+	return scopes, nil
+}
+
+// getPrimaryEmail returns the primary email of the user
+func getPrimaryEmail(lfUsername string) (string, error) {
+	user, err := v2UserService.GetClient().GetUserByUsername(lfUsername)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range user.Emails {
+		if e != nil && e.IsPrimary != nil && *e.IsPrimary {
+			return utils.StringValue(e.EmailAddress), nil
+		}
+	}
+	return "", ErrNoPrimaryEmail
+}
@@ -0,0 +1,87 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_group_config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"gopkg.in/yaml.v2"
+)
+
+// the formats of the configuration document
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// ErrEmptyDocument is returned when the configuration document has no content
+var ErrEmptyDocument = errors.New("the configuration document is empty")
+
+// MarshalConfig returns the configuration as a JSON or YAML document
+func MarshalConfig(config *models.ClaGroupConfig, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if format != FormatYAML {
+		return data, nil
+	}
+
+	// the JSON document is read as YAML into a map slice, which keeps the field order of the model
+	var document yaml.MapSlice
+	err = yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(document)
+}
+
+// UnmarshalConfig parses a JSON or YAML configuration document - every JSON document is a YAML document as well.
+// Unknown fields are rejected, so a misspelled field does not silently reset a setting.
+func UnmarshalConfig(data []byte) (*models.ClaGroupConfig, error) {
+	var document interface{}
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, ErrEmptyDocument
+	}
+
+	// YAML maps are decoded with interface keys, which can not be encoded as JSON
+	jsonData, err := json.Marshal(jsonValue(document))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	var config models.ClaGroupConfig
+	err = decoder.Decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// jsonValue converts the maps of the decoded YAML document to maps with string keys
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonValue(item)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,135 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_group_config
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_group"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// yamlMime is the content type of an exported YAML document
+const yamlMime = "application/x-yaml"
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, v1ProjectService v1Project.Service) {
+	api.ClaGroupExportClaGroupConfigHandler = cla_group.ExportClaGroupConfigHandlerFunc(
+		func(params cla_group.ExportClaGroupConfigParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			cg, err := v1ProjectService.GetCLAGroupByID(params.ClaGroupID)
+			if err != nil {
+				if err == v1Project.ErrProjectDoesNotExist {
+					return cla_group.NewExportClaGroupConfigNotFound().WithPayload(&models.ErrorResponse{
+						Code:    "404",
+						Message: fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found", params.ClaGroupID),
+					})
+				}
+				return cla_group.NewExportClaGroupConfigInternalServerError().WithPayload(&models.ErrorResponse{
+					Code:    "500",
+					Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID, error = %+v", err),
+				})
+			}
+			if !utils.IsUserAuthorizedForProject(authUser, cg.FoundationSFID) {
+				return cla_group.NewExportClaGroupConfigForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ExportCLAGroupConfig with Project scope of %s",
+						authUser.UserName, cg.FoundationSFID),
+				})
+			}
+
+			config, err := service.ExportConfig(params.ClaGroupID)
+			if err != nil {
+				log.Warnf("unable to export the configuration of cla group: %s, error: %+v", params.ClaGroupID, err)
+				return cla_group.NewExportClaGroupConfigInternalServerError().WithPayload(&models.ErrorResponse{
+					Code:    "500",
+					Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
+				})
+			}
+			if utils.StringValue(params.Format) != FormatYAML {
+				return cla_group.NewExportClaGroupConfigOK().WithPayload(config)
+			}
+
+			document, err := MarshalConfig(config, FormatYAML)
+			if err != nil {
+				log.Warnf("unable to marshal the configuration of cla group: %s, error: %+v", params.ClaGroupID, err)
+				return cla_group.NewExportClaGroupConfigInternalServerError().WithPayload(&models.ErrorResponse{
+					Code:    "500",
+					Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
+				})
+			}
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+				rw.Header().Set(runtime.HeaderContentType, yamlMime)
+				rw.WriteHeader(http.StatusOK)
+				_, writeErr := rw.Write(document)
+				if writeErr != nil {
+					log.Warnf("unable to write the configuration of cla group: %s, error: %v", params.ClaGroupID, writeErr)
+				}
+			})
+		})
+
+	api.ClaGroupApplyClaGroupConfigHandler = cla_group.ApplyClaGroupConfigHandlerFunc(
+		func(params cla_group.ApplyClaGroupConfigParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if params.ApplyInput == nil || params.ApplyInput.Document == nil {
+				return cla_group.NewApplyClaGroupConfigBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: "EasyCLA - 400 Bad Request - the configuration document is required",
+				})
+			}
+			config, err := UnmarshalConfig([]byte(*params.ApplyInput.Document))
+			if err != nil {
+				return cla_group.NewApplyClaGroupConfigBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: fmt.Sprintf("EasyCLA - 400 Bad Request - unable to parse the configuration document, error = %s", err.Error()),
+				})
+			}
+
+			cg, err := v1ProjectService.GetCLAGroupByID(params.ClaGroupID)
+			if err != nil {
+				if err == v1Project.ErrProjectDoesNotExist {
+					return cla_group.NewApplyClaGroupConfigNotFound().WithPayload(&models.ErrorResponse{
+						Code:    "404",
+						Message: fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found", params.ClaGroupID),
+					})
+				}
+				return cla_group.NewApplyClaGroupConfigInternalServerError().WithPayload(&models.ErrorResponse{
+					Code:    "500",
+					Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID, error = %+v", err),
+				})
+			}
+			if !utils.IsUserAuthorizedForProject(authUser, cg.FoundationSFID) {
+				return cla_group.NewApplyClaGroupConfigForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ApplyCLAGroupConfig with Project scope of %s",
+						authUser.UserName, cg.FoundationSFID),
+				})
+			}
+
+			plan, err := service.ApplyConfig(params.ClaGroupID, config, params.ApplyInput.DryRun, authUser.UserName)
+			if err != nil {
+				log.Warnf("unable to apply the configuration to cla group: %s, error: %+v", params.ClaGroupID, err)
+				if err == v1Project.ErrCLAGroupArchived || strings.Contains(err.Error(), "bad request") {
+					return cla_group.NewApplyClaGroupConfigBadRequest().WithPayload(&models.ErrorResponse{
+						Code:    "400",
+						Message: fmt.Sprintf("EasyCLA - 400 Bad Request - %s", err.Error()),
+					})
+				}
+				return cla_group.NewApplyClaGroupConfigInternalServerError().WithPayload(&models.ErrorResponse{
+					Code:    "500",
+					Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
+				})
+			}
+			return cla_group.NewApplyClaGroupConfigOK().WithPayload(plan)
+		})
+}
@@ -0,0 +1,717 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_group_config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	v1Gerrits "github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
+	v1Template "github.com/communitybridge/easycla/cla-backend-go/template"
+	"github.com/communitybridge/easycla/cla-backend-go/v2/cla_groups"
+	v2GithubOrganizations "github.com/communitybridge/easycla/cla-backend-go/v2/github_organizations"
+	v2Repositories "github.com/communitybridge/easycla/cla-backend-go/v2/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+// the actions of the planned changes
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+)
+
+// the resource types of the planned changes
+const (
+	resourceCLAGroup           = "cla_group"
+	resourceTemplate           = "template"
+	resourceProject            = "project"
+	resourceGithubOrganization = "github_organization"
+	resourceGithubRepository   = "github_repository"
+	resourceGerritInstance     = "gerrit_instance"
+)
+
+// Service exports the configuration of a cla group and applies a configuration to a cla group
+type Service interface {
+	ExportConfig(claGroupID string) (*models.ClaGroupConfig, error)
+	ApplyConfig(claGroupID string, config *models.ClaGroupConfig, dryRun bool, lfUsername string) (*models.ClaGroupConfigPlan, error)
+}
+
+type service struct {
+	v1ProjectService           v1Project.Service
+	claGroupService            cla_groups.Service
+	projectsClaGroupsRepo      projects_cla_groups.Repository
+	templateService            v1Template.Service
+	repositoriesService        v2Repositories.Service
+	gerritService              v1Gerrits.Service
+	githubOrganizationsService v2GithubOrganizations.Service
+	eventsService              events.Service
+}
+
+// NewService creates a new cla group configuration service
+func NewService(v1ProjectService v1Project.Service, claGroupService cla_groups.Service, projectsClaGroupsRepo projects_cla_groups.Repository,
+	templateService v1Template.Service, repositoriesService v2Repositories.Service, gerritService v1Gerrits.Service,
+	githubOrganizationsService v2GithubOrganizations.Service, eventsService events.Service) Service {
+	return service{
+		v1ProjectService:           v1ProjectService,
+		claGroupService:            claGroupService,
+		projectsClaGroupsRepo:      projectsClaGroupsRepo,
+		templateService:            templateService,
+		repositoriesService:        repositoriesService,
+		gerritService:              gerritService,
+		githubOrganizationsService: githubOrganizationsService,
+		eventsService:              eventsService,
+	}
+}
+
+// liveState is the live configuration of a cla group with the ids of the records which can be deleted
+type liveState struct {
+	config        *models.ClaGroupConfig
+	repositoryIDs map[string]string
+	gerritIDs     map[string]string
+}
+
+// plannedChange is a change of the plan with the function making the change
+type plannedChange struct {
+	change *models.ClaGroupConfigChange
+	apply  func() error
+}
+
+// ExportConfig returns the live configuration of the cla group
+func (s service) ExportConfig(claGroupID string) (*models.ClaGroupConfig, error) {
+	claGroup, err := s.v1ProjectService.GetCLAGroupByID(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.loadState(claGroup)
+	if err != nil {
+		return nil, err
+	}
+	return state.config, nil
+}
+
+// ApplyConfig compares the configuration with the live configuration of the cla group and makes the changes needed
+// for the cla group to match the configuration - the settings, template, enrolled projects, github organizations and
+// repositories and gerrit instances. With dryRun set only the plan is returned. The plan is always computed against
+// the live configuration, so an apply which failed part way can be run again.
+func (s service) ApplyConfig(claGroupID string, config *models.ClaGroupConfig, dryRun bool, lfUsername string) (*models.ClaGroupConfigPlan, error) {
+	f := logrus.Fields{"function": "ApplyConfig", "cla_group_id": claGroupID, "dry_run": dryRun}
+	claGroup, err := s.v1ProjectService.GetCLAGroupByID(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if claGroup.ProjectArchived {
+		return nil, v1Project.ErrCLAGroupArchived
+	}
+	err = s.validateConfig(claGroup, config)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.loadState(claGroup)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.planChanges(claGroup, state, config)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &models.ClaGroupConfigPlan{
+		ClaGroupID: claGroupID,
+		Changes:    make([]*models.ClaGroupConfigChange, 0, len(changes)),
+		DryRun:     dryRun,
+	}
+	for _, planned := range changes {
+		plan.Changes = append(plan.Changes, planned.change)
+	}
+	if dryRun {
+		return plan, nil
+	}
+
+	var applied []string
+	for _, planned := range changes {
+		log.WithFields(f).Debugf("applying change: %+v", planned.change)
+		err = planned.apply()
+		if err != nil {
+			log.WithFields(f).Warnf("unable to apply change: %+v, error: %+v", planned.change, err)
+			break
+		}
+		applied = append(applied, describeChange(planned.change))
+	}
+	if len(applied) > 0 {
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.CLAGroupConfigApplied,
+			ProjectModel: claGroup,
+			LfUsername:   lfUsername,
+			EventData:    &events.CLAGroupConfigAppliedEventData{Changes: applied},
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan.Applied = true
+	return plan, nil
+}
+
+// validateConfig checks the configuration can be applied to the cla group
+func (s service) validateConfig(claGroup *v1Models.Project, config *models.ClaGroupConfig) error {
+	if config.FoundationSfid != "" && config.FoundationSfid != claGroup.FoundationSFID {
+		return fmt.Errorf("bad request: the foundation_sfid of the cla group can not be changed")
+	}
+	if config.ClaGroupName == "" {
+		return fmt.Errorf("bad request: cla_group_name cannot be empty")
+	}
+	if !config.IclaEnabled && !config.CclaEnabled {
+		return fmt.Errorf("bad request: can not configure cla group with both icla and ccla disabled")
+	}
+	if config.CclaRequiresIcla && !(config.IclaEnabled && config.CclaEnabled) {
+		return fmt.Errorf("bad request: ccla_requires_icla can not be enabled if one of icla/ccla is disabled")
+	}
+	err := v1Signatures.ValidateContributionPolicy(config.ContributionPolicy, config.ContributionPolicyYears, config.IclaEnabled, config.CclaEnabled)
+	if err != nil {
+		return fmt.Errorf("bad request: %s", err)
+	}
+	if config.ClaGroupName != claGroup.ProjectName {
+		existingCLAGroup, err := s.v1ProjectService.GetCLAGroupByName(config.ClaGroupName)
+		if err != nil {
+			return err
+		}
+		if existingCLAGroup != nil && existingCLAGroup.ProjectID != claGroup.ProjectID {
+			return fmt.Errorf("bad request: cla_group with name %s already exist", config.ClaGroupName)
+		}
+	}
+
+	projectSFIDs := make(map[string]bool)
+	for _, projectSFID := range config.ProjectSfidList {
+		if projectSFIDs[projectSFID] {
+			return fmt.Errorf("bad request: project_sfid: %s is listed more than once", projectSFID)
+		}
+		projectSFIDs[projectSFID] = true
+	}
+	keys := make(map[string]bool)
+	for _, org := range config.GithubOrganizations {
+		if !projectSFIDs[org.ProjectSfid] {
+			return fmt.Errorf("bad request: github organization: %s belongs to project_sfid: %s which is not enrolled", org.GithubOrganizationName, org.ProjectSfid)
+		}
+		key := githubOrganizationKey(org.ProjectSfid, org.GithubOrganizationName)
+		if keys[key] {
+			return fmt.Errorf("bad request: github organization: %s is listed more than once for project_sfid: %s", org.GithubOrganizationName, org.ProjectSfid)
+		}
+		keys[key] = true
+	}
+	for _, githubRepo := range config.GithubRepositories {
+		if !keys[githubOrganizationKey(githubRepo.ProjectSfid, githubRepo.GithubOrganizationName)] {
+			return fmt.Errorf("bad request: github repository: %s belongs to github organization: %s which is not configured for project_sfid: %s",
+				githubRepo.RepositoryGithubID, githubRepo.GithubOrganizationName, githubRepo.ProjectSfid)
+		}
+		key := githubRepositoryKey(githubRepo.ProjectSfid, githubRepo.RepositoryGithubID)
+		if keys[key] {
+			return fmt.Errorf("bad request: github repository: %s is listed more than once for project_sfid: %s", githubRepo.RepositoryGithubID, githubRepo.ProjectSfid)
+		}
+		keys[key] = true
+	}
+	for _, gerrit := range config.GerritInstances {
+		if !projectSFIDs[gerrit.ProjectSfid] {
+			return fmt.Errorf("bad request: gerrit instance: %s belongs to project_sfid: %s which is not enrolled", gerrit.GerritName, gerrit.ProjectSfid)
+		}
+		key := gerritInstanceKey(gerrit.ProjectSfid, gerrit.GerritName)
+		if keys[key] {
+			return fmt.Errorf("bad request: gerrit instance: %s is listed more than once for project_sfid: %s", gerrit.GerritName, gerrit.ProjectSfid)
+		}
+		keys[key] = true
+	}
+	return nil
+}
+
+// loadState loads the live configuration of the cla group
+func (s service) loadState(claGroup *v1Models.Project) (*liveState, error) {
+	f := logrus.Fields{"function": "loadState", "cla_group_id": claGroup.ProjectID}
+	state := &liveState{
+		config: &models.ClaGroupConfig{
+			ClaGroupName:            claGroup.ProjectName,
+			ClaGroupDescription:     claGroup.ProjectDescription,
+			FoundationSfid:          claGroup.FoundationSFID,
+			IclaEnabled:             claGroup.ProjectICLAEnabled,
+			CclaEnabled:             claGroup.ProjectCCLAEnabled,
+			CclaRequiresIcla:        claGroup.ProjectCCLARequiresICLA,
+			ContributionPolicy:      v1Signatures.GetContributionPolicy(claGroup),
+			ContributionPolicyYears: claGroup.ProjectContributionPolicyYears,
+			ProjectSfidList:         []string{},
+			GithubOrganizations:     []*models.ClaGroupConfigGithubOrganization{},
+			GithubRepositories:      []*models.ClaGroupConfigGithubRepository{},
+			GerritInstances:         []*models.ClaGroupConfigGerritInstance{},
+		},
+		repositoryIDs: make(map[string]string),
+		gerritIDs:     make(map[string]string),
+	}
+
+	templateFields, err := s.templateService.GetCLAGroupTemplateFields(claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the template fields, error: %+v", err)
+		return nil, err
+	}
+	if templateFields.TemplateID != "" {
+		metaFields := make(map[string]string)
+		for _, metaField := range templateFields.MetaFields {
+			metaFields[metaField.TemplateVariable] = metaField.Value
+		}
+		state.config.Template = &models.ClaGroupConfigTemplate{
+			TemplateID: templateFields.TemplateID,
+			MetaFields: metaFields,
+		}
+	}
+
+	projects, err := s.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the projects, error: %+v", err)
+		return nil, err
+	}
+	for _, project := range projects {
+		state.config.ProjectSfidList = append(state.config.ProjectSfidList, project.ProjectSFID)
+	}
+	sort.Strings(state.config.ProjectSfidList)
+
+	for _, projectSFID := range state.config.ProjectSfidList {
+		orgs, orgErr := s.githubOrganizationsService.GetGithubOrganizations(projectSFID)
+		if orgErr != nil {
+			log.WithFields(f).Warnf("unable to load the github organizations of project: %s, error: %+v", projectSFID, orgErr)
+			return nil, orgErr
+		}
+		for _, org := range orgs.List {
+			state.config.GithubOrganizations = append(state.config.GithubOrganizations, &models.ClaGroupConfigGithubOrganization{
+				ProjectSfid:            projectSFID,
+				GithubOrganizationName: org.GithubOrganizationName,
+			})
+		}
+
+		repos, repoErr := s.repositoriesService.ListProjectRepositories(projectSFID)
+		if repoErr != nil {
+			log.WithFields(f).Warnf("unable to load the github repositories of project: %s, error: %+v", projectSFID, repoErr)
+			return nil, repoErr
+		}
+		for _, githubRepo := range repos.List {
+			if githubRepo.RepositoryProjectID != claGroup.ProjectID {
+				continue
+			}
+			state.config.GithubRepositories = append(state.config.GithubRepositories, &models.ClaGroupConfigGithubRepository{
+				ProjectSfid:            projectSFID,
+				GithubOrganizationName: githubRepo.RepositoryOrganizationName,
+				RepositoryGithubID:     githubRepo.RepositoryExternalID,
+				RepositoryName:         githubRepo.RepositoryName,
+			})
+			state.repositoryIDs[githubRepositoryKey(projectSFID, githubRepo.RepositoryExternalID)] = githubRepo.RepositoryID
+		}
+	}
+
+	gerrits, err := s.gerritService.GetClaGroupGerrits(claGroup.ProjectID, nil)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the gerrit instances, error: %+v", err)
+		return nil, err
+	}
+	for _, gerrit := range gerrits.List {
+		state.config.GerritInstances = append(state.config.GerritInstances, &models.ClaGroupConfigGerritInstance{
+			ProjectSfid: gerrit.ProjectSFID,
+			GerritName:  gerrit.GerritName,
+			GerritURL:   gerrit.GerritURL,
+			GroupIDIcla: gerrit.GroupIDIcla,
+			GroupIDCcla: gerrit.GroupIDCcla,
+		})
+		state.gerritIDs[gerritInstanceKey(gerrit.ProjectSFID, gerrit.GerritName)] = gerrit.GerritID
+	}
+	return state, nil
+}
+
+// ConfigChange is a change of the plan with the configured or live resource it changes
+type ConfigChange struct {
+	Change             *models.ClaGroupConfigChange
+	GithubOrganization *models.ClaGroupConfigGithubOrganization
+	GithubRepository   *models.ClaGroupConfigGithubRepository
+	GerritInstance     *models.ClaGroupConfigGerritInstance
+}
+
+// PlanConfigChanges returns the changes needed for the live configuration to match the configuration. Records are
+// created before records are deleted, so a project keeps its CLA checks while its configuration is changed. An empty
+// description leaves the description as it is.
+func PlanConfigChanges(live *models.ClaGroupConfig, config *models.ClaGroupConfig) []*ConfigChange {
+	var changes []*ConfigChange
+
+	var updatedSettings []string
+	if config.ClaGroupName != live.ClaGroupName {
+		updatedSettings = append(updatedSettings, "cla_group_name")
+	}
+	if config.ClaGroupDescription != "" && config.ClaGroupDescription != live.ClaGroupDescription {
+		updatedSettings = append(updatedSettings, "cla_group_description")
+	}
+	if config.IclaEnabled != live.IclaEnabled {
+		updatedSettings = append(updatedSettings, "icla_enabled")
+	}
+	if config.CclaEnabled != live.CclaEnabled {
+		updatedSettings = append(updatedSettings, "ccla_enabled")
+	}
+	if config.CclaRequiresIcla != live.CclaRequiresIcla {
+		updatedSettings = append(updatedSettings, "ccla_requires_icla")
+	}
+	if contributionPolicy(config) != live.ContributionPolicy || config.ContributionPolicyYears != live.ContributionPolicyYears {
+		updatedSettings = append(updatedSettings, "contribution_policy")
+	}
+	if len(updatedSettings) > 0 {
+		changes = append(changes, &ConfigChange{
+			Change: &models.ClaGroupConfigChange{Action: actionUpdate, ResourceType: resourceCLAGroup, Resource: config.ClaGroupName, Detail: strings.Join(updatedSettings, ", ")},
+		})
+	}
+
+	if templateChange := planTemplateChange(live, config); templateChange != nil {
+		changes = append(changes, templateChange)
+	}
+
+	liveProjects := make(map[string]bool)
+	for _, projectSFID := range live.ProjectSfidList {
+		liveProjects[projectSFID] = true
+	}
+	desiredProjects := make(map[string]bool)
+	for _, projectSFID := range config.ProjectSfidList {
+		desiredProjects[projectSFID] = true
+		if liveProjects[projectSFID] {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Change: &models.ClaGroupConfigChange{Action: actionCreate, ResourceType: resourceProject, Resource: projectSFID, ProjectSfid: projectSFID},
+		})
+	}
+
+	liveOrgs := make(map[string]bool)
+	for _, org := range live.GithubOrganizations {
+		liveOrgs[githubOrganizationKey(org.ProjectSfid, org.GithubOrganizationName)] = true
+	}
+	desiredOrgs := make(map[string]bool)
+	for _, org := range config.GithubOrganizations {
+		key := githubOrganizationKey(org.ProjectSfid, org.GithubOrganizationName)
+		desiredOrgs[key] = true
+		if liveOrgs[key] {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Change:             &models.ClaGroupConfigChange{Action: actionCreate, ResourceType: resourceGithubOrganization, Resource: org.GithubOrganizationName, ProjectSfid: org.ProjectSfid},
+			GithubOrganization: org,
+		})
+	}
+
+	liveRepos := make(map[string]bool)
+	for _, githubRepo := range live.GithubRepositories {
+		liveRepos[githubRepositoryKey(githubRepo.ProjectSfid, githubRepo.RepositoryGithubID)] = true
+	}
+	desiredRepos := make(map[string]bool)
+	for _, githubRepo := range config.GithubRepositories {
+		key := githubRepositoryKey(githubRepo.ProjectSfid, githubRepo.RepositoryGithubID)
+		desiredRepos[key] = true
+		if liveRepos[key] {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Change:           &models.ClaGroupConfigChange{Action: actionCreate, ResourceType: resourceGithubRepository, Resource: repositoryName(githubRepo), ProjectSfid: githubRepo.ProjectSfid},
+			GithubRepository: githubRepo,
+		})
+	}
+
+	liveGerrits := make(map[string]*models.ClaGroupConfigGerritInstance)
+	for _, gerrit := range live.GerritInstances {
+		liveGerrits[gerritInstanceKey(gerrit.ProjectSfid, gerrit.GerritName)] = gerrit
+	}
+	desiredGerrits := make(map[string]bool)
+	for _, gerrit := range config.GerritInstances {
+		key := gerritInstanceKey(gerrit.ProjectSfid, gerrit.GerritName)
+		desiredGerrits[key] = true
+		liveGerrit, exists := liveGerrits[key]
+		if exists && reflect.DeepEqual(liveGerrit, gerrit) {
+			continue
+		}
+		action := actionCreate
+		if exists {
+			// gerrit instances can not be updated, the instance is replaced
+			action = actionUpdate
+		}
+		changes = append(changes, &ConfigChange{
+			Change:         &models.ClaGroupConfigChange{Action: action, ResourceType: resourceGerritInstance, Resource: gerrit.GerritName, ProjectSfid: gerrit.ProjectSfid},
+			GerritInstance: gerrit,
+		})
+	}
+
+	for _, gerrit := range live.GerritInstances {
+		if desiredGerrits[gerritInstanceKey(gerrit.ProjectSfid, gerrit.GerritName)] {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Change:         &models.ClaGroupConfigChange{Action: actionDelete, ResourceType: resourceGerritInstance, Resource: gerrit.GerritName, ProjectSfid: gerrit.ProjectSfid},
+			GerritInstance: gerrit,
+		})
+	}
+
+	// the repositories are deleted before their organizations, which delete the remaining repositories of the organization
+	for _, githubRepo := range live.GithubRepositories {
+		if desiredRepos[githubRepositoryKey(githubRepo.ProjectSfid, githubRepo.RepositoryGithubID)] {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Change:           &models.ClaGroupConfigChange{Action: actionDelete, ResourceType: resourceGithubRepository, Resource: repositoryName(githubRepo), ProjectSfid: githubRepo.ProjectSfid},
+			GithubRepository: githubRepo,
+		})
+	}
+
+	for _, org := range live.GithubOrganizations {
+		if desiredOrgs[githubOrganizationKey(org.ProjectSfid, org.GithubOrganizationName)] {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Change:             &models.ClaGroupConfigChange{Action: actionDelete, ResourceType: resourceGithubOrganization, Resource: org.GithubOrganizationName, ProjectSfid: org.ProjectSfid},
+			GithubOrganization: org,
+		})
+	}
+
+	for _, projectSFID := range live.ProjectSfidList {
+		if desiredProjects[projectSFID] {
+			continue
+		}
+		changes = append(changes, &ConfigChange{
+			Change: &models.ClaGroupConfigChange{Action: actionDelete, ResourceType: resourceProject, Resource: projectSFID, ProjectSfid: projectSFID},
+		})
+	}
+
+	return changes
+}
+
+// planChanges returns the changes of the plan with the functions making the changes
+func (s service) planChanges(claGroup *v1Models.Project, state *liveState, config *models.ClaGroupConfig) ([]*plannedChange, error) {
+	var changes []*plannedChange
+	for _, configChange := range PlanConfigChanges(state.config, config) {
+		apply, err := s.changeFunction(claGroup, state, config, configChange)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &plannedChange{change: configChange.Change, apply: apply})
+	}
+	return changes, nil
+}
+
+// changeFunction returns the function making the change
+func (s service) changeFunction(claGroup *v1Models.Project, state *liveState, config *models.ClaGroupConfig, configChange *ConfigChange) (func() error, error) {
+	change := configChange.Change
+	switch change.ResourceType {
+	case resourceCLAGroup:
+		return func() error {
+			// an empty description is not written, the description is left as it is
+			_, err := s.v1ProjectService.UpdateCLAGroup(&v1Models.Project{
+				ProjectID:                      claGroup.ProjectID,
+				ProjectName:                    config.ClaGroupName,
+				ProjectDescription:             config.ClaGroupDescription,
+				ProjectICLAEnabled:             config.IclaEnabled,
+				ProjectCCLAEnabled:             config.CclaEnabled,
+				ProjectCCLARequiresICLA:        config.CclaRequiresIcla,
+				ProjectContributionPolicy:      contributionPolicy(config),
+				ProjectContributionPolicyYears: config.ContributionPolicyYears,
+			})
+			return err
+		}, nil
+
+	case resourceTemplate:
+		templateFields, err := s.templateFields(config.Template)
+		if err != nil {
+			return nil, err
+		}
+		return func() error {
+			_, createErr := s.templateService.CreateCLAGroupTemplate(context.Background(), claGroup.ProjectID, templateFields)
+			return createErr
+		}, nil
+
+	case resourceProject:
+		projectSFID := change.ProjectSfid
+		if change.Action == actionDelete {
+			return func() error {
+				return s.claGroupService.UnenrollProjectsInClaGroup(claGroup.ProjectID, []string{projectSFID})
+			}, nil
+		}
+		return func() error {
+			return s.claGroupService.EnrollProjectsInClaGroup(claGroup.ProjectID, claGroup.FoundationSFID, []string{projectSFID})
+		}, nil
+
+	case resourceGithubOrganization:
+		org := configChange.GithubOrganization
+		if change.Action == actionDelete {
+			return func() error {
+				return s.githubOrganizationsService.DeleteGithubOrganization(org.ProjectSfid, org.GithubOrganizationName)
+			}, nil
+		}
+		return func() error {
+			_, addErr := s.githubOrganizationsService.AddGithubOrganization(org.ProjectSfid, &models.CreateGithubOrganization{
+				OrganizationName: org.GithubOrganizationName,
+			})
+			return addErr
+		}, nil
+
+	case resourceGithubRepository:
+		githubRepo := configChange.GithubRepository
+		if change.Action == actionDelete {
+			repositoryID := state.repositoryIDs[githubRepositoryKey(githubRepo.ProjectSfid, githubRepo.RepositoryGithubID)]
+			return func() error {
+				return s.repositoriesService.DeleteGithubRepository(githubRepo.ProjectSfid, repositoryID)
+			}, nil
+		}
+		return func() error {
+			_, addErr := s.repositoriesService.AddGithubRepository(githubRepo.ProjectSfid, &models.GithubRepositoryInput{
+				ClaGroupID:             aws.String(claGroup.ProjectID),
+				GithubOrganizationName: aws.String(githubRepo.GithubOrganizationName),
+				RepositoryGithubID:     aws.String(githubRepo.RepositoryGithubID),
+			})
+			return addErr
+		}, nil
+
+	case resourceGerritInstance:
+		gerrit := configChange.GerritInstance
+		gerritID := state.gerritIDs[gerritInstanceKey(gerrit.ProjectSfid, gerrit.GerritName)]
+		if change.Action == actionDelete {
+			return func() error {
+				return s.gerritService.DeleteGerrit(gerritID)
+			}, nil
+		}
+		replace := change.Action == actionUpdate
+		return func() error {
+			if replace {
+				deleteErr := s.gerritService.DeleteGerrit(gerritID)
+				if deleteErr != nil {
+					return deleteErr
+				}
+			}
+			_, addErr := s.gerritService.AddGerrit(claGroup.ProjectID, gerrit.ProjectSfid, &v1Models.AddGerritInput{
+				GerritName:  aws.String(gerrit.GerritName),
+				GerritURL:   aws.String(gerrit.GerritURL),
+				GroupIDIcla: gerrit.GroupIDIcla,
+				GroupIDCcla: gerrit.GroupIDCcla,
+			})
+			return addErr
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported change of resource type: %s", change.ResourceType)
+}
+
+// contributionPolicy returns the configured contribution policy - an omitted contribution policy is the default policy
+func contributionPolicy(config *models.ClaGroupConfig) string {
+	if config.ContributionPolicy == "" {
+		return v1Signatures.ContributionPolicyICLAOrCCLA
+	}
+	return config.ContributionPolicy
+}
+
+// planTemplateChange returns the change generating the documents of the cla group from the configured template. The
+// documents are generated again when the template or its field values change, or when ICLA or CCLA is enabled -
+// they are left as they are when the configuration has no template.
+func planTemplateChange(live *models.ClaGroupConfig, config *models.ClaGroupConfig) *ConfigChange {
+	if config.Template == nil {
+		return nil
+	}
+
+	var detail string
+	switch {
+	case live.Template == nil || live.Template.TemplateID != config.Template.TemplateID:
+		detail = "template_id"
+	case !metaFieldsEqual(live.Template.MetaFields, config.Template.MetaFields):
+		detail = "meta_fields"
+	case (config.IclaEnabled && !live.IclaEnabled) || (config.CclaEnabled && !live.CclaEnabled):
+		detail = "documents of the enabled icla/ccla"
+	default:
+		return nil
+	}
+
+	action := actionUpdate
+	if live.Template == nil {
+		action = actionCreate
+	}
+	return &ConfigChange{
+		Change: &models.ClaGroupConfigChange{Action: action, ResourceType: resourceTemplate, Resource: config.Template.TemplateID, Detail: detail},
+	}
+}
+
+// templateFields returns the fields generating the documents from the configured template - every meta field of the
+// template needs a value
+func (s service) templateFields(template *models.ClaGroupConfigTemplate) (*v1Models.CreateClaGroupTemplate, error) {
+	templates, err := s.templateService.GetTemplates(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		if t.ID != template.TemplateID {
+			continue
+		}
+		result := &v1Models.CreateClaGroupTemplate{TemplateID: t.ID}
+		for _, field := range t.MetaFields {
+			value, ok := template.MetaFields[field.TemplateVariable]
+			if !ok || value == "" {
+				return nil, fmt.Errorf("bad request: template field value of variable %s cannot be empty", field.TemplateVariable)
+			}
+			result.MetaFields = append(result.MetaFields, &v1Models.MetaField{
+				Name:             field.Name,
+				Description:      field.Description,
+				TemplateVariable: field.TemplateVariable,
+				Value:            value,
+			})
+		}
+		if len(template.MetaFields) != len(result.MetaFields) {
+			return nil, fmt.Errorf("bad request: the meta_fields contain variables which are not fields of template: %s", t.ID)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("bad request: template: %s not found", template.TemplateID)
+}
+
+// metaFieldsEqual returns true when both meta field values are the same - an empty and a missing map are equal
+func metaFieldsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for variable, value := range a {
+		if otherValue, ok := b[variable]; !ok || otherValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// describeChange returns the change as recorded in the event
+func describeChange(change *models.ClaGroupConfigChange) string {
+	description := fmt.Sprintf("%s %s %s", change.Action, change.ResourceType, change.Resource)
+	if change.ProjectSfid != "" && change.ResourceType != resourceProject {
+		description = fmt.Sprintf("%s of project %s", description, change.ProjectSfid)
+	}
+	return description
+}
+
+// repositoryName returns the name of the github repository in the plan, the github id when the name is not known
+func repositoryName(githubRepo *models.ClaGroupConfigGithubRepository) string {
+	if githubRepo.RepositoryName != "" {
+		return githubRepo.RepositoryName
+	}
+	return githubRepo.RepositoryGithubID
+}
+
+// github organization names are case insensitive
+func githubOrganizationKey(projectSFID, organizationName string) string {
+	return fmt.Sprintf("org#%s#%s", projectSFID, strings.ToLower(organizationName))
+}
+
+func githubRepositoryKey(projectSFID, repositoryGithubID string) string {
+	return fmt.Sprintf("repo#%s#%s", projectSFID, repositoryGithubID)
+}
+
+func gerritInstanceKey(projectSFID, gerritName string) string {
+	return fmt.Sprintf("gerrit#%s#%s", projectSFID, gerritName)
+}
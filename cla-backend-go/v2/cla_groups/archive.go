@@ -0,0 +1,257 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_groups
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	v1Gerrits "github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	v1Repositories "github.com/communitybridge/easycla/cla-backend-go/repositories"
+	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultCLAGroupArchiveRetention is the time an archived cla group can be restored before it is purged
+const DefaultCLAGroupArchiveRetention = 30 * 24 * time.Hour
+
+// ErrCLAGroupNameConflict is returned when an archived cla group can not be restored because another cla group has its name
+var ErrCLAGroupNameConflict = errors.New("another cla group has the name of the archived cla group")
+
+// purgeLFUsername is the user name of the events logged by the purge of archived cla groups
+const purgeLFUsername = "easycla_system_user"
+
+// ArchiveService archives, restores and purges cla groups
+type ArchiveService interface {
+	ArchiveCLAGroup(claGroup *v1Models.Project, lfUsername string) error
+	RestoreCLAGroup(claGroupID string, lfUsername string) (*models.ClaGroupRestoreOutput, error)
+	PurgeArchivedCLAGroups(retention time.Duration) error
+}
+
+type archiveService struct {
+	projectRepo           v1Project.ProjectRepository
+	projectsClaGroupsRepo projects_cla_groups.Repository
+	gerritService         v1Gerrits.Service
+	repositoriesService   v1Repositories.Service
+	signatureService      v1Signatures.SignatureService
+	eventsService         events.Service
+}
+
+// NewArchiveService creates a new cla group archive service
+func NewArchiveService(projectRepo v1Project.ProjectRepository, projectsClaGroupsRepo projects_cla_groups.Repository, gerritService v1Gerrits.Service,
+	repositoriesService v1Repositories.Service, signatureService v1Signatures.SignatureService, eventsService events.Service) ArchiveService {
+	return &archiveService{
+		projectRepo:           projectRepo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		gerritService:         gerritService,
+		repositoriesService:   repositoriesService,
+		signatureService:      signatureService,
+		eventsService:         eventsService,
+	}
+}
+
+// ArchiveCLAGroup archives the cla group and removes its project associations, which disables the CLA service of the
+// projects. The gerrit instances, github repositories and signatures of the cla group are kept until the purge.
+func (s *archiveService) ArchiveCLAGroup(claGroup *v1Models.Project, lfUsername string) error {
+	f := logrus.Fields{"function": "ArchiveCLAGroup", "cla_group_id": claGroup.ProjectID}
+	if claGroup.ProjectArchived {
+		return v1Project.ErrCLAGroupArchived
+	}
+
+	projectCLAGroups, err := s.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the projects of the cla group, error: %+v", err)
+		return err
+	}
+	projectSFIDs := make([]string, 0, len(projectCLAGroups))
+	for _, projectCLAGroup := range projectCLAGroups {
+		projectSFIDs = append(projectSFIDs, projectCLAGroup.ProjectSFID)
+	}
+
+	// the associations are recorded on the cla group before they are removed, so they can always be restored
+	log.WithFields(f).Debugf("archiving cla group with projects: %+v", projectSFIDs)
+	err = s.projectRepo.ArchiveCLAGroup(claGroup.ProjectID, projectSFIDs, lfUsername)
+	if err != nil {
+		return err
+	}
+	if len(projectSFIDs) > 0 {
+		err = s.projectsClaGroupsRepo.RemoveProjectAssociatedWithClaGroup(claGroup.ProjectID, []string{}, true)
+		if err != nil {
+			log.WithFields(f).Warnf("unable to remove the project associations of the archived cla group, error: %+v", err)
+			return err
+		}
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:    events.CLAGroupArchived,
+		ProjectModel: claGroup,
+		LfUsername:   lfUsername,
+		EventData:    &events.CLAGroupArchivedEventData{ProjectSFIDs: projectSFIDs},
+	})
+	return nil
+}
+
+// RestoreCLAGroup restores the archived cla group and the project associations it had when it was archived - a
+// project which was associated with another cla group in the meantime is returned as a warning
+func (s *archiveService) RestoreCLAGroup(claGroupID string, lfUsername string) (*models.ClaGroupRestoreOutput, error) {
+	f := logrus.Fields{"function": "RestoreCLAGroup", "cla_group_id": claGroupID}
+	claGroup, err := s.projectRepo.GetArchivedCLAGroup(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the name lookups skip the archived cla groups, so a cla group with the same name may have been created since
+	sameNameCLAGroup, err := s.projectRepo.GetCLAGroupByName(claGroup.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	if sameNameCLAGroup != nil {
+		log.WithFields(f).Warnf("unable to restore the cla group - cla group: %s has the same name", sameNameCLAGroup.ProjectID)
+		return nil, ErrCLAGroupNameConflict
+	}
+
+	projectSFIDs, err := s.projectRepo.RestoreCLAGroup(claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ClaGroupRestoreOutput{
+		ClaGroupID:       claGroupID,
+		RestoredProjects: []string{},
+		Warnings:         []string{},
+	}
+	for _, projectSFID := range projectSFIDs {
+		associateErr := s.projectsClaGroupsRepo.AssociateClaGroupWithProject(claGroupID, projectSFID, claGroup.FoundationSFID)
+		if associateErr != nil {
+			log.WithFields(f).Warnf("unable to restore the association with project: %s, error: %+v", projectSFID, associateErr)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to associate project %s: %v", projectSFID, associateErr))
+			continue
+		}
+		result.RestoredProjects = append(result.RestoredProjects, projectSFID)
+	}
+
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:    events.CLAGroupRestored,
+		ProjectModel: claGroup,
+		LfUsername:   lfUsername,
+		EventData:    &events.CLAGroupRestoredEventData{ProjectSFIDs: result.RestoredProjects, Warnings: result.Warnings},
+	})
+	return result, nil
+}
+
+// PurgeArchivedCLAGroups deletes the cla groups archived longer than the retention with their gerrit instances and
+// github repositories, and invalidates their signatures. A cla group which fails to delete is tried again on the next run.
+func (s *archiveService) PurgeArchivedCLAGroups(retention time.Duration) error {
+	f := logrus.Fields{"function": "PurgeArchivedCLAGroups", "retention": retention.String()}
+	claGroups, err := s.projectRepo.GetArchivedCLAGroups()
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the archived cla groups, error: %+v", err)
+		return err
+	}
+
+	now, _ := utils.CurrentTime()
+	for _, claGroup := range SelectPurgeableCLAGroups(claGroups, now, retention) {
+		if purgeErr := s.purgeCLAGroup(claGroup); purgeErr != nil {
+			log.WithFields(f).Warnf("unable to purge the archived cla group: %s, error: %+v", claGroup.ProjectID, purgeErr)
+		}
+	}
+	return nil
+}
+
+// SelectPurgeableCLAGroups returns the archived cla groups which were archived longer than the retention - the cla
+// groups with an invalid archive date are skipped
+func SelectPurgeableCLAGroups(claGroups []v1Models.Project, now time.Time, retention time.Duration) []*v1Models.Project {
+	var purgeable []*v1Models.Project
+	for i := range claGroups {
+		claGroup := &claGroups[i]
+		archivedOn, err := utils.ParseDateTime(claGroup.ProjectDateArchived)
+		if err != nil {
+			log.Warnf("unable to parse the archive date: %s of cla group: %s - skipping", claGroup.ProjectDateArchived, claGroup.ProjectID)
+			continue
+		}
+		if now.Before(archivedOn.Add(retention)) {
+			continue
+		}
+		purgeable = append(purgeable, claGroup)
+	}
+	return purgeable
+}
+
+// purgeCLAGroup deletes the cla group with its gerrit instances and github repositories and invalidates its
+// signatures. The cla group is deleted first, so an archived cla group which is restored or fails to delete keeps its
+// gerrit instances, github repositories and signatures. The remaining steps are all run, the first error is returned.
+func (s *archiveService) purgeCLAGroup(claGroup *v1Models.Project) error {
+	f := logrus.Fields{"function": "purgeCLAGroup", "cla_group_id": claGroup.ProjectID}
+	log.WithFields(f).Debug("purging archived cla group")
+
+	// the cla group may have been restored since the archived cla groups were loaded
+	_, err := s.projectRepo.GetArchivedCLAGroup(claGroup.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	err = s.projectRepo.DeleteCLAGroup(claGroup.ProjectID)
+	if err != nil {
+		return err
+	}
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType:    events.CLAGroupDeleted,
+		ProjectModel: claGroup,
+		LfUsername:   purgeLFUsername,
+		EventData:    &events.CLAGroupDeletedEventData{},
+	})
+
+	var purgeErr error
+	err = s.gerritService.DeleteClaGroupGerrits(claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).Errorf("unable to delete the gerrit instances of the purged cla group, error: %+v", err)
+		purgeErr = err
+	} else {
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.GerritRepositoryDeleted,
+			ProjectModel: claGroup,
+			LfUsername:   purgeLFUsername,
+			EventData:    &events.GerritProjectDeletedEventData{},
+		})
+	}
+
+	err = s.repositoriesService.DeleteProject(claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).Errorf("unable to delete the github repositories of the purged cla group, error: %+v", err)
+		if purgeErr == nil {
+			purgeErr = err
+		}
+	} else {
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.GithubRepositoryDeleted,
+			ProjectModel: claGroup,
+			LfUsername:   purgeLFUsername,
+			EventData:    &events.GithubProjectDeletedEventData{},
+		})
+	}
+
+	err = s.signatureService.InvalidateProjectRecords(claGroup.ProjectID, claGroup.ProjectName)
+	if err != nil {
+		log.WithFields(f).Errorf("unable to invalidate the signatures of the purged cla group, error: %+v", err)
+		if purgeErr == nil {
+			purgeErr = err
+		}
+	} else {
+		s.eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.InvalidatedSignature,
+			ProjectModel: claGroup,
+			LfUsername:   purgeLFUsername,
+			EventData:    &events.SignatureProjectInvalidatedEventData{},
+		})
+	}
+	return purgeErr
+}
@@ -0,0 +1,151 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_groups
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// CloneCLAGroup creates a new cla group with the ICLA/CCLA settings and the documents of the source cla group and
+// optionally copies the gerrit instances and github organizations - settings which can not be copied are returned as
+// warnings, they do not fail the clone
+func (s *service) CloneCLAGroup(sourceCLAGroup *v1Models.Project, input *models.CloneClaGroupInput, projectManagerLFID string) (*models.CloneClaGroupOutput, error) {
+	f := logrus.Fields{"function": "CloneCLAGroup", "source_cla_group_id": sourceCLAGroup.ProjectID}
+	if input.ClaGroupName == nil || input.FoundationSfid == nil {
+		return nil, fmt.Errorf("bad request: required parameters are not passed")
+	}
+
+	description := input.ClaGroupDescription
+	if description == "" {
+		description = sourceCLAGroup.ProjectDescription
+	}
+	createInput := &models.CreateClaGroupInput{
+		ClaGroupName:            input.ClaGroupName,
+		ClaGroupDescription:     description,
+		CclaEnabled:             &sourceCLAGroup.ProjectCCLAEnabled,
+		CclaRequiresIcla:        &sourceCLAGroup.ProjectCCLARequiresICLA,
+		IclaEnabled:             &sourceCLAGroup.ProjectICLAEnabled,
+		ContributionPolicy:      sourceCLAGroup.ProjectContributionPolicy,
+		ContributionPolicyYears: sourceCLAGroup.ProjectContributionPolicyYears,
+		FoundationSfid:          input.FoundationSfid,
+		ProjectSfidList:         input.ProjectSfidList,
+	}
+	claGroupModel := &v1Models.Project{
+		FoundationSFID:                 *input.FoundationSfid,
+		ProjectDescription:             description,
+		ProjectCCLAEnabled:             sourceCLAGroup.ProjectCCLAEnabled,
+		ProjectCCLARequiresICLA:        sourceCLAGroup.ProjectCCLARequiresICLA,
+		ProjectExternalID:              *input.FoundationSfid,
+		ProjectACL:                     []string{projectManagerLFID},
+		ProjectICLAEnabled:             sourceCLAGroup.ProjectICLAEnabled,
+		ProjectName:                    *input.ClaGroupName,
+		ProjectResignPolicy:            sourceCLAGroup.ProjectResignPolicy,
+		ProjectResignDeadline:          sourceCLAGroup.ProjectResignDeadline,
+		ProjectContributionPolicy:      sourceCLAGroup.ProjectContributionPolicy,
+		ProjectContributionPolicyYears: sourceCLAGroup.ProjectContributionPolicyYears,
+		Version:                        "v2",
+	}
+
+	log.WithFields(f).Debug("cloning cla group")
+	claGroup, err := s.createCLAGroup(createInput, claGroupModel, func(claGroupID string) (v1Models.TemplatePdfs, error) {
+		log.WithFields(f).Debugf("copying the documents of the source cla group to cla group: %s", claGroupID)
+		copyErr := s.v1TemplateService.CopyCLAGroupTemplate(context.Background(), sourceCLAGroup.ProjectID, claGroupID)
+		if copyErr != nil {
+			return v1Models.TemplatePdfs{}, copyErr
+		}
+		clonedCLAGroup, getErr := s.v1ProjectService.GetCLAGroupByID(claGroupID)
+		if getErr != nil {
+			return v1Models.TemplatePdfs{}, getErr
+		}
+		return v1Models.TemplatePdfs{
+			CorporatePDFURL:  getS3Url(claGroupID, clonedCLAGroup.ProjectCorporateDocuments),
+			IndividualPDFURL: getS3Url(claGroupID, clonedCLAGroup.ProjectIndividualDocuments),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.CloneClaGroupOutput{
+		ClaGroup:                  claGroup,
+		SourceClaGroupID:          sourceCLAGroup.ProjectID,
+		CopiedGerritInstances:     []string{},
+		CopiedGithubOrganizations: []string{},
+		Warnings:                  []string{},
+	}
+	if input.CopyGerritInstances {
+		s.cloneGerritInstances(sourceCLAGroup, claGroup, result)
+	}
+	if input.CopyGithubOrganizations {
+		s.cloneGithubOrganizations(sourceCLAGroup, claGroup, result)
+	}
+	return result, nil
+}
+
+// cloneGerritInstances adds the gerrit instances of the source cla group to the cloned cla group
+func (s *service) cloneGerritInstances(sourceCLAGroup *v1Models.Project, claGroup *models.ClaGroup, result *models.CloneClaGroupOutput) {
+	f := logrus.Fields{"function": "cloneGerritInstances", "source_cla_group_id": sourceCLAGroup.ProjectID, "cla_group_id": claGroup.ClaGroupID}
+	gerrits, err := s.v1GerritService.GetClaGroupGerrits(sourceCLAGroup.ProjectID, nil)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the gerrit instances of the source cla group, error: %+v", err)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("unable to load the gerrit instances of the source cla group: %v", err))
+		return
+	}
+
+	// the gerrit instances are attached to the first project of the cloned cla group, the foundation for a standalone project
+	projectSFID := claGroup.FoundationSfid
+	if len(claGroup.ProjectList) > 0 {
+		projectSFID = claGroup.ProjectList[0].ProjectSfid
+	}
+	for _, gerrit := range gerrits.List {
+		_, addErr := s.v1GerritService.AddGerrit(claGroup.ClaGroupID, projectSFID, &v1Models.AddGerritInput{
+			GerritName:  aws.String(gerrit.GerritName),
+			GerritURL:   aws.String(gerrit.GerritURL),
+			GroupIDCcla: gerrit.GroupIDCcla,
+			GroupIDIcla: gerrit.GroupIDIcla,
+		})
+		if addErr != nil {
+			log.WithFields(f).Warnf("unable to copy gerrit instance: %s, error: %+v", gerrit.GerritName, addErr)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to copy gerrit instance %s: %v", gerrit.GerritName, addErr))
+			continue
+		}
+		result.CopiedGerritInstances = append(result.CopiedGerritInstances, gerrit.GerritName)
+	}
+}
+
+// cloneGithubOrganizations adds the github organizations of the source cla group foundation to the foundation of the
+// cloned cla group - github organizations belong to a foundation, so there is nothing to copy within the same foundation
+func (s *service) cloneGithubOrganizations(sourceCLAGroup *v1Models.Project, claGroup *models.ClaGroup, result *models.CloneClaGroupOutput) {
+	f := logrus.Fields{"function": "cloneGithubOrganizations", "source_cla_group_id": sourceCLAGroup.ProjectID, "cla_group_id": claGroup.ClaGroupID}
+	if sourceCLAGroup.FoundationSFID == claGroup.FoundationSfid {
+		log.WithFields(f).Debugf("the github organizations of foundation: %s are shared by its cla groups - nothing to copy", claGroup.FoundationSfid)
+		return
+	}
+
+	orgs, err := s.v1GithubOrganizationsService.GetGithubOrganizations(sourceCLAGroup.FoundationSFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the github organizations of the source cla group, error: %+v", err)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("unable to load the github organizations of the source cla group: %v", err))
+		return
+	}
+	for _, org := range orgs.List {
+		_, addErr := s.v1GithubOrganizationsService.AddGithubOrganization(claGroup.FoundationSfid, &v1Models.CreateGithubOrganization{
+			OrganizationName: org.OrganizationName,
+		})
+		if addErr != nil {
+			// a github organization can only belong to one foundation
+			log.WithFields(f).Warnf("unable to copy github organization: %s, error: %+v", org.OrganizationName, addErr)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("unable to copy github organization %s: %v", org.OrganizationName, addErr))
+			continue
+		}
+		result.CopiedGithubOrganizations = append(result.CopiedGithubOrganizations, org.OrganizationName)
+	}
+}
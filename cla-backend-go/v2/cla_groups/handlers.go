@@ -9,11 +9,6 @@ import (
 
 	"github.com/communitybridge/easycla/cla-backend-go/template"
 
-	v1Repositories "github.com/communitybridge/easycla/cla-backend-go/repositories"
-	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
-
-	v1Gerrits "github.com/communitybridge/easycla/cla-backend-go/gerrits"
-
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/foundation"
 
 	"github.com/communitybridge/easycla/cla-backend-go/events"
@@ -29,7 +24,7 @@ import (
 )
 
 // Configure configures the cla group api
-func Configure(api *operations.EasyclaAPI, service Service, v1ProjectService v1Project.Service, eventsService events.Service, v1GerritService v1Gerrits.Service, v1RepositoryService v1Repositories.Service, v1SignatureService v1Signatures.SignatureService) {
+func Configure(api *operations.EasyclaAPI, service Service, archiveService ArchiveService, v1ProjectService v1Project.Service, eventsService events.Service) {
 
 	api.ClaGroupCreateClaGroupHandler = cla_group.CreateClaGroupHandlerFunc(func(params cla_group.CreateClaGroupParams, authUser *auth.User) middleware.Responder {
 		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
@@ -65,96 +60,142 @@ func Configure(api *operations.EasyclaAPI, service Service, v1ProjectService v1P
 		return cla_group.NewCreateClaGroupOK().WithPayload(claGroup)
 	})
 
-	api.ClaGroupDeleteClaGroupHandler = cla_group.DeleteClaGroupHandlerFunc(func(params cla_group.DeleteClaGroupParams, authUser *auth.User) middleware.Responder {
+	api.ClaGroupCloneClaGroupHandler = cla_group.CloneClaGroupHandlerFunc(func(params cla_group.CloneClaGroupParams, authUser *auth.User) middleware.Responder {
 		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
-		cg, err := v1ProjectService.GetCLAGroupByID(params.ClaGroupID)
+		sourceCLAGroup, err := v1ProjectService.GetCLAGroupByID(params.ClaGroupID)
 		if err != nil {
 			if err == v1Project.ErrProjectDoesNotExist {
-				return cla_group.NewDeleteClaGroupNotFound().WithPayload(&models.ErrorResponse{
+				return cla_group.NewCloneClaGroupNotFound().WithPayload(&models.ErrorResponse{
 					Code: "404",
 					Message: fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found",
 						params.ClaGroupID),
 				})
 			}
-			return cla_group.NewDeleteClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
+			return cla_group.NewCloneClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
 				Code: "500",
 				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID, error = %+v",
 					err),
 			})
 		}
-		if !utils.IsUserAuthorizedForProject(authUser, cg.FoundationSFID) {
-			return cla_group.NewDeleteClaGroupForbidden().WithPayload(&models.ErrorResponse{
-				Code: "403",
-				Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to DeleteCLAGroup with Project scope of %s",
-					authUser.UserName, cg.FoundationSFID),
+		if params.CloneInput == nil || params.CloneInput.FoundationSfid == nil {
+			return cla_group.NewCloneClaGroupBadRequest().WithPayload(&models.ErrorResponse{
+				Code:    "400",
+				Message: "EasyCLA - 400 Bad Request - required parameters are not passed",
 			})
 		}
+		// The user needs access to the source cla group and to the foundation of the new cla group
+		for _, projectSFID := range []string{sourceCLAGroup.FoundationSFID, *params.CloneInput.FoundationSfid} {
+			if !utils.IsUserAuthorizedForProject(authUser, projectSFID) {
+				return cla_group.NewCloneClaGroupForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to CloneCLAGroup with Project scope of %s",
+						authUser.UserName, projectSFID),
+				})
+			}
+		}
 
-		// Delete gerrit repositories
-		log.Debugf(" Processing gerrit delete with cla-group id: %s", params.ClaGroupID)
-		err = v1GerritService.DeleteClaGroupGerrits(params.ClaGroupID)
+		result, err := service.CloneCLAGroup(sourceCLAGroup, params.CloneInput, utils.StringValue(params.XUSERNAME))
 		if err != nil {
-			return cla_group.NewDeleteClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
+			if strings.Contains(err.Error(), "bad request") {
+				return cla_group.NewCloneClaGroupBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: fmt.Sprintf("EasyCLA - 400 Bad Request - %s", err.Error()),
+				})
+			}
+			return cla_group.NewCloneClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
 				Code:    "500",
 				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
 			})
 		}
-		// Log gerrit event
+
 		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:    events.GerritRepositoryDeleted,
-			ProjectModel: cg,
-			LfUsername:   authUser.UserName,
-			EventData:    &events.GerritProjectDeletedEventData{},
+			EventType:  events.CLAGroupCreated,
+			ProjectID:  result.ClaGroup.ClaGroupID,
+			LfUsername: authUser.UserName,
+			EventData: &events.CLAGroupCreatedEventData{
+				SourceCLAGroupID:   sourceCLAGroup.ProjectID,
+				SourceCLAGroupName: sourceCLAGroup.ProjectName,
+			},
 		})
 
-		// Delete github repositories
-		err = v1RepositoryService.DeleteProject(params.ClaGroupID)
+		return cla_group.NewCloneClaGroupOK().WithPayload(result)
+	})
+
+	api.ClaGroupDeleteClaGroupHandler = cla_group.DeleteClaGroupHandlerFunc(func(params cla_group.DeleteClaGroupParams, authUser *auth.User) middleware.Responder {
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		cg, err := v1ProjectService.GetCLAGroupByID(params.ClaGroupID)
 		if err != nil {
+			if err == v1Project.ErrProjectDoesNotExist {
+				return cla_group.NewDeleteClaGroupNotFound().WithPayload(&models.ErrorResponse{
+					Code: "404",
+					Message: fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found",
+						params.ClaGroupID),
+				})
+			}
 			return cla_group.NewDeleteClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
-				Code:    "500",
-				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
+				Code: "500",
+				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID, error = %+v",
+					err),
+			})
+		}
+		if !utils.IsUserAuthorizedForProject(authUser, cg.FoundationSFID) {
+			return cla_group.NewDeleteClaGroupForbidden().WithPayload(&models.ErrorResponse{
+				Code: "403",
+				Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to DeleteCLAGroup with Project scope of %s",
+					authUser.UserName, cg.FoundationSFID),
 			})
 		}
 
-		// Log github delete event
-		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:    events.GithubRepositoryDeleted,
-			ProjectModel: cg,
-			LfUsername:   authUser.UserName,
-			EventData:    &events.GithubProjectDeletedEventData{},
-		})
-
-		// Invalidate project signatures
-		err = v1SignatureService.InvalidateProjectRecords(params.ClaGroupID, cg.ProjectName)
+		// the cla group is archived - it is deleted by the purge after the retention window unless an admin restores it
+		log.Debugf("archiving cla-group id: %s", params.ClaGroupID)
+		err = archiveService.ArchiveCLAGroup(cg, authUser.UserName)
 		if err != nil {
 			return cla_group.NewDeleteClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
 				Code:    "500",
 				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
 			})
 		}
-		// Log invalidate signatures
-		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:    events.InvalidatedSignature,
-			ProjectModel: cg,
-			LfUsername:   authUser.UserName,
-			EventData:    &events.SignatureProjectInvalidatedEventData{},
-		})
-		err = service.DeleteCLAGroup(params.ClaGroupID)
+
+		return cla_group.NewDeleteClaGroupNoContent()
+	})
+
+	api.ClaGroupRestoreClaGroupHandler = cla_group.RestoreClaGroupHandlerFunc(func(params cla_group.RestoreClaGroupParams, authUser *auth.User) middleware.Responder {
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		if !utils.IsUserAdmin(authUser) {
+			return cla_group.NewRestoreClaGroupForbidden().WithPayload(&models.ErrorResponse{
+				Code: "403",
+				Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to RestoreCLAGroup - only Admins allowed to restore CLA Groups.",
+					authUser.UserName),
+			})
+		}
+
+		result, err := archiveService.RestoreCLAGroup(params.ClaGroupID, authUser.UserName)
 		if err != nil {
-			return cla_group.NewDeleteClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
+			log.Warnf("unable to restore cla-group id: %s, error: %+v", params.ClaGroupID, err)
+			switch err {
+			case v1Project.ErrProjectDoesNotExist:
+				return cla_group.NewRestoreClaGroupNotFound().WithPayload(&models.ErrorResponse{
+					Code: "404",
+					Message: fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found",
+						params.ClaGroupID),
+				})
+			case v1Project.ErrCLAGroupNotArchived:
+				return cla_group.NewRestoreClaGroupBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: fmt.Sprintf("EasyCLA - 400 Bad Request - cla_group %s is not archived", params.ClaGroupID),
+				})
+			case ErrCLAGroupNameConflict:
+				return cla_group.NewRestoreClaGroupBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: fmt.Sprintf("EasyCLA - 400 Bad Request - cla_group %s can not be restored, error = %s", params.ClaGroupID, err.Error()),
+				})
+			}
+			return cla_group.NewRestoreClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
 				Code:    "500",
 				Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
 			})
 		}
-
-		eventsService.LogEvent(&events.LogEventArgs{
-			EventType:    events.CLAGroupDeleted,
-			ProjectModel: cg,
-			LfUsername:   authUser.UserName,
-			EventData:    &events.CLAGroupDeletedEventData{},
-		})
-
-		return cla_group.NewDeleteClaGroupNoContent()
+		return cla_group.NewRestoreClaGroupOK().WithPayload(result)
 	})
 
 	api.ClaGroupEnrollProjectsHandler = cla_group.EnrollProjectsHandlerFunc(func(params cla_group.EnrollProjectsParams, authUser *auth.User) middleware.Responder {
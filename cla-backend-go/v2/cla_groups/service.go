@@ -20,9 +20,12 @@ import (
 
 	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	v1Gerrits "github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	v1GithubOrganizations "github.com/communitybridge/easycla/cla-backend-go/github_organizations"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
 	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
 	v1Template "github.com/communitybridge/easycla/cla-backend-go/template"
 	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
 	psproject "github.com/communitybridge/easycla/cla-backend-go/v2/project-service/client/project"
@@ -36,16 +39,20 @@ const (
 )
 
 type service struct {
-	v1ProjectService      v1Project.Service
-	v1TemplateService     v1Template.Service
-	projectsClaGroupsRepo projects_cla_groups.Repository
-	metricsRepo           metrics.Repository
+	v1ProjectService             v1Project.Service
+	v1TemplateService            v1Template.Service
+	projectsClaGroupsRepo        projects_cla_groups.Repository
+	metricsRepo                  metrics.Repository
+	v1GerritService              v1Gerrits.Service
+	v1GithubOrganizationsService v1GithubOrganizations.Service
 }
 
 // Service interface
 type Service interface {
 	CreateCLAGroup(input *models.CreateClaGroupInput, projectManagerLFID string) (*models.ClaGroup, error)
+	CloneCLAGroup(sourceCLAGroup *v1Models.Project, input *models.CloneClaGroupInput, projectManagerLFID string) (*models.CloneClaGroupOutput, error)
 	EnrollProjectsInClaGroup(claGroupID string, foundationSFID string, projectSFIDList []string) error
+	UnenrollProjectsInClaGroup(claGroupID string, projectSFIDList []string) error
 	DeleteCLAGroup(claGroupID string) error
 	ListClaGroupsForFoundationOrProject(foundationSFID string) (*models.ClaGroupList, error)
 	ValidateCLAGroup(input *models.ClaGroupValidationRequest) (bool, []string)
@@ -53,12 +60,14 @@ type Service interface {
 }
 
 // NewService returns instance of CLA group service
-func NewService(projectService v1Project.Service, templateService v1Template.Service, projectsClaGroupsRepo projects_cla_groups.Repository, metricsRepo metrics.Repository) Service {
+func NewService(projectService v1Project.Service, templateService v1Template.Service, projectsClaGroupsRepo projects_cla_groups.Repository, metricsRepo metrics.Repository, gerritService v1Gerrits.Service, githubOrganizationsService v1GithubOrganizations.Service) Service {
 	return &service{
-		v1ProjectService:      projectService, // aka cla_group service of v1
-		v1TemplateService:     templateService,
-		projectsClaGroupsRepo: projectsClaGroupsRepo,
-		metricsRepo:           metricsRepo,
+		v1ProjectService:             projectService, // aka cla_group service of v1
+		v1TemplateService:            templateService,
+		projectsClaGroupsRepo:        projectsClaGroupsRepo,
+		metricsRepo:                  metricsRepo,
+		v1GerritService:              gerritService,
+		v1GithubOrganizationsService: githubOrganizationsService,
 	}
 }
 
@@ -87,6 +96,24 @@ func (s *service) ValidateCLAGroup(input *models.ClaGroupValidationRequest) (boo
 
 	// Note: CLA Group Description Min/Max Character Length validated via Swagger Spec restrictions
 
+	// The contribution policy is validated against the ICLA/CCLA flags of the request - a flag which is not
+	// provided is considered enabled
+	if input.ContributionPolicy != nil || input.ContributionPolicyYears != nil {
+		iclaEnabled, cclaEnabled := true, true
+		if input.IclaEnabled != nil {
+			iclaEnabled = *input.IclaEnabled
+		}
+		if input.CclaEnabled != nil {
+			cclaEnabled = *input.CclaEnabled
+		}
+		err := v1Signatures.ValidateContributionPolicy(utils.StringValue(input.ContributionPolicy),
+			utils.Int64Value(input.ContributionPolicyYears), iclaEnabled, cclaEnabled)
+		if err != nil {
+			valid = false
+			validationErrors = append(validationErrors, err.Error())
+		}
+	}
+
 	// Optional - we can expand this API logic to validate other fields if needed.
 
 	return valid, validationErrors
@@ -107,6 +134,10 @@ func (s *service) validateClaGroupInput(input *models.CreateClaGroupInput) (bool
 			return false, fmt.Errorf("bad request: ccla_requires_icla can not be enabled if one of icla/ccla is disabled")
 		}
 	}
+	err := v1Signatures.ValidateContributionPolicy(input.ContributionPolicy, input.ContributionPolicyYears, *input.IclaEnabled, *input.CclaEnabled)
+	if err != nil {
+		return false, fmt.Errorf("bad request: %s", err)
+	}
 	claGroupModel, err := s.v1ProjectService.GetCLAGroupByName(*input.ClaGroupName)
 	if err != nil {
 		return false, err
@@ -215,8 +246,6 @@ func (s *service) enrollProjects(claGroupID string, foundationSFID string, proje
 
 func (s *service) CreateCLAGroup(input *models.CreateClaGroupInput, projectManagerLFID string) (*models.ClaGroup, error) {
 	f := logrus.Fields{"function": "CreateCLAGroup"}
-	// Validate the input
-	log.WithFields(f).WithField("input", input).Debugf("validating create cla group input")
 	if input.IclaEnabled == nil ||
 		input.CclaEnabled == nil ||
 		input.CclaRequiresIcla == nil ||
@@ -224,6 +253,44 @@ func (s *service) CreateCLAGroup(input *models.CreateClaGroupInput, projectManag
 		input.FoundationSfid == nil {
 		return nil, fmt.Errorf("bad request: required parameters are not passed")
 	}
+
+	claGroupModel := &v1Models.Project{
+		FoundationSFID:                 *input.FoundationSfid,
+		ProjectDescription:             input.ClaGroupDescription,
+		ProjectCCLAEnabled:             *input.CclaEnabled,
+		ProjectCCLARequiresICLA:        *input.CclaRequiresIcla,
+		ProjectExternalID:              *input.FoundationSfid,
+		ProjectACL:                     []string{projectManagerLFID},
+		ProjectICLAEnabled:             *input.IclaEnabled,
+		ProjectName:                    *input.ClaGroupName,
+		ProjectContributionPolicy:      input.ContributionPolicy,
+		ProjectContributionPolicyYears: input.ContributionPolicyYears,
+		Version:                        "v2",
+	}
+
+	// Attach template with cla group
+	return s.createCLAGroup(input, claGroupModel, func(claGroupID string) (v1Models.TemplatePdfs, error) {
+		var templateFields v1Models.CreateClaGroupTemplate
+		err := copier.Copy(&templateFields, &input.TemplateFields)
+		if err != nil {
+			log.WithFields(f).Error("unable to create v1 create cla group template model", err)
+			return v1Models.TemplatePdfs{}, err
+		}
+		log.WithFields(f).Debug("attaching cla_group_template")
+		if templateFields.TemplateID == "" {
+			log.WithFields(f).Debug("using apache style template as template_id is not passed")
+			templateFields.TemplateID = v1Template.ApacheStyleTemplateID
+		}
+		return s.v1TemplateService.CreateCLAGroupTemplate(context.Background(), claGroupID, &templateFields)
+	})
+}
+
+// createCLAGroup validates the input, creates the cla group, attaches the documents and enrolls the projects - the
+// cla group is deleted again when attaching the documents or enrolling the projects fails
+func (s *service) createCLAGroup(input *models.CreateClaGroupInput, claGroupModel *v1Models.Project, attachDocuments func(claGroupID string) (v1Models.TemplatePdfs, error)) (*models.ClaGroup, error) {
+	f := logrus.Fields{"function": "createCLAGroup"}
+	// Validate the input
+	log.WithFields(f).WithField("input", input).Debugf("validating create cla group input")
 	standaloneProject, err := s.validateClaGroupInput(input)
 	if err != nil {
 		log.WithFields(f).Warnf("validation of create cla group input failed")
@@ -232,17 +299,7 @@ func (s *service) CreateCLAGroup(input *models.CreateClaGroupInput, projectManag
 
 	// Create cla group
 	log.WithFields(f).WithField("input", input).Debugf("creating cla group")
-	claGroup, err := s.v1ProjectService.CreateCLAGroup(&v1Models.Project{
-		FoundationSFID:          *input.FoundationSfid,
-		ProjectDescription:      input.ClaGroupDescription,
-		ProjectCCLAEnabled:      *input.CclaEnabled,
-		ProjectCCLARequiresICLA: *input.CclaRequiresIcla,
-		ProjectExternalID:       *input.FoundationSfid,
-		ProjectACL:              []string{projectManagerLFID},
-		ProjectICLAEnabled:      *input.IclaEnabled,
-		ProjectName:             *input.ClaGroupName,
-		Version:                 "v2",
-	})
+	claGroup, err := s.v1ProjectService.CreateCLAGroup(claGroupModel)
 	if err != nil {
 		log.WithFields(f).Errorf("creating cla group failed. error = %s", err.Error())
 		return nil, err
@@ -250,21 +307,9 @@ func (s *service) CreateCLAGroup(input *models.CreateClaGroupInput, projectManag
 	log.WithFields(f).WithField("cla_group", claGroup).Debugf("cla group created")
 	f["cla_group_id"] = claGroup.ProjectID
 
-	// Attach template with cla group
-	var templateFields v1Models.CreateClaGroupTemplate
-	err = copier.Copy(&templateFields, &input.TemplateFields)
+	pdfUrls, err := attachDocuments(claGroup.ProjectID)
 	if err != nil {
-		log.WithFields(f).Error("unable to create v1 create cla group template model", err)
-		return nil, err
-	}
-	log.WithFields(f).Debug("attaching cla_group_template")
-	if templateFields.TemplateID == "" {
-		log.WithFields(f).Debug("using apache style template as template_id is not passed")
-		templateFields.TemplateID = v1Template.ApacheStyleTemplateID
-	}
-	pdfUrls, err := s.v1TemplateService.CreateCLAGroupTemplate(context.Background(), claGroup.ProjectID, &templateFields)
-	if err != nil {
-		log.WithFields(f).Error("attaching cla_group_template failed", err)
+		log.WithFields(f).Error("attaching cla_group documents failed", err)
 		log.WithFields(f).Debug("deleting created cla group")
 		deleteErr := s.v1ProjectService.DeleteCLAGroup(claGroup.ProjectID)
 		if deleteErr != nil {
@@ -272,7 +317,7 @@ func (s *service) CreateCLAGroup(input *models.CreateClaGroupInput, projectManag
 		}
 		return nil, err
 	}
-	log.WithFields(f).Debug("cla_group_template attached", pdfUrls)
+	log.WithFields(f).Debug("cla_group documents attached", pdfUrls)
 
 	// Associate projects with cla group
 
@@ -311,17 +356,19 @@ func (s *service) CreateCLAGroup(input *models.CreateClaGroupInput, projectManag
 	}
 
 	return &models.ClaGroup{
-		CclaEnabled:         claGroup.ProjectCCLAEnabled,
-		CclaPdfURL:          pdfUrls.CorporatePDFURL,
-		CclaRequiresIcla:    claGroup.ProjectCCLARequiresICLA,
-		ClaGroupDescription: claGroup.ProjectDescription,
-		ClaGroupID:          claGroup.ProjectID,
-		ClaGroupName:        claGroup.ProjectName,
-		FoundationSfid:      claGroup.FoundationSFID,
-		FoundationName:      foundationName,
-		IclaEnabled:         claGroup.ProjectICLAEnabled,
-		IclaPdfURL:          pdfUrls.IndividualPDFURL,
-		ProjectList:         projectList,
+		CclaEnabled:             claGroup.ProjectCCLAEnabled,
+		CclaPdfURL:              pdfUrls.CorporatePDFURL,
+		CclaRequiresIcla:        claGroup.ProjectCCLARequiresICLA,
+		ClaGroupDescription:     claGroup.ProjectDescription,
+		ClaGroupID:              claGroup.ProjectID,
+		ClaGroupName:            claGroup.ProjectName,
+		FoundationSfid:          claGroup.FoundationSFID,
+		FoundationName:          foundationName,
+		IclaEnabled:             claGroup.ProjectICLAEnabled,
+		ContributionPolicy:      v1Signatures.GetContributionPolicy(claGroup),
+		ContributionPolicyYears: claGroup.ProjectContributionPolicyYears,
+		IclaPdfURL:              pdfUrls.IndividualPDFURL,
+		ProjectList:             projectList,
 	}, nil
 }
 
@@ -344,6 +391,33 @@ func (s *service) EnrollProjectsInClaGroup(claGroupID string, foundationSFID str
 	return nil
 }
 
+// UnenrollProjectsInClaGroup removes the association of the projects with the cla group, which disables the CLA
+// service of the projects
+func (s *service) UnenrollProjectsInClaGroup(claGroupID string, projectSFIDList []string) error {
+	f := logrus.Fields{"cla_group_id": claGroupID, "project_sfid_list": projectSFIDList}
+	log.WithFields(f).Debug("validating unenroll project input")
+	enrolledProjects, err := s.projectsClaGroupsRepo.GetProjectsIdsForClaGroup(claGroupID)
+	if err != nil {
+		return err
+	}
+	enrolledProjectList := utils.NewStringSet()
+	for _, pr := range enrolledProjects {
+		enrolledProjectList.Add(pr.ProjectSFID)
+	}
+	for _, projectSFID := range projectSFIDList {
+		if !enrolledProjectList.Include(projectSFID) {
+			return fmt.Errorf("bad request: invalid project_sfid: %s. This project is not enrolled in the cla_group", projectSFID)
+		}
+	}
+	log.WithFields(f).Debug("unenrolling projects from cla_group")
+	err = s.projectsClaGroupsRepo.RemoveProjectAssociatedWithClaGroup(claGroupID, projectSFIDList, false)
+	if err != nil {
+		log.WithFields(f).Errorf("unenrolling projects from cla_group failed. error = %s", err)
+		return err
+	}
+	return nil
+}
+
 func (s *service) DeleteCLAGroup(claGroupID string) error {
 	f := logrus.Fields{"cla_group_id": claGroupID}
 	log.WithFields(f).Debug("deleting cla_group")
@@ -409,17 +483,19 @@ func (s *service) ListClaGroupsForFoundationOrProject(foundationSFID string) (*m
 		}
 
 		cg := &models.ClaGroup{
-			CclaEnabled:         v1ClaGroup.ProjectCCLAEnabled,
-			CclaRequiresIcla:    v1ClaGroup.ProjectCCLARequiresICLA,
-			ClaGroupDescription: v1ClaGroup.ProjectDescription,
-			ClaGroupID:          v1ClaGroup.ProjectID,
-			ClaGroupName:        v1ClaGroup.ProjectName,
-			FoundationSfid:      v1ClaGroup.FoundationSFID,
-			FoundationName:      foundationName,
-			IclaEnabled:         v1ClaGroup.ProjectICLAEnabled,
-			CclaPdfURL:          getS3Url(v1ClaGroup.ProjectID, v1ClaGroup.ProjectCorporateDocuments),
-			IclaPdfURL:          getS3Url(v1ClaGroup.ProjectID, v1ClaGroup.ProjectIndividualDocuments),
-			ProjectList:         make([]*models.ClaGroupProject, 0),
+			CclaEnabled:             v1ClaGroup.ProjectCCLAEnabled,
+			CclaRequiresIcla:        v1ClaGroup.ProjectCCLARequiresICLA,
+			ClaGroupDescription:     v1ClaGroup.ProjectDescription,
+			ClaGroupID:              v1ClaGroup.ProjectID,
+			ClaGroupName:            v1ClaGroup.ProjectName,
+			FoundationSfid:          v1ClaGroup.FoundationSFID,
+			FoundationName:          foundationName,
+			IclaEnabled:             v1ClaGroup.ProjectICLAEnabled,
+			ContributionPolicy:      v1Signatures.GetContributionPolicy(v1ClaGroup),
+			ContributionPolicyYears: v1ClaGroup.ProjectContributionPolicyYears,
+			CclaPdfURL:              getS3Url(v1ClaGroup.ProjectID, v1ClaGroup.ProjectCorporateDocuments),
+			IclaPdfURL:              getS3Url(v1ClaGroup.ProjectID, v1ClaGroup.ProjectIndividualDocuments),
+			ProjectList:             make([]*models.ClaGroupProject, 0),
 			// Add root_project_repositories_count to repositories_count initially
 			RepositoriesCount:            v1ClaGroup.RootProjectRepositoriesCount,
 			RootProjectRepositoriesCount: v1ClaGroup.RootProjectRepositoriesCount,
@@ -0,0 +1,251 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	v2AcsService "github.com/communitybridge/easycla/cla-backend-go/v2/acs-service"
+	v2OrgService "github.com/communitybridge/easycla/cla-backend-go/v2/organization-service"
+)
+
+// default ages of the cla-manager-designee role scopes at which they expire and the designee is reminded
+const (
+	RoleCLAManagerDesignee = "cla-manager-designee"
+
+	DefaultDesigneeTTL            = 30 * 24 * time.Hour
+	DefaultDesigneeReminderBefore = 7 * 24 * time.Hour
+)
+
+// DesigneeExpiryService removes the cla-manager-designee role scopes which were not used to sign a CCLA
+type DesigneeExpiryService interface {
+	ProcessDesigneeGrants(ttl, reminderBefore time.Duration) error
+	BackfillDesigneeGrants(organizationIDs []string, dryRun bool) ([]*DesigneeGrant, error)
+}
+
+type designeeExpiryService struct {
+	designeeRepo DesigneeRepository
+	eventService events.Service
+}
+
+// NewDesigneeExpiryService creates a new designee expiry service
+func NewDesigneeExpiryService(designeeRepo DesigneeRepository, eventService events.Service) DesigneeExpiryService {
+	return &designeeExpiryService{
+		designeeRepo: designeeRepo,
+		eventService: eventService,
+	}
+}
+
+// ProcessDesigneeGrants removes the designee role scopes granted more than ttl ago and reminds the designees whose role
+// scope expires within reminderBefore. Grants whose role scope no longer exists, for example because the CCLA was
+// signed, are forgotten.
+func (s *designeeExpiryService) ProcessDesigneeGrants(ttl, reminderBefore time.Duration) error {
+	grants, err := s.designeeRepo.GetDesigneeGrants()
+	if err != nil {
+		log.Warnf("ProcessDesigneeGrants - unable to load the designee grants, error: %+v", err)
+		return err
+	}
+
+	expired, reminders := SelectDesigneeGrants(grants, time.Now().UTC(), ttl, reminderBefore)
+	for _, grant := range reminders {
+		scopeID, ok := s.getDesigneeScopeID(grant)
+		if !ok || scopeID == "" {
+			continue
+		}
+		granted, _ := utils.ParseDateTime(grant.DateCreated)
+		s.sendDesigneeExpiryReminder(grant, granted.Add(ttl))
+	}
+
+	var roleID string
+	for _, grant := range expired {
+		scopeID, ok := s.getDesigneeScopeID(grant)
+		if !ok || scopeID == "" {
+			continue
+		}
+		if roleID == "" {
+			roleID, err = v2AcsService.GetClient().GetRoleID(RoleCLAManagerDesignee)
+			if err != nil {
+				log.Warnf("ProcessDesigneeGrants - unable to lookup the role ID of %s, error: %+v", RoleCLAManagerDesignee, err)
+				return err
+			}
+		}
+		s.expireDesigneeGrant(grant, roleID, scopeID, ttl)
+	}
+	return nil
+}
+
+// SelectDesigneeGrants returns the grants older than ttl, which expire, and the grants which expire within
+// reminderBefore and were not reminded yet. Grants with an invalid creation date are skipped.
+func SelectDesigneeGrants(grants []*DesigneeGrant, now time.Time, ttl, reminderBefore time.Duration) ([]*DesigneeGrant, []*DesigneeGrant) {
+	var expired, reminders []*DesigneeGrant
+	for _, grant := range grants {
+		granted, parseErr := utils.ParseDateTime(grant.DateCreated)
+		if parseErr != nil {
+			log.Warnf("SelectDesigneeGrants - unable to parse the creation date: %s of grant: %s - skipping",
+				grant.DateCreated, grant.DesigneeID)
+			continue
+		}
+		age := now.Sub(granted)
+		switch {
+		case age >= ttl:
+			expired = append(expired, grant)
+		case reminderBefore > 0 && age >= ttl-reminderBefore && grant.DateReminderSent == "":
+			reminders = append(reminders, grant)
+		}
+	}
+	return expired, reminders
+}
+
+// getDesigneeScopeID returns the ID of the role scope of the grant, an empty ID when the role scope no longer exists in
+// which case the grant is removed, and false when the role scope can not be looked up
+func (s *designeeExpiryService) getDesigneeScopeID(grant *DesigneeGrant) (string, bool) {
+	scopeID, scopeErr := v2OrgService.GetClient().GetScopeID(grant.CompanySFID, grant.ProjectSFID, RoleCLAManagerDesignee, "project|organization", grant.LfUsername)
+	if scopeErr != nil {
+		log.Warnf("getDesigneeScopeID - unable to lookup the role scope of grant: %s, error: %+v - skipping", grant.DesigneeID, scopeErr)
+		return "", false
+	}
+	if scopeID == "" {
+		log.Debugf("getDesigneeScopeID - role scope of grant: %s no longer exists - removing the grant", grant.DesigneeID)
+		_ = s.designeeRepo.DeleteDesigneeGrant(grant.DesigneeID)
+	}
+	return scopeID, true
+}
+
+// BackfillDesigneeGrants records a grant for each cla-manager-designee role scope of the organizations which has no
+// grant, such as the role scopes assigned before the grants were recorded. The assignment date of these role scopes is
+// unknown, so their TTL starts with the backfill. Returns the grants which are, or with dryRun would be, recorded.
+func (s *designeeExpiryService) BackfillDesigneeGrants(organizationIDs []string, dryRun bool) ([]*DesigneeGrant, error) {
+	grants, err := s.designeeRepo.GetDesigneeGrants()
+	if err != nil {
+		log.Warnf("BackfillDesigneeGrants - unable to load the designee grants, error: %+v", err)
+		return nil, err
+	}
+	recorded := utils.NewStringSet()
+	for _, grant := range grants {
+		recorded.Add(grant.DesigneeID)
+	}
+
+	var backfilled []*DesigneeGrant
+	for _, organizationID := range organizationIDs {
+		result, listErr := v2OrgService.GetClient().ListOrgUserScopes(organizationID, []string{RoleCLAManagerDesignee})
+		if listErr != nil {
+			log.Warnf("BackfillDesigneeGrants - unable to load the role scopes of organization: %s, error: %+v - skipping", organizationID, listErr)
+			continue
+		}
+
+		for _, userRole := range result.Userroles {
+			if userRole.Contact.Username == "" {
+				continue
+			}
+			for _, roleScope := range userRole.RoleScopes {
+				if roleScope.RoleName != RoleCLAManagerDesignee {
+					continue
+				}
+				for _, scope := range roleScope.Scopes {
+					objectList := strings.Split(scope.ObjectID, "|")
+					if len(objectList) != 2 {
+						continue
+					}
+					grant := &DesigneeGrant{
+						LfUsername:  userRole.Contact.Username,
+						UserEmail:   userRole.Contact.EmailAddress,
+						UserSFID:    userRole.Contact.ID,
+						ProjectSFID: objectList[0],
+						CompanySFID: organizationID,
+					}
+					grantID := designeeGrantID(grant.ProjectSFID, grant.CompanySFID, grant.LfUsername)
+					if recorded.Include(grantID) {
+						continue
+					}
+					recorded.Add(grantID)
+
+					if !dryRun {
+						if addErr := s.designeeRepo.AddDesigneeGrant(grant); addErr != nil {
+							log.Warnf("BackfillDesigneeGrants - unable to record the grant: %s, error: %+v", grantID, addErr)
+							continue
+						}
+					}
+					grant.DesigneeID = grantID
+					backfilled = append(backfilled, grant)
+				}
+			}
+		}
+	}
+
+	log.Debugf("BackfillDesigneeGrants - checked %d organizations, backfilled %d grants", len(organizationIDs), len(backfilled))
+	return backfilled, nil
+}
+
+// expireDesigneeGrant removes the role scope of the grant and logs the expiry event
+func (s *designeeExpiryService) expireDesigneeGrant(grant *DesigneeGrant, roleID, scopeID string, ttl time.Duration) {
+	deleteErr := v2OrgService.GetClient().DeleteOrgUserRoleOrgScopeProjectOrg(grant.CompanySFID, roleID, scopeID, &grant.LfUsername, &grant.UserEmail)
+	if deleteErr != nil {
+		log.Warnf("expireDesigneeGrant - unable to remove the role scope of grant: %s, error: %+v", grant.DesigneeID, deleteErr)
+		return
+	}
+	if err := s.designeeRepo.DeleteDesigneeGrant(grant.DesigneeID); err != nil {
+		log.Warnf("expireDesigneeGrant - unable to delete grant: %s, error: %+v", grant.DesigneeID, err)
+	}
+
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType:         events.ExpireUserRoleScopeType,
+		LfUsername:        grant.LfUsername,
+		ExternalProjectID: grant.ProjectSFID,
+		EventData: &events.ExpireRoleScopeData{
+			Role:      RoleCLAManagerDesignee,
+			Scope:     fmt.Sprintf("%s|%s", grant.ProjectSFID, grant.CompanySFID),
+			GrantedOn: grant.DateCreated,
+			TTLDays:   int(ttl.Hours() / 24),
+		},
+	})
+}
+
+// sendDesigneeExpiryReminder sends the designee a reminder that the role scope expires and records the reminder
+func (s *designeeExpiryService) sendDesigneeExpiryReminder(grant *DesigneeGrant, expiresOn time.Time) {
+	if grant.UserEmail == "" {
+		log.Warnf("sendDesigneeExpiryReminder - unable to send email - email missing for grant: %s", grant.DesigneeID)
+		return
+	}
+
+	companyName := grant.CompanySFID
+	org, orgErr := v2OrgService.GetClient().GetOrganization(grant.CompanySFID)
+	if orgErr == nil && org != nil && org.Name != "" {
+		companyName = org.Name
+	}
+	projectName := grant.ProjectName
+	if projectName == "" {
+		projectName = grant.ProjectSFID
+	}
+
+	// subject string, body string, recipients []string
+	subject := fmt.Sprintf("EasyCLA: CLA Manager Designee Role Expiring for %s", projectName)
+	recipients := []string{grant.UserEmail}
+	body := fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the project %s.</p>
+<p>You were assigned as a CLA Manager designee for %s on the project %s on %s. The Corporate CLA has not been
+signed yet and the designee role will be removed on %s.</p>
+<p>To keep managing the CLA for %s, please have the Corporate CLA signed before then. You may request the role again
+at any time.</p>
+%s
+%s`,
+		grant.LfUsername, projectName, companyName, projectName, grant.DateCreated, expiresOn.Format("2006-01-02"),
+		companyName, utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
+
+	err := utils.SendEmail(subject, body, recipients)
+	if err != nil {
+		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
+		return
+	}
+	log.Debugf("sent email with subject: %s to recipients: %+v", subject, recipients)
+
+	if updateErr := s.designeeRepo.UpdateDesigneeGrantReminderSent(grant.DesigneeID); updateErr != nil {
+		log.Warnf("sendDesigneeExpiryReminder - unable to record the reminder date of grant: %s, error: %+v", grant.DesigneeID, updateErr)
+	}
+}
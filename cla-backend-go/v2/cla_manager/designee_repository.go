@@ -0,0 +1,148 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_manager
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// DesigneeGrant is the record of a cla-manager-designee role scope assigned to a user
+type DesigneeGrant struct {
+	DesigneeID       string `dynamodbav:"designee_id"`
+	LfUsername       string `dynamodbav:"lf_username"`
+	UserEmail        string `dynamodbav:"user_email"`
+	UserSFID         string `dynamodbav:"user_sfid"`
+	ProjectSFID      string `dynamodbav:"project_sfid"`
+	ProjectName      string `dynamodbav:"project_name"`
+	CompanySFID      string `dynamodbav:"company_sfid"`
+	DateCreated      string `dynamodbav:"date_created"`
+	DateReminderSent string `dynamodbav:"date_reminder_sent"`
+}
+
+// DesigneeRepository stores the cla-manager-designee role scope assignments
+type DesigneeRepository interface {
+	AddDesigneeGrant(grant *DesigneeGrant) error
+	GetDesigneeGrants() ([]*DesigneeGrant, error)
+	UpdateDesigneeGrantReminderSent(designeeID string) error
+	DeleteDesigneeGrant(designeeID string) error
+}
+
+type designeeRepository struct {
+	tableName      string
+	dynamoDBClient *dynamodb.DynamoDB
+}
+
+// NewDesigneeRepository creates a new designee repository instance
+func NewDesigneeRepository(awsSession *session.Session, stage string) DesigneeRepository {
+	return designeeRepository{
+		tableName:      fmt.Sprintf("cla-%s-cla-manager-designees", stage),
+		dynamoDBClient: dynamodb.New(awsSession),
+	}
+}
+
+// designeeGrantID returns the ID of the grant of the user for the project and company - assigning the role again
+// replaces the previous grant
+func designeeGrantID(projectSFID, companySFID, lfUsername string) string {
+	return fmt.Sprintf("%s|%s|%s", projectSFID, companySFID, lfUsername)
+}
+
+// AddDesigneeGrant records the assignment of the cla-manager-designee role scope
+func (repo designeeRepository) AddDesigneeGrant(grant *DesigneeGrant) error {
+	_, now := utils.CurrentTime()
+	grant.DesigneeID = designeeGrantID(grant.ProjectSFID, grant.CompanySFID, grant.LfUsername)
+	grant.DateCreated = now
+	grant.DateReminderSent = ""
+
+	av, err := dynamodbattribute.MarshalMap(grant)
+	if err != nil {
+		log.Warnf("unable to marshal the designee grant: %+v, error: %v", grant, err)
+		return err
+	}
+	delete(av, "date_reminder_sent")
+
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.tableName),
+	})
+	if err != nil {
+		log.Warnf("unable to add the designee grant: %s, error: %v", grant.DesigneeID, err)
+		return err
+	}
+	return nil
+}
+
+// GetDesigneeGrants returns all the recorded cla-manager-designee role scope assignments
+func (repo designeeRepository) GetDesigneeGrants() ([]*DesigneeGrant, error) {
+	scanInput := &dynamodb.ScanInput{
+		TableName: aws.String(repo.tableName),
+	}
+
+	var grants []*DesigneeGrant
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.Warnf("error scanning the designee grants, error: %v", err)
+			return nil, err
+		}
+
+		var pageGrants []*DesigneeGrant
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &pageGrants)
+		if err != nil {
+			log.Warnf("error unmarshalling the designee grants, error: %v", err)
+			return nil, err
+		}
+		grants = append(grants, pageGrants...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return grants, nil
+}
+
+// UpdateDesigneeGrantReminderSent records the date of the expiry reminder of the grant
+func (repo designeeRepository) UpdateDesigneeGrantReminderSent(designeeID string) error {
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"designee_id": {S: aws.String(designeeID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#R": aws.String("date_reminder_sent"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":r": {S: aws.String(now)},
+		},
+		UpdateExpression: aws.String("SET #R = :r"),
+	})
+	if err != nil {
+		log.Warnf("unable to update the reminder date of designee grant: %s, error: %v", designeeID, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteDesigneeGrant removes the record of the grant
+func (repo designeeRepository) DeleteDesigneeGrant(designeeID string) error {
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: aws.String(repo.tableName),
+		Key: map[string]*dynamodb.AttributeValue{
+			"designee_id": {S: aws.String(designeeID)},
+		},
+	})
+	if err != nil {
+		log.Warnf("unable to delete designee grant: %s, error: %v", designeeID, err)
+		return err
+	}
+	return nil
+}
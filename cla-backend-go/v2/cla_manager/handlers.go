@@ -86,12 +86,36 @@ func Configure(api *operations.EasyclaAPI, service Service, LfxPortalURL string,
 
 		errResponse := service.DeleteCLAManager(cginfo.ClaGroupID, params)
 		if errResponse != nil {
+			if errResponse.Code == "409" {
+				return cla_manager.NewDeleteCLAManagerConflict().WithPayload(errResponse)
+			}
 			return cla_manager.NewDeleteCLAManagerBadRequest().WithPayload(errResponse)
 		}
 
 		return cla_manager.NewDeleteCLAManagerNoContent()
 	})
 
+	api.ClaManagerCreateFoundationCLAManagerHandler = cla_manager.CreateFoundationCLAManagerHandlerFunc(func(params cla_manager.CreateFoundationCLAManagerParams, authUser *auth.User) middleware.Responder {
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		if !utils.IsUserAuthorizedForProjectOrganization(authUser, params.FoundationSFID, params.CompanySFID) {
+			return cla_manager.NewCreateFoundationCLAManagerForbidden().WithPayload(&models.ErrorResponse{
+				Code: "403",
+				Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to CreateFoundationCLAManager with Project|Organization scope of %s | %s",
+					authUser.UserName, params.FoundationSFID, params.CompanySFID),
+			})
+		}
+
+		result, errResponse := service.CreateFoundationCLAManager(params.CompanySFID, params.FoundationSFID, params.UserLFID)
+		if errResponse != nil {
+			if errResponse.Code == "404" {
+				return cla_manager.NewCreateFoundationCLAManagerNotFound().WithPayload(errResponse)
+			}
+			return cla_manager.NewCreateFoundationCLAManagerBadRequest().WithPayload(errResponse)
+		}
+
+		return cla_manager.NewCreateFoundationCLAManagerOK().WithPayload(result)
+	})
+
 	api.ClaManagerCreateCLAManagerDesigneeHandler = cla_manager.CreateCLAManagerDesigneeHandlerFunc(func(params cla_manager.CreateCLAManagerDesigneeParams, authUser *auth.User) middleware.Responder {
 		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
 		if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
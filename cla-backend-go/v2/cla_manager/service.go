@@ -6,6 +6,7 @@ package cla_manager
 import (
 	"errors"
 	"fmt"
+	"html"
 	"regexp"
 	"strings"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/project"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/v2/organization-service/client/organizations"
 
 	v1ClaManager "github.com/communitybridge/easycla/cla-backend-go/cla_manager"
@@ -37,6 +39,14 @@ import (
 // Lead representing type of user
 const Lead = "lead"
 
+// outcomes of adding a CLA Manager to a CLA Group of a foundation
+const (
+	foundationCLAManagerAdded          = "added"
+	foundationCLAManagerAlreadyManager = "already_manager"
+	foundationCLAManagerNotSigned      = "not_signed"
+	foundationCLAManagerFailed         = "failed"
+)
+
 var (
 	//ErrSalesForceProjectNotFound returned error if salesForce Project not found
 	ErrSalesForceProjectNotFound = errors.New("salesforce Project not found")
@@ -75,12 +85,14 @@ type service struct {
 	v2CompanyService    v2Company.Service
 	eventService        events.Service
 	projectCGRepo       projects_cla_groups.Repository
+	designeeRepo        DesigneeRepository
 }
 
 // Service interface
 type Service interface {
 	CreateCLAManager(claGroupID string, params cla_manager.CreateCLAManagerParams, authUsername string) (*models.CompanyClaManager, *models.ErrorResponse)
 	DeleteCLAManager(claGroupID string, params cla_manager.DeleteCLAManagerParams) *models.ErrorResponse
+	CreateFoundationCLAManager(companySFID, foundationSFID, userLFID string) (*models.FoundationClaManagerResult, *models.ErrorResponse)
 	InviteCompanyAdmin(contactAdmin bool, companyID string, projectID string, userEmail string, contributor *v1User.User, lFxPortalURL string) (*models.ClaManagerDesignee, *models.ErrorResponse)
 	CreateCLAManagerDesignee(companyID string, projectID string, userEmail string) (*models.ClaManagerDesignee, error)
 	CreateCLAManagerRequest(contactAdmin bool, companyID string, projectID string, userEmail string, fullName string, authUser *auth.User, requestEmail, LfxPortalURL string) (*models.ClaManagerDesignee, error)
@@ -90,7 +102,7 @@ type Service interface {
 // NewService returns instance of CLA Manager service
 func NewService(compService company.IService, projService project.Service, mgrService v1ClaManager.IService, claUserService easyCLAUser.Service,
 	repoService repositories.Service, v2CompService v2Company.Service,
-	evService events.Service, projectCGroupRepo projects_cla_groups.Repository) Service {
+	evService events.Service, projectCGroupRepo projects_cla_groups.Repository, designeeRepo DesigneeRepository) Service {
 	return &service{
 		companyService:      compService,
 		projectService:      projService,
@@ -100,6 +112,7 @@ func NewService(compService company.IService, projService project.Service, mgrSe
 		v2CompanyService:    v2CompService,
 		eventService:        evService,
 		projectCGRepo:       projectCGroupRepo,
+		designeeRepo:        designeeRepo,
 	}
 }
 
@@ -379,6 +392,33 @@ func (s *service) DeleteCLAManager(claGroupID string, params cla_manager.DeleteC
 		}
 	}
 
+	// Reject the removal of the last CLA Manager before any role scope is removed
+	claManagers, managersErr := s.v2CompanyService.GetCompanyCLAGroupManagers(companyModel.CompanyID, claGroupID)
+	if managersErr != nil {
+		msg := buildErrorMessageDelete(params, managersErr)
+		log.Warn(msg)
+		return &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+	if claManagers == nil {
+		msg := fmt.Sprintf("Not found signature for project: %s and company: %s ", claGroupID, companyModel.CompanyID)
+		log.Warn(msg)
+		return &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+	if IsLastCLAManager(claManagers.List, params.UserLFID) {
+		msg := buildErrorMessageDelete(params, signatures.ErrLastCLAManager)
+		log.Warn(msg)
+		return &models.ErrorResponse{
+			Message: msg,
+			Code:    "409",
+		}
+	}
+
 	acsClient := v2AcsService.GetClient()
 
 	roleID, roleErr := acsClient.GetRoleID("cla-manager")
@@ -404,10 +444,17 @@ func (s *service) DeleteCLAManager(claGroupID string, params cla_manager.DeleteC
 	}
 
 	orgClient := v2OrgService.GetClient()
+	var email string
+	if len(user.Emails) > 0 {
+		email = utils.StringValue(user.Emails[0].EmailAddress)
+	}
 
+	// Projects of the removed role scopes - the scopes are restored if the manager can not be removed
+	var removedScopeProjectSFIDs []string
 	for _, projectCG := range projectCLAGroups {
 		scopeID, scopeErr := orgClient.GetScopeID(params.CompanySFID, projectCG.ProjectSFID, "cla-manager", "project|organization", params.UserLFID)
 		if scopeErr != nil {
+			s.restoreCLAManagerScopes(params.CompanySFID, roleID, email, removedScopeProjectSFIDs)
 			msg := buildErrorMessageDelete(params, scopeErr)
 			log.Warn(msg)
 			return &models.ErrorResponse{
@@ -416,6 +463,7 @@ func (s *service) DeleteCLAManager(claGroupID string, params cla_manager.DeleteC
 			}
 		}
 		if scopeID == "" {
+			s.restoreCLAManagerScopes(params.CompanySFID, roleID, email, removedScopeProjectSFIDs)
 			msg := buildErrorMessageDelete(params, ErrScopeNotFound)
 			log.Warn(msg)
 			return &models.ErrorResponse{
@@ -423,9 +471,9 @@ func (s *service) DeleteCLAManager(claGroupID string, params cla_manager.DeleteC
 				Code:    "400",
 			}
 		}
-		email := *user.Emails[0].EmailAddress
 		deleteErr := orgClient.DeleteOrgUserRoleOrgScopeProjectOrg(params.CompanySFID, roleID, scopeID, &user.Username, &email)
 		if deleteErr != nil {
+			s.restoreCLAManagerScopes(params.CompanySFID, roleID, email, removedScopeProjectSFIDs)
 			msg := buildErrorMessageDelete(params, deleteErr)
 			log.Warn(msg)
 			return &models.ErrorResponse{
@@ -433,10 +481,22 @@ func (s *service) DeleteCLAManager(claGroupID string, params cla_manager.DeleteC
 				Code:    "400",
 			}
 		}
+		removedScopeProjectSFIDs = append(removedScopeProjectSFIDs, projectCG.ProjectSFID)
 	}
 
 	signature, deleteErr := s.managerService.RemoveClaManager(companyModel.CompanyID, claGroupID, params.UserLFID)
 
+	if deleteErr != nil || signature == nil {
+		s.restoreCLAManagerScopes(params.CompanySFID, roleID, email, removedScopeProjectSFIDs)
+	}
+	if deleteErr == signatures.ErrLastCLAManager {
+		msg := buildErrorMessageDelete(params, deleteErr)
+		log.Warn(msg)
+		return &models.ErrorResponse{
+			Message: msg,
+			Code:    "409",
+		}
+	}
 	if deleteErr != nil {
 		msg := buildErrorMessageDelete(params, deleteErr)
 		log.Warn(msg)
@@ -457,6 +517,215 @@ func (s *service) DeleteCLAManager(claGroupID string, params cla_manager.DeleteC
 	return nil
 }
 
+// IsLastCLAManager returns true if the user is the only CLA Manager of the list
+func IsLastCLAManager(claManagers []*models.CompanyClaManager, lfUsername string) bool {
+	return len(claManagers) == 1 && claManagers[0].LfUsername == lfUsername
+}
+
+// restoreCLAManagerScopes assigns the cla-manager role scopes of the projects again after the CLA Manager could not
+// be removed from the signature ACL
+func (s *service) restoreCLAManagerScopes(companySFID, roleID, email string, projectSFIDs []string) {
+	orgClient := v2OrgService.GetClient()
+	for _, projectSFID := range projectSFIDs {
+		scopeErr := orgClient.CreateOrgUserRoleOrgScopeProjectOrg(email, projectSFID, companySFID, roleID)
+		if scopeErr != nil {
+			log.Warnf("unable to restore the cla-manager role scope of user: %s for company: %s, project: %s, error: %+v",
+				email, companySFID, projectSFID, scopeErr)
+		}
+	}
+}
+
+// CreateFoundationCLAManager adds the user as CLA Manager to the signed CCLA of every CLA Group of the foundation and
+// assigns the cla-manager role scope for the projects of each CLA Group. A failure for one CLA Group does not stop the
+// remaining CLA Groups, the outcome of each CLA Group is reported.
+func (s *service) CreateFoundationCLAManager(companySFID, foundationSFID, userLFID string) (*models.FoundationClaManagerResult, *models.ErrorResponse) {
+	companyModel, companyErr := s.companyService.GetCompanyByExternalID(companySFID)
+	if companyErr != nil || companyModel == nil {
+		msg := fmt.Sprintf("company lookup error for companySFID: %s, error: %+v", companySFID, companyErr)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+
+	userServiceClient := v2UserService.GetClient()
+	user, userErr := userServiceClient.GetUserByUsername(userLFID)
+	if userErr != nil || user == nil {
+		msg := fmt.Sprintf("Failed to get user when searching by username: %s , error: %v ", userLFID, userErr)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+	if user.Account.ID != strings.TrimSpace(companySFID) {
+		msg := fmt.Sprintf("User : %s not in organization : %s ", user.Username, companySFID)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+	var userEmail string
+	for _, e := range user.Emails {
+		if e != nil && e.IsPrimary != nil && *e.IsPrimary {
+			userEmail = utils.StringValue(e.EmailAddress)
+			break
+		}
+	}
+	if userEmail == "" {
+		msg := fmt.Sprintf("User : %s has no email address", user.Username)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+
+	projectCLAGroups, getErr := s.projectCGRepo.GetProjectsIdsForFoundation(foundationSFID)
+	if getErr != nil {
+		msg := fmt.Sprintf("Error getting the CLA Groups of foundation: %s, error: %+v", foundationSFID, getErr)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+	if len(projectCLAGroups) == 0 {
+		msg := fmt.Sprintf("No CLA Groups found for foundation: %s", foundationSFID)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "404",
+		}
+	}
+
+	// AddClaManager requires the EasyCLA user record
+	claUser, claUserErr := s.easyCLAUserService.GetUserByLFUserName(user.Username)
+	if claUserErr != nil {
+		msg := fmt.Sprintf("Problem getting claUser by :%s, error: %+v ", user.Username, claUserErr)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+	if claUser == nil {
+		log.Debugf("User not found when searching by LFID: %s and shall be created", user.Username)
+		_, currentTimeString := utils.CurrentTime()
+		claUserModel := &v1Models.User{
+			UserExternalID: companySFID,
+			LfEmail:        userEmail,
+			Admin:          true,
+			LfUsername:     user.Username,
+			DateCreated:    currentTimeString,
+			DateModified:   currentTimeString,
+			Username:       fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+			Version:        "v1",
+		}
+		_, userModelErr := s.easyCLAUserService.CreateUser(claUserModel, nil)
+		if userModelErr != nil {
+			msg := fmt.Sprintf("Failed to create user : %+v", claUserModel)
+			log.Warn(msg)
+			return nil, &models.ErrorResponse{
+				Message: msg,
+				Code:    "400",
+			}
+		}
+	}
+
+	roleID, roleErr := v2AcsService.GetClient().GetRoleID("cla-manager")
+	if roleErr != nil {
+		msg := fmt.Sprintf("Failed to get the role ID of cla-manager, error: %+v", roleErr)
+		log.Warn(msg)
+		return nil, &models.ErrorResponse{
+			Message: msg,
+			Code:    "400",
+		}
+	}
+
+	claGroupIDs, claGroupProjects := GroupProjectsByCLAGroup(projectCLAGroups)
+
+	result := &models.FoundationClaManagerResult{
+		CompanySFID:    companySFID,
+		FoundationSFID: foundationSFID,
+		UserLFID:       user.Username,
+	}
+	for _, claGroupID := range claGroupIDs {
+		result.Results = append(result.Results, s.addFoundationCLAGroupManager(companyModel.CompanyID, companySFID, claGroupID,
+			claGroupProjects[claGroupID], user.ID, user.Username, userEmail, roleID))
+	}
+
+	if user.Type == Lead {
+		log.Debug("converting lead to contact")
+		if err := userServiceClient.ConvertToContact(user.ID); err != nil {
+			log.Warnf("converting lead to contact failed: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// GroupProjectsByCLAGroup groups the projects of a foundation by CLA Group, returns the CLA Group IDs in the order
+// of their first project
+func GroupProjectsByCLAGroup(projectCLAGroups []*projects_cla_groups.ProjectClaGroup) ([]string, map[string][]*projects_cla_groups.ProjectClaGroup) {
+	var claGroupIDs []string
+	claGroupProjects := make(map[string][]*projects_cla_groups.ProjectClaGroup)
+	for _, projectCG := range projectCLAGroups {
+		if _, ok := claGroupProjects[projectCG.ClaGroupID]; !ok {
+			claGroupIDs = append(claGroupIDs, projectCG.ClaGroupID)
+		}
+		claGroupProjects[projectCG.ClaGroupID] = append(claGroupProjects[projectCG.ClaGroupID], projectCG)
+	}
+	return claGroupIDs, claGroupProjects
+}
+
+// addFoundationCLAGroupManager adds the CLA Manager to the signed CCLA of the CLA Group and assigns the cla-manager role
+// scope for each project of the CLA Group
+func (s *service) addFoundationCLAGroupManager(companyID, companySFID, claGroupID string, projectCLAGroups []*projects_cla_groups.ProjectClaGroup,
+	userSFID, lfUsername, userEmail, roleID string) *models.FoundationClaManagerClaGroupResult {
+	groupResult := &models.FoundationClaManagerClaGroupResult{
+		ClaGroupID:   claGroupID,
+		ClaGroupName: projectCLAGroups[0].ClaGroupName,
+		Status:       foundationCLAManagerAdded,
+	}
+
+	signature, addErr := s.managerService.AddClaManager(companyID, claGroupID, lfUsername)
+	if addErr == signatures.ErrCLAManagerAlreadyInACL {
+		// still assign any missing role scopes so a partially failed request can be repeated
+		groupResult.Status = foundationCLAManagerAlreadyManager
+	} else if addErr != nil {
+		log.Warnf("unable to add CLA Manager: %s to CLA Group: %s of company: %s, error: %+v", lfUsername, claGroupID, companyID, addErr)
+		groupResult.Status = foundationCLAManagerFailed
+		groupResult.Message = addErr.Error()
+		return groupResult
+	} else if signature == nil {
+		log.Debugf("no signed CCLA for CLA Group: %s and company: %s", claGroupID, companyID)
+		groupResult.Status = foundationCLAManagerNotSigned
+		groupResult.Message = "the Corporate CLA of the CLA Group is not signed"
+		return groupResult
+	}
+
+	orgClient := v2OrgService.GetClient()
+	for _, projectCG := range projectCLAGroups {
+		hasScope, err := orgClient.IsUserHaveRoleScope("cla-manager", userSFID, companySFID, projectCG.ProjectSFID)
+		if err == nil && !hasScope {
+			err = orgClient.CreateOrgUserRoleOrgScopeProjectOrg(userEmail, projectCG.ProjectSFID, companySFID, roleID)
+		}
+		if err != nil {
+			log.Warnf("unable to assign the cla-manager role scope to user: %s for project: %s and company: %s, error: %+v",
+				lfUsername, projectCG.ProjectSFID, companySFID, err)
+			groupResult.Status = foundationCLAManagerFailed
+			groupResult.Message = fmt.Sprintf("the signature ACL was updated but the role scope for project %s was not assigned: %v",
+				projectCG.ProjectSFID, err)
+			return groupResult
+		}
+		groupResult.ProjectSFIDs = append(groupResult.ProjectSFIDs, projectCG.ProjectSFID)
+	}
+	return groupResult
+}
+
 //CreateCLAManagerDesignee creates designee for cla manager prospect
 func (s *service) CreateCLAManagerDesignee(companyID string, projectID string, userEmail string) (*models.ClaManagerDesignee, error) {
 	// integrate user,acs,org and project services
@@ -502,6 +771,20 @@ func (s *service) CreateCLAManagerDesignee(companyID string, projectID string, u
 		return nil, scopeErr
 	}
 
+	// Record the grant so that the role scope expires if the CCLA is never signed
+	grantErr := s.designeeRepo.AddDesigneeGrant(&DesigneeGrant{
+		LfUsername:  user.Username,
+		UserEmail:   userEmail,
+		UserSFID:    user.ID,
+		ProjectSFID: projectID,
+		ProjectName: projectSF.Name,
+		CompanySFID: companyID,
+	})
+	if grantErr != nil {
+		log.Warnf("Problem recording the cla-manager-designee grant for user: %s, projectID: %s, companyID: %s, error: %+v",
+			user.Username, projectID, companyID, grantErr)
+	}
+
 	// Log Event
 	s.eventService.LogEvent(
 		&events.LogEventArgs{
@@ -805,7 +1088,15 @@ func sendEmailToCLAManager(manager string, managerEmail string, contributorName
     %s`,
 		manager, company, project, contributorName,
 		utils.GetEmailHelpContent(true), utils.GetEmailSignOffContent())
-	err := utils.SendEmail(subject, body, recipients)
+	err := utils.SendNotificationEmail(&utils.NotificationEmail{
+		Subject:          subject,
+		Body:             body,
+		RecipientName:    manager,
+		RecipientAddress: managerEmail,
+		CompanyName:      company,
+		ClaGroupName:     project,
+		Summary:          fmt.Sprintf("- %s requested to be approved as a contributor", html.EscapeString(contributorName)),
+	})
 	if err != nil {
 		log.Warnf("problem sending email with subject: %s to recipients: %+v, error: %+v", subject, recipients, err)
 	} else {
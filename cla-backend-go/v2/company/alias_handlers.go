@@ -0,0 +1,47 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/company"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// configureAliasHandlers sets up the handlers of the aliases and former names of a company
+func configureAliasHandlers(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository) {
+	api.CompanyUpdateCompanyAliasesHandler = company.UpdateCompanyAliasesHandlerFunc(
+		func(params company.UpdateCompanyAliasesParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewUpdateCompanyAliasesForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Update Company Aliases with Organization scope of %s",
+						authUser.UserName, params.CompanySFID),
+				})
+			}
+
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewUpdateCompanyAliasesNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewUpdateCompanyAliasesBadRequest().WithPayload(errorResponse(err))
+			}
+
+			result, err := service.UpdateCompanyAliases(comp, params.Body)
+			if err != nil {
+				log.Warnf("unable to update the aliases of company: %s, error: %+v", comp.CompanyID, err)
+				return company.NewUpdateCompanyAliasesBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewUpdateCompanyAliasesOK().WithPayload(result)
+		})
+}
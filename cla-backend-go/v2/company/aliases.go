@@ -0,0 +1,81 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"strings"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// UpdateCompanyAliases replaces the aliases and former names of the company
+func (s *service) UpdateCompanyAliases(companyModel *v1Models.Company, input *models.CompanyAliasesInput) (*models.Company, error) {
+	aliases := CleanCompanyNames(companyModel.CompanyName, input.CompanyAliases)
+	formerNames := CleanCompanyNames(companyModel.CompanyName, input.CompanyFormerNames)
+
+	err := s.companyRepo.UpdateCompanyAliases(companyModel.CompanyID, aliases, formerNames)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCompanyByID(companyModel.CompanyID)
+}
+
+// getProbableDuplicateCompanies returns the existing companies whose name, aliases or former names are similar
+// enough to the company name to probably be the same company
+func (s *service) getProbableDuplicateCompanies(companyName string) ([]*models.CompanyMatch, error) {
+	companies, err := s.companyRepo.SearchCompanyByName(companyName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*models.CompanyMatch
+	for _, companyModel := range companies.Companies {
+		if companyModel.RelevanceScore < utils.ProbableDuplicateCompanyScore {
+			continue
+		}
+		matches = append(matches, &models.CompanyMatch{
+			CompanyID:         companyModel.CompanyID,
+			CompanyExternalID: companyModel.CompanyExternalID,
+			CompanyName:       companyModel.CompanyName,
+			RelevanceScore:    companyModel.RelevanceScore,
+		})
+	}
+	return matches, nil
+}
+
+// warnProbableDuplicateCompanies logs the probable duplicates of the company name
+func (s *service) warnProbableDuplicateCompanies(f logrus.Fields, companyName string) {
+	probableDuplicates, err := s.getProbableDuplicateCompanies(companyName)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to search for probable duplicates of the company, error: %+v", err)
+	}
+	for _, duplicate := range probableDuplicates {
+		log.WithFields(f).Warnf("company: %s is a probable duplicate of the existing company: %s (%s) with relevance score: %.2f",
+			companyName, duplicate.CompanyName, duplicate.CompanyID, duplicate.RelevanceScore)
+	}
+}
+
+// CleanCompanyNames returns the trimmed, non-empty names without duplicates and without the company name itself
+func CleanCompanyNames(companyName string, names []string) []string {
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(companyName)): true}
+	var result []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		if !utils.ValidCompanyName(name) {
+			log.Warnf("ignoring invalid name: %s for company: %s", name, companyName)
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
+}
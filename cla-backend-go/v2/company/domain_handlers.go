@@ -0,0 +1,147 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/company"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// configureDomainHandlers sets up the handlers of the domains claimed by a company
+func configureDomainHandlers(api *operations.EasyclaAPI, v1CompanyRepo v1Company.IRepository, domainService v1Company.DomainVerificationService) {
+	api.CompanyListCompanyDomainsHandler = company.ListCompanyDomainsHandlerFunc(
+		func(params company.ListCompanyDomainsParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewListCompanyDomainsForbidden().WithPayload(domainForbiddenResponse(authUser, "List Company Domains", params.CompanySFID))
+			}
+
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewListCompanyDomainsNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewListCompanyDomainsBadRequest().WithPayload(errorResponse(err))
+			}
+
+			domains, err := domainService.GetCompanyDomains(comp.CompanyID)
+			if err != nil {
+				log.Warnf("unable to load the domains of company: %s, error: %+v", comp.CompanyID, err)
+				return company.NewListCompanyDomainsBadRequest().WithPayload(errorResponse(err))
+			}
+
+			result := &models.CompanyDomainList{}
+			for _, domain := range domains {
+				result.List = append(result.List, v2CompanyDomain(domain))
+			}
+			return company.NewListCompanyDomainsOK().WithPayload(result)
+		})
+
+	api.CompanyClaimCompanyDomainHandler = company.ClaimCompanyDomainHandlerFunc(
+		func(params company.ClaimCompanyDomainParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewClaimCompanyDomainForbidden().WithPayload(domainForbiddenResponse(authUser, "Claim Company Domain", params.CompanySFID))
+			}
+
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewClaimCompanyDomainNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewClaimCompanyDomainBadRequest().WithPayload(errorResponse(err))
+			}
+
+			domain, err := domainService.ClaimCompanyDomain(comp.CompanyID, params.Body.Domain)
+			if err != nil {
+				log.Warnf("unable to claim domain: %s for company: %s, error: %+v", params.Body.Domain, comp.CompanyID, err)
+				if err == v1Company.ErrDomainAlreadyClaimed {
+					return company.NewClaimCompanyDomainConflict().WithPayload(errorResponse(err))
+				}
+				return company.NewClaimCompanyDomainBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewClaimCompanyDomainOK().WithPayload(v2CompanyDomain(domain))
+		})
+
+	api.CompanyVerifyCompanyDomainHandler = company.VerifyCompanyDomainHandlerFunc(
+		func(params company.VerifyCompanyDomainParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewVerifyCompanyDomainForbidden().WithPayload(domainForbiddenResponse(authUser, "Verify Company Domain", params.CompanySFID))
+			}
+
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewVerifyCompanyDomainNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewVerifyCompanyDomainBadRequest().WithPayload(errorResponse(err))
+			}
+
+			domain, err := domainService.VerifyCompanyDomain(comp.CompanyID, params.Domain)
+			if err != nil {
+				log.Warnf("unable to verify domain: %s of company: %s, error: %+v", params.Domain, comp.CompanyID, err)
+				if err == v1Company.ErrDomainNotClaimed {
+					return company.NewVerifyCompanyDomainNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewVerifyCompanyDomainBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewVerifyCompanyDomainOK().WithPayload(v2CompanyDomain(domain))
+		})
+
+	api.CompanyDeleteCompanyDomainHandler = company.DeleteCompanyDomainHandlerFunc(
+		func(params company.DeleteCompanyDomainParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewDeleteCompanyDomainForbidden().WithPayload(domainForbiddenResponse(authUser, "Delete Company Domain", params.CompanySFID))
+			}
+
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewDeleteCompanyDomainNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewDeleteCompanyDomainBadRequest().WithPayload(errorResponse(err))
+			}
+
+			err = domainService.RemoveCompanyDomain(comp.CompanyID, params.Domain)
+			if err != nil {
+				log.Warnf("unable to remove domain: %s of company: %s, error: %+v", params.Domain, comp.CompanyID, err)
+				if err == v1Company.ErrDomainNotClaimed {
+					return company.NewDeleteCompanyDomainNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewDeleteCompanyDomainBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewDeleteCompanyDomainNoContent()
+		})
+}
+
+// domainForbiddenResponse returns the response of a user without access to the domains of the company
+func domainForbiddenResponse(authUser *auth.User, operation, companySFID string) *models.ErrorResponse {
+	return &models.ErrorResponse{
+		Code: "403",
+		Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to %s with Organization scope of %s",
+			authUser.UserName, operation, companySFID),
+	}
+}
+
+// v2CompanyDomain converts the v1 company domain model to a v2 model
+func v2CompanyDomain(domain *v1Models.CompanyDomain) *models.CompanyDomain {
+	return &models.CompanyDomain{
+		Domain:             domain.Domain,
+		VerificationRecord: domain.VerificationRecord,
+		Verified:           domain.Verified,
+		DateCreated:        domain.DateCreated,
+		DateVerified:       domain.DateVerified,
+	}
+}
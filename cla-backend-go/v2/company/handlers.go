@@ -21,7 +21,7 @@ import (
 )
 
 // Configure sets up the middleware handlers
-func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository, LFXPortalURL string) { // nolint
+func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository, domainService v1Company.DomainVerificationService, LFXPortalURL string) { // nolint
 
 	api.CompanyGetCompanyProjectClaManagersHandler = company.GetCompanyProjectClaManagersHandlerFunc(
 		func(params company.GetCompanyProjectClaManagersParams, authUser *auth.User) middleware.Responder {
@@ -290,6 +290,10 @@ func Configure(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Comp
 
 			return company.NewDeleteCompanyBySFIDNoContent()
 		})
+
+	configureDomainHandlers(api, v1CompanyRepo, domainService)
+	configureHierarchyHandlers(api, service, v1CompanyRepo)
+	configureAliasHandlers(api, service, v1CompanyRepo)
 }
 
 type codedResponse interface {
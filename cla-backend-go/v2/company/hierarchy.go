@@ -0,0 +1,106 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+)
+
+// GetCompanyHierarchy returns the parent company and the subsidiaries of the company
+func (s *service) GetCompanyHierarchy(companyModel *v1Models.Company) (*models.CompanyHierarchy, error) {
+	hierarchy := &models.CompanyHierarchy{
+		CompanyID:              companyModel.CompanyID,
+		CompanyName:            companyModel.CompanyName,
+		SubsidiaryCclaCoverage: companyModel.SubsidiaryCclaCoverage,
+		Subsidiaries:           []*models.CompanyHierarchyEntry{},
+	}
+
+	if companyModel.ParentCompanyID != "" {
+		parent, err := s.companyRepo.GetCompany(companyModel.ParentCompanyID)
+		if err != nil && err != company.ErrCompanyDoesNotExist {
+			return nil, err
+		}
+		if parent != nil {
+			hierarchy.ParentCompany = companyHierarchyEntry(parent)
+		} else {
+			log.Warnf("the parent company: %s of company: %s does not exist", companyModel.ParentCompanyID, companyModel.CompanyID)
+		}
+	}
+
+	subsidiaries, err := s.companyRepo.GetSubsidiaryCompanies(companyModel.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	for _, subsidiary := range subsidiaries {
+		hierarchy.Subsidiaries = append(hierarchy.Subsidiaries, companyHierarchyEntry(subsidiary))
+	}
+	return hierarchy, nil
+}
+
+// UpdateCompanyHierarchy updates the parent company of the company and whether the CCLAs of the company cover the
+// employees of its subsidiaries
+func (s *service) UpdateCompanyHierarchy(companyModel *v1Models.Company, input *models.CompanyHierarchyInput) (*models.CompanyHierarchy, error) {
+	if input.ParentCompanyID != "" && input.ParentCompanyID != companyModel.ParentCompanyID {
+		err := company.ValidateParentCompany(s.companyRepo, companyModel.CompanyID, input.ParentCompanyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.companyRepo.UpdateCompanyHierarchy(companyModel.CompanyID, input.ParentCompanyID, input.SubsidiaryCclaCoverage)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedCompany, err := s.companyRepo.GetCompany(companyModel.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCompanyHierarchy(updatedCompany)
+}
+
+// getParentCompanyProjectActiveCLAs returns the active CLAs of the parent companies which cover the CLA Groups
+// the company has not signed - the nearest parent company is used when several parent companies signed a CLA Group
+func (s *service) getParentCompanyProjectActiveCLAs(companyModel *v1Models.Company, projectSFID string, unsignedClaGroups map[string]*claGroupModel) ([]*models.ActiveCla, error) {
+	parentCLAs := make([]*models.ActiveCla, 0)
+	if companyModel.ParentCompanyID == "" || len(unsignedClaGroups) == 0 {
+		return parentCLAs, nil
+	}
+
+	coveringCompanies, err := company.GetCCLACoveringCompanies(s.companyRepo, companyModel)
+	if err != nil {
+		return nil, err
+	}
+
+	covered := make(map[string]bool)
+	for _, coveringCompany := range coveringCompanies {
+		activeCLAList, err := s.GetCompanyProjectActiveCLAs(coveringCompany.CompanyID, projectSFID)
+		if err != nil {
+			return nil, err
+		}
+		for _, activeCLA := range activeCLAList.List {
+			if _, unsigned := unsignedClaGroups[activeCLA.ProjectID]; !unsigned || covered[activeCLA.ProjectID] {
+				continue
+			}
+			covered[activeCLA.ProjectID] = true
+			activeCLA.CoveringCompanyID = coveringCompany.CompanyID
+			activeCLA.CoveringCompanyName = coveringCompany.CompanyName
+			parentCLAs = append(parentCLAs, activeCLA)
+		}
+	}
+	return parentCLAs, nil
+}
+
+// companyHierarchyEntry converts the company to a company hierarchy entry
+func companyHierarchyEntry(companyModel *v1Models.Company) *models.CompanyHierarchyEntry {
+	return &models.CompanyHierarchyEntry{
+		CompanyID:              companyModel.CompanyID,
+		CompanyName:            companyModel.CompanyName,
+		CompanyExternalID:      companyModel.CompanyExternalID,
+		SubsidiaryCclaCoverage: companyModel.SubsidiaryCclaCoverage,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company
+
+import (
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/company"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// configureHierarchyHandlers sets up the handlers of the parent company and subsidiaries of a company
+func configureHierarchyHandlers(api *operations.EasyclaAPI, service Service, v1CompanyRepo v1Company.IRepository) {
+	api.CompanyGetCompanyHierarchyHandler = company.GetCompanyHierarchyHandlerFunc(
+		func(params company.GetCompanyHierarchyParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewGetCompanyHierarchyForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Get Company Hierarchy with Organization scope of %s",
+						authUser.UserName, params.CompanySFID),
+				})
+			}
+
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewGetCompanyHierarchyNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewGetCompanyHierarchyBadRequest().WithPayload(errorResponse(err))
+			}
+
+			result, err := service.GetCompanyHierarchy(comp)
+			if err != nil {
+				log.Warnf("unable to load the hierarchy of company: %s, error: %+v", comp.CompanyID, err)
+				return company.NewGetCompanyHierarchyBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewGetCompanyHierarchyOK().WithPayload(result)
+		})
+
+	api.CompanyUpdateCompanyHierarchyHandler = company.UpdateCompanyHierarchyHandlerFunc(
+		func(params company.UpdateCompanyHierarchyParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAuthorizedForOrganization(authUser, params.CompanySFID) {
+				return company.NewUpdateCompanyHierarchyForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Update Company Hierarchy with Organization scope of %s",
+						authUser.UserName, params.CompanySFID),
+				})
+			}
+
+			comp, err := v1CompanyRepo.GetCompanyByExternalID(params.CompanySFID)
+			if err != nil {
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewUpdateCompanyHierarchyNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewUpdateCompanyHierarchyBadRequest().WithPayload(errorResponse(err))
+			}
+
+			// A subsidiary is covered by the CCLAs of the parent company - the user must have access to the parent
+			// company as well, admins only when the parent company has no SFID
+			if params.Body.ParentCompanyID != "" && params.Body.ParentCompanyID != comp.ParentCompanyID {
+				parent, parentErr := v1CompanyRepo.GetCompany(params.Body.ParentCompanyID)
+				if parentErr != nil {
+					if parentErr == v1Company.ErrCompanyDoesNotExist {
+						return company.NewUpdateCompanyHierarchyNotFound().WithPayload(errorResponse(parentErr))
+					}
+					return company.NewUpdateCompanyHierarchyBadRequest().WithPayload(errorResponse(parentErr))
+				}
+				if !utils.IsUserAdmin(authUser) &&
+					(parent.CompanyExternalID == "" || !utils.IsUserAuthorizedForOrganization(authUser, parent.CompanyExternalID)) {
+					return company.NewUpdateCompanyHierarchyForbidden().WithPayload(&models.ErrorResponse{
+						Code: "403",
+						Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to the parent company %s",
+							authUser.UserName, parent.CompanyName),
+					})
+				}
+			}
+
+			result, err := service.UpdateCompanyHierarchy(comp, params.Body)
+			if err != nil {
+				log.Warnf("unable to update the hierarchy of company: %s, error: %+v", comp.CompanyID, err)
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewUpdateCompanyHierarchyNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewUpdateCompanyHierarchyBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewUpdateCompanyHierarchyOK().WithPayload(result)
+		})
+}
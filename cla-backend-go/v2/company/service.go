@@ -71,6 +71,9 @@ type Service interface {
 	DeleteCompanyByID(companyID string) error
 	DeleteCompanyBySFID(companySFID string) error
 	GetCompanyCLAGroupManagers(companyID, claGroupID string) (*models.CompanyClaManagers, error)
+	GetCompanyHierarchy(companyModel *v1Models.Company) (*models.CompanyHierarchy, error)
+	UpdateCompanyHierarchy(companyModel *v1Models.Company, input *models.CompanyHierarchyInput) (*models.CompanyHierarchy, error)
+	UpdateCompanyAliases(companyModel *v1Models.Company, input *models.CompanyAliasesInput) (*models.Company, error)
 }
 
 // ProjectRepo contains project repo methods
@@ -213,6 +216,9 @@ func (s *service) CreateCompany(companyName string, companyWebsite string, userE
 	f := logrus.Fields{"companyName": companyName, "companyWebsite": companyWebsite, "userID": userID, "LFXPortalURL": LFXPortalURL}
 	var lfUser *v2UserServiceModels.User
 
+	// Warn about probable duplicates - the names are fuzzy matched, so the company is created anyway
+	s.warnProbableDuplicateCompanies(f, companyName)
+
 	// Create Sales Force company
 	orgClient := orgService.GetClient()
 	log.Debugf("Creating Organization : %s Website: %s", companyName, companyWebsite)
@@ -270,9 +276,10 @@ func (s *service) CreateCompany(companyName string, companyWebsite string, userE
 	}
 
 	return &models.CompanyOutput{
-		CompanyName:    org.Name,
-		CompanyWebsite: companyWebsite,
-		LogoURL:        org.LogoURL,
+		CompanyName:        org.Name,
+		CompanyWebsite:     companyWebsite,
+		LogoURL:            org.LogoURL,
+		ProbableDuplicates: probableDuplicates,
 	}, nil
 }
 
@@ -435,6 +442,16 @@ func (s *service) GetCompanyProjectCLA(authUser *auth.User, companySFID, project
 		delete(claGroups, activeCLA.ProjectID)
 	}
 
+	// the CCLAs of the parent companies which opted in cover the CLA Groups the company has not signed
+	resp.ParentSignedClaList, err = s.getParentCompanyProjectActiveCLAs(companyModel, projectSFID, claGroups)
+	if err != nil {
+		log.WithFields(f).Warnf("problem fetching the active CLAs of the parent companies, error: %+v", err)
+		return nil, err
+	}
+	for _, parentCLA := range resp.ParentSignedClaList {
+		delete(claGroups, parentCLA.ProjectID)
+	}
+
 	// fill details for not signed cla
 	for claGroupID, claGroup := range claGroups {
 		unsignedProject := &models.UnsignedProject{
@@ -900,6 +917,9 @@ func (s service) autoCreateCompany(companySFID string) (*v1Models.Company, error
 	}
 
 	log.WithFields(f).Debug("found platform organization record in SF")
+	// Warn about probable duplicates - the names are fuzzy matched, so the company is created anyway
+	s.warnProbableDuplicateCompanies(f, sfOrgModel.Name)
+
 	// Auto-create based on the SF record information
 	companyModel, companyCreateErr := s.companyRepo.CreateCompany(&v1Models.Company{
 		CompanyExternalID: companySFID,
@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1Company "github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/company"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+	api.CompanyPreviewCompanyMergeHandler = company.PreviewCompanyMergeHandlerFunc(
+		func(params company.PreviewCompanyMergeParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return company.NewPreviewCompanyMergeForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Preview Company Merge - only Admins allowed to merge companies.",
+						authUser.UserName),
+				})
+			}
+
+			plan, err := service.PreviewMerge(params.Body.SurvivorCompanyID, params.Body.DuplicateCompanyIDs)
+			if err != nil {
+				log.Warnf("unable to preview the merge of companies %+v into company: %s, error: %+v",
+					params.Body.DuplicateCompanyIDs, params.Body.SurvivorCompanyID, err)
+				if err == v1Company.ErrCompanyDoesNotExist {
+					return company.NewPreviewCompanyMergeNotFound().WithPayload(errorResponse(err))
+				}
+				return company.NewPreviewCompanyMergeBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewPreviewCompanyMergeOK().WithPayload(plan)
+		})
+
+	api.CompanyMergeCompaniesHandler = company.MergeCompaniesHandlerFunc(
+		func(params company.MergeCompaniesParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if !utils.IsUserAdmin(authUser) {
+				return company.NewMergeCompaniesForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to Merge Companies - only Admins allowed to merge companies.",
+						authUser.UserName),
+				})
+			}
+
+			plan, err := service.Merge(params.Body.SurvivorCompanyID, params.Body.DuplicateCompanyIDs, authUser.UserName)
+			if err != nil {
+				log.Warnf("unable to merge companies %+v into company: %s, error: %+v",
+					params.Body.DuplicateCompanyIDs, params.Body.SurvivorCompanyID, err)
+				switch err {
+				case v1Company.ErrCompanyDoesNotExist:
+					return company.NewMergeCompaniesNotFound().WithPayload(errorResponse(err))
+				case ErrMergeConflict:
+					return company.NewMergeCompaniesConflict().WithPayload(&models.ErrorResponse{
+						Code:    "409",
+						Message: fmt.Sprintf("EasyCLA - 409 Conflict - %s: %s", err.Error(), strings.Join(plan.Conflicts, ", ")),
+					})
+				}
+				return company.NewMergeCompaniesBadRequest().WithPayload(errorResponse(err))
+			}
+			return company.NewMergeCompaniesOK().WithPayload(plan)
+		})
+}
+
+type codedResponse interface {
+	Code() string
+}
+
+func errorResponse(err error) *models.ErrorResponse {
+	code := ""
+	if e, ok := err.(codedResponse); ok {
+		code = e.Code()
+	}
+
+	e := models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	return &e
+}
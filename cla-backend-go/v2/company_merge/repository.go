@@ -0,0 +1,307 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// the tables and indexes holding references to a company
+const (
+	signaturesTable         = "signatures"
+	companyInvitesTable     = "company-invites"
+	approvalRequestsTable   = "ccla-whitelist-requests"
+	claManagerRequestsTable = "cla-manager-requests"
+	usersTable              = "users"
+	companiesTable          = "companies"
+
+	signatureReferenceIndex       = "reference-signature-index"
+	signatureUserCCLACompanyIndex = "signature-user-ccla-company-index"
+	requestedCompanyIndex         = "requested-company-index"
+	approvalRequestCompanyIndex   = "company-id-project-id-index"
+	claManagerRequestCompanyIndex = "cla-manager-requests-company-project-index"
+)
+
+// CompanySignature is the part of a signature record needed to merge the signatures of companies
+type CompanySignature struct {
+	SignatureID        string `dynamodbav:"signature_id"`
+	SignatureType      string `dynamodbav:"signature_type"`
+	SignatureProjectID string `dynamodbav:"signature_project_id"`
+}
+
+// Repository reads and repoints the records referencing a company
+type Repository interface {
+	GetCompanySignatures(companyID string) ([]*CompanySignature, error)
+	GetEmployeeSignatureIDs(companyID string) ([]string, error)
+	GetInviteIDs(companyID string) ([]string, error)
+	GetApprovalListRequestIDs(companyID string) ([]string, error)
+	GetCLAManagerRequestIDs(companyID string) ([]string, error)
+	GetUserIDs(companyID string) ([]string, error)
+
+	UpdateSignatureCompany(signatureID, companyID, companyName string) error
+	UpdateEmployeeSignatureCompany(signatureID, companyID string) error
+	UpdateInviteCompany(inviteID, companyID string) error
+	UpdateApprovalListRequestCompany(requestID, companyID, companyName string) error
+	UpdateCLAManagerRequestCompany(requestID, companyID, companyExternalID, companyName string) error
+	UpdateUserCompany(userID, companyID string) error
+	UpdateCompanyExternalID(companyID, companyExternalID string) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+}
+
+// NewRepository creates a new company merge repository instance
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+	}
+}
+
+func (repo repository) tableName(table string) string {
+	return fmt.Sprintf("cla-%s-%s", repo.stage, table)
+}
+
+// GetCompanySignatures returns the signatures which reference the company, for example the CCLA signatures
+func (repo repository) GetCompanySignatures(companyID string) ([]*CompanySignature, error) {
+	keyCondition := expression.Key("signature_reference_id").Equal(expression.Value(companyID))
+	projection := expression.NamesList(expression.Name("signature_id"), expression.Name("signature_type"), expression.Name("signature_project_id"))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		log.Warnf("error building expression for the signatures of company: %s, error: %v", companyID, err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.tableName(signaturesTable)),
+		IndexName:                 aws.String(signatureReferenceIndex),
+	}
+
+	var signatures []*CompanySignature
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.Warnf("error querying the signatures of company: %s, error: %v", companyID, err)
+			return nil, err
+		}
+
+		var pageSignatures []*CompanySignature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &pageSignatures)
+		if err != nil {
+			log.Warnf("error unmarshalling the signatures of company: %s, error: %v", companyID, err)
+			return nil, err
+		}
+		signatures = append(signatures, pageSignatures...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return signatures, nil
+}
+
+// GetEmployeeSignatureIDs returns the IDs of the employee signatures acknowledging the CCLA of the company
+func (repo repository) GetEmployeeSignatureIDs(companyID string) ([]string, error) {
+	return repo.queryIDs(signaturesTable, signatureUserCCLACompanyIndex, "signature_user_ccla_company_id", companyID, "signature_id")
+}
+
+// GetInviteIDs returns the IDs of the company ACL invites of the company
+func (repo repository) GetInviteIDs(companyID string) ([]string, error) {
+	return repo.queryIDs(companyInvitesTable, requestedCompanyIndex, "requested_company_id", companyID, "company_invite_id")
+}
+
+// GetApprovalListRequestIDs returns the IDs of the approval list requests of the company
+func (repo repository) GetApprovalListRequestIDs(companyID string) ([]string, error) {
+	return repo.queryIDs(approvalRequestsTable, approvalRequestCompanyIndex, "company_id", companyID, "request_id")
+}
+
+// GetCLAManagerRequestIDs returns the IDs of the CLA Manager requests of the company
+func (repo repository) GetCLAManagerRequestIDs(companyID string) ([]string, error) {
+	return repo.queryIDs(claManagerRequestsTable, claManagerRequestCompanyIndex, "company_id", companyID, "request_id")
+}
+
+// GetUserIDs returns the IDs of the users associated with the company - the users table has no company index so
+// the table is scanned
+func (repo repository) GetUserIDs(companyID string) ([]string, error) {
+	filter := expression.Name("user_company_id").Equal(expression.Value(companyID))
+	projection := expression.NamesList(expression.Name("user_id"))
+	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.Warnf("error building expression for the users of company: %s, error: %v", companyID, err)
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.tableName(usersTable)),
+	}
+
+	var userIDs []string
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.Warnf("error scanning the users of company: %s, error: %v", companyID, err)
+			return nil, err
+		}
+		for _, item := range results.Items {
+			if id, ok := item["user_id"]; ok && id.S != nil {
+				userIDs = append(userIDs, *id.S)
+			}
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return userIDs, nil
+}
+
+// queryIDs returns the values of the ID attribute of the records matching the key of the index
+func (repo repository) queryIDs(table, indexName, keyName, keyValue, idName string) ([]string, error) {
+	keyCondition := expression.Key(keyName).Equal(expression.Value(keyValue))
+	projection := expression.NamesList(expression.Name(idName))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		log.Warnf("error building expression for %s: %s in table: %s, error: %v", keyName, keyValue, table, err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.tableName(table)),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var ids []string
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.Warnf("error querying %s: %s in table: %s, error: %v", keyName, keyValue, table, err)
+			return nil, err
+		}
+		for _, item := range results.Items {
+			if id, ok := item[idName]; ok && id.S != nil {
+				ids = append(ids, *id.S)
+			}
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return ids, nil
+}
+
+// UpdateSignatureCompany points the signature to the company
+func (repo repository) UpdateSignatureCompany(signatureID, companyID, companyName string) error {
+	return repo.updateAttributes(signaturesTable, "signature_id", signatureID, map[string]string{
+		"signature_reference_id":         companyID,
+		"signature_reference_name":       companyName,
+		"signature_reference_name_lower": strings.ToLower(companyName),
+	})
+}
+
+// UpdateEmployeeSignatureCompany points the employee signature to the company
+func (repo repository) UpdateEmployeeSignatureCompany(signatureID, companyID string) error {
+	return repo.updateAttributes(signaturesTable, "signature_id", signatureID, map[string]string{
+		"signature_user_ccla_company_id": companyID,
+	})
+}
+
+// UpdateInviteCompany points the company ACL invite to the company
+func (repo repository) UpdateInviteCompany(inviteID, companyID string) error {
+	return repo.updateAttributes(companyInvitesTable, "company_invite_id", inviteID, map[string]string{
+		"requested_company_id": companyID,
+	})
+}
+
+// UpdateApprovalListRequestCompany points the approval list request to the company
+func (repo repository) UpdateApprovalListRequestCompany(requestID, companyID, companyName string) error {
+	return repo.updateAttributes(approvalRequestsTable, "request_id", requestID, map[string]string{
+		"company_id":   companyID,
+		"company_name": companyName,
+	})
+}
+
+// UpdateCLAManagerRequestCompany points the CLA Manager request to the company
+func (repo repository) UpdateCLAManagerRequestCompany(requestID, companyID, companyExternalID, companyName string) error {
+	values := map[string]string{
+		"company_id":   companyID,
+		"company_name": companyName,
+	}
+	if companyExternalID != "" {
+		values["company_external_id"] = companyExternalID
+	}
+	return repo.updateAttributes(claManagerRequestsTable, "request_id", requestID, values)
+}
+
+// UpdateUserCompany points the user to the company
+func (repo repository) UpdateUserCompany(userID, companyID string) error {
+	return repo.updateAttributes(usersTable, "user_id", userID, map[string]string{
+		"user_company_id": companyID,
+	})
+}
+
+// UpdateCompanyExternalID sets the Salesforce ID of the company
+func (repo repository) UpdateCompanyExternalID(companyID, companyExternalID string) error {
+	return repo.updateAttributes(companiesTable, "company_id", companyID, map[string]string{
+		"company_external_id": companyExternalID,
+	})
+}
+
+// updateAttributes sets the string attributes and the modified date of the record
+func (repo repository) updateAttributes(table, keyName, keyValue string, values map[string]string) error {
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name("date_modified"), expression.Value(now))
+	for name, value := range values {
+		update = update.Set(expression.Name(name), expression.Value(value))
+	}
+	// only update existing records
+	condition := expression.AttributeExists(expression.Name(keyName))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.Warnf("error building expression for the update of %s: %s in table: %s, error: %v", keyName, keyValue, table, err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.tableName(table)),
+		Key: map[string]*dynamodb.AttributeValue{
+			keyName: {S: aws.String(keyValue)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		log.Warnf("unable to update %s: %s in table: %s, error: %v", keyName, keyValue, table, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package company_merge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/communitybridge/easycla/cla-backend-go/company"
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// cclaSignatureType is the signature type of the corporate signatures
+const cclaSignatureType = "ccla"
+
+// errors
+var (
+	ErrInvalidMergeInput = errors.New("a surviving company and at least one different duplicate company are required")
+	ErrMergeConflict     = errors.New("the companies can not be merged - see the conflicts of the merge preview")
+)
+
+// Service merges duplicate company records into a surviving company
+type Service interface {
+	PreviewMerge(survivorCompanyID string, duplicateCompanyIDs []string) (*models.CompanyMergePlan, error)
+	Merge(survivorCompanyID string, duplicateCompanyIDs []string, lfUsername string) (*models.CompanyMergePlan, error)
+}
+
+type service struct {
+	repo         Repository
+	companyRepo  company.IRepository
+	eventService events.Service
+}
+
+// NewService creates a new company merge service
+func NewService(repo Repository, companyRepo company.IRepository, eventService events.Service) Service {
+	return service{
+		repo:         repo,
+		companyRepo:  companyRepo,
+		eventService: eventService,
+	}
+}
+
+// PreviewMerge returns the records of the duplicate companies which are moved to the surviving company. The
+// conflicts of the plan list the reasons the companies can not be merged, for example two CCLAs for the same CLA Group.
+func (s service) PreviewMerge(survivorCompanyID string, duplicateCompanyIDs []string) (*models.CompanyMergePlan, error) {
+	survivor, duplicates, err := s.getCompanies(survivorCompanyID, duplicateCompanyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &models.CompanyMergePlan{
+		SurvivorCompanyID:   survivor.CompanyID,
+		SurvivorCompanyName: survivor.CompanyName,
+	}
+
+	acl := utils.NewStringSetFromStringArray(survivor.CompanyACL)
+	for _, duplicate := range duplicates {
+		plan.DuplicateCompanyIDs = append(plan.DuplicateCompanyIDs, duplicate.CompanyID)
+		plan.DuplicateCompanyNames = append(plan.DuplicateCompanyNames, duplicate.CompanyName)
+		for _, entry := range duplicate.CompanyACL {
+			acl.Add(entry)
+		}
+	}
+	plan.CompanyACL = acl.List()
+	plan.CompanyExternalID, plan.Conflicts = MergeCompanyExternalIDs(survivor.CompanyExternalID, duplicates)
+
+	// a CLA Group may only have one CCLA signature per company
+	cclaCompanyByProject := make(map[string]string)
+	survivorSignatures, err := s.repo.GetCompanySignatures(survivor.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	CCLASignatureConflicts(cclaCompanyByProject, survivor.CompanyID, survivorSignatures)
+
+	for _, duplicate := range duplicates {
+		signatures, err := s.repo.GetCompanySignatures(duplicate.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sig := range signatures {
+			plan.CclaSignatureIDs = append(plan.CclaSignatureIDs, sig.SignatureID)
+		}
+		plan.Conflicts = append(plan.Conflicts, CCLASignatureConflicts(cclaCompanyByProject, duplicate.CompanyID, signatures)...)
+
+		ids, err := s.repo.GetEmployeeSignatureIDs(duplicate.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		plan.EmployeeSignatureIDs = append(plan.EmployeeSignatureIDs, ids...)
+
+		ids, err = s.repo.GetInviteIDs(duplicate.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		plan.InviteIDs = append(plan.InviteIDs, ids...)
+
+		ids, err = s.repo.GetApprovalListRequestIDs(duplicate.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		plan.ApprovalListRequestIDs = append(plan.ApprovalListRequestIDs, ids...)
+
+		ids, err = s.repo.GetCLAManagerRequestIDs(duplicate.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		plan.ClaManagerRequestIDs = append(plan.ClaManagerRequestIDs, ids...)
+
+		ids, err = s.repo.GetUserIDs(duplicate.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		plan.UserIDs = append(plan.UserIDs, ids...)
+	}
+
+	return plan, nil
+}
+
+// Merge moves the records of the duplicate companies to the surviving company, deletes the duplicates and logs the
+// audit event. The duplicates are deleted last, so a merge which failed part way can be run again.
+func (s service) Merge(survivorCompanyID string, duplicateCompanyIDs []string, lfUsername string) (*models.CompanyMergePlan, error) {
+	plan, err := s.PreviewMerge(survivorCompanyID, duplicateCompanyIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Conflicts) > 0 {
+		log.Warnf("unable to merge companies %+v into company: %s - conflicts: %+v", duplicateCompanyIDs, survivorCompanyID, plan.Conflicts)
+		return plan, ErrMergeConflict
+	}
+
+	survivorID, survivorName := plan.SurvivorCompanyID, plan.SurvivorCompanyName
+	for _, id := range plan.CclaSignatureIDs {
+		if err := s.repo.UpdateSignatureCompany(id, survivorID, survivorName); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range plan.EmployeeSignatureIDs {
+		if err := s.repo.UpdateEmployeeSignatureCompany(id, survivorID); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range plan.InviteIDs {
+		if err := s.repo.UpdateInviteCompany(id, survivorID); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range plan.ApprovalListRequestIDs {
+		if err := s.repo.UpdateApprovalListRequestCompany(id, survivorID, survivorName); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range plan.ClaManagerRequestIDs {
+		if err := s.repo.UpdateCLAManagerRequestCompany(id, survivorID, plan.CompanyExternalID, survivorName); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range plan.UserIDs {
+		if err := s.repo.UpdateUserCompany(id, survivorID); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(plan.CompanyACL) > 0 {
+		if err := s.companyRepo.UpdateCompanyAccessList(survivorID, plan.CompanyACL); err != nil {
+			return nil, err
+		}
+	}
+	if plan.CompanyExternalID != "" {
+		if err := s.repo.UpdateCompanyExternalID(survivorID, plan.CompanyExternalID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, duplicateID := range plan.DuplicateCompanyIDs {
+		if err := s.companyRepo.DeleteCompanyByID(duplicateID); err != nil {
+			log.Warnf("unable to delete the merged company: %s, error: %+v", duplicateID, err)
+			return nil, err
+		}
+	}
+	plan.Merged = true
+
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType:  events.CompanyMerged,
+		CompanyID:  survivorID,
+		LfUsername: lfUsername,
+		EventData: &events.CompanyMergedEventData{
+			DuplicateCompanyIDs:   plan.DuplicateCompanyIDs,
+			DuplicateCompanyNames: plan.DuplicateCompanyNames,
+			UpdatedRecordsCount: len(plan.CclaSignatureIDs) + len(plan.EmployeeSignatureIDs) + len(plan.InviteIDs) +
+				len(plan.ApprovalListRequestIDs) + len(plan.ClaManagerRequestIDs) + len(plan.UserIDs),
+		},
+	})
+
+	return plan, nil
+}
+
+// MergeCompanyExternalIDs returns the Salesforce ID of the merged company - the companies may only reference a single
+// Salesforce organization, a conflict is returned for each duplicate with another Salesforce ID
+func MergeCompanyExternalIDs(survivorExternalID string, duplicates []*v1Models.Company) (string, []string) {
+	externalID := survivorExternalID
+	var conflicts []string
+	for _, duplicate := range duplicates {
+		if duplicate.CompanyExternalID == "" {
+			continue
+		}
+		if externalID == "" {
+			externalID = duplicate.CompanyExternalID
+		} else if externalID != duplicate.CompanyExternalID {
+			conflicts = append(conflicts, fmt.Sprintf("company %s has the Salesforce ID %s, expecting %s",
+				duplicate.CompanyID, duplicate.CompanyExternalID, externalID))
+		}
+	}
+	return externalID, conflicts
+}
+
+// CCLASignatureConflicts records the CLA Groups of the CCLA signatures of the company in cclaCompanyByProject and
+// returns a conflict for each CLA Group which already has a CCLA signature of another company
+func CCLASignatureConflicts(cclaCompanyByProject map[string]string, companyID string, signatures []*CompanySignature) []string {
+	var conflicts []string
+	for _, sig := range signatures {
+		if sig.SignatureType != cclaSignatureType {
+			continue
+		}
+		if otherCompanyID, ok := cclaCompanyByProject[sig.SignatureProjectID]; ok {
+			if otherCompanyID != companyID {
+				conflicts = append(conflicts, fmt.Sprintf("companies %s and %s both have a CCLA signature for CLA Group %s",
+					otherCompanyID, companyID, sig.SignatureProjectID))
+			}
+			continue
+		}
+		cclaCompanyByProject[sig.SignatureProjectID] = companyID
+	}
+	return conflicts
+}
+
+// getCompanies loads the surviving company and the duplicate companies
+func (s service) getCompanies(survivorCompanyID string, duplicateCompanyIDs []string) (*v1Models.Company, []*v1Models.Company, error) {
+	if survivorCompanyID == "" || len(duplicateCompanyIDs) == 0 {
+		return nil, nil, ErrInvalidMergeInput
+	}
+	seen := utils.NewStringSet()
+	seen.Add(survivorCompanyID)
+	for _, id := range duplicateCompanyIDs {
+		if id == "" || seen.Include(id) {
+			return nil, nil, ErrInvalidMergeInput
+		}
+		seen.Add(id)
+	}
+
+	survivor, err := s.companyRepo.GetCompany(survivorCompanyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var duplicates []*v1Models.Company
+	for _, id := range duplicateCompanyIDs {
+		duplicate, err := s.companyRepo.GetCompany(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+	return survivor, duplicates, nil
+}
@@ -0,0 +1,81 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package notifications
+
+import (
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/notifications"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Notifications "github.com/communitybridge/easycla/cla-backend-go/notifications"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service v1Notifications.Service) {
+	api.NotificationsGetNotificationPreferenceHandler = notifications.GetNotificationPreferenceHandlerFunc(
+		func(params notifications.GetNotificationPreferenceParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if authUser.Email == "" {
+				return notifications.NewGetNotificationPreferenceBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: "EasyCLA - 400 Bad Request - the email of the user is missing",
+				})
+			}
+
+			preference, err := service.GetPreference(authUser.Email)
+			if err != nil {
+				log.Warnf("unable to load the notification preference of user: %s, error: %+v", authUser.UserName, err)
+				return notifications.NewGetNotificationPreferenceBadRequest().WithPayload(errorResponse(err))
+			}
+			return notifications.NewGetNotificationPreferenceOK().WithPayload(v2NotificationPreference(preference))
+		})
+
+	api.NotificationsUpdateNotificationPreferenceHandler = notifications.UpdateNotificationPreferenceHandlerFunc(
+		func(params notifications.UpdateNotificationPreferenceParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if authUser.Email == "" {
+				return notifications.NewUpdateNotificationPreferenceBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: "EasyCLA - 400 Bad Request - the email of the user is missing",
+				})
+			}
+
+			preference, err := service.UpdatePreference(authUser.Email, params.Body.Frequency)
+			if err != nil {
+				log.Warnf("unable to update the notification preference of user: %s, error: %+v", authUser.UserName, err)
+				return notifications.NewUpdateNotificationPreferenceBadRequest().WithPayload(errorResponse(err))
+			}
+			return notifications.NewUpdateNotificationPreferenceOK().WithPayload(v2NotificationPreference(preference))
+		})
+}
+
+// v2NotificationPreference converts the stored preference into the response model
+func v2NotificationPreference(preference *v1Notifications.Preference) *models.NotificationPreference {
+	return &models.NotificationPreference{
+		UserEmail:    preference.UserEmail,
+		Frequency:    preference.Frequency,
+		DateModified: preference.DateModified,
+	}
+}
+
+type codedResponse interface {
+	Code() string
+}
+
+func errorResponse(err error) *models.ErrorResponse {
+	code := ""
+	if e, ok := err.(codedResponse); ok {
+		code = e.Code()
+	}
+
+	e := models.ErrorResponse{
+		Code:    code,
+		Message: err.Error(),
+	}
+
+	return &e
+}
@@ -186,6 +186,15 @@ func Configure(api *operations.EasyclaAPI, service v1Project.Service, v2Service
 			})
 		}
 
+		if projectParams.Body.ProjectResignDeadline != "" {
+			if _, parseErr := utils.ParseDateTime(projectParams.Body.ProjectResignDeadline); parseErr != nil {
+				return project.NewUpdateProjectBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: fmt.Sprintf("EasyCLA - 400 Bad Request - invalid resign deadline: %s", projectParams.Body.ProjectResignDeadline),
+				})
+			}
+		}
+
 		in, err := v1ProjectModel(&projectParams.Body)
 		if err != nil {
 			return project.NewUpdateProjectInternalServerError().WithPayload(errorResponse(err))
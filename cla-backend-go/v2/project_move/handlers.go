@@ -0,0 +1,79 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package project_move
+
+import (
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/cla_group"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service, v1ProjectService v1Project.Service) {
+	api.ClaGroupMoveProjectToClaGroupHandler = cla_group.MoveProjectToClaGroupHandlerFunc(
+		func(params cla_group.MoveProjectToClaGroupParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			if params.MoveInput == nil || params.MoveInput.ProjectSfid == nil {
+				return cla_group.NewMoveProjectToClaGroupBadRequest().WithPayload(&models.ErrorResponse{
+					Code:    "400",
+					Message: "EasyCLA - 400 Bad Request - the project_sfid of the project to move is required",
+				})
+			}
+			projectSFID := *params.MoveInput.ProjectSfid
+
+			cg, err := v1ProjectService.GetCLAGroupByID(params.ClaGroupID)
+			if err != nil {
+				if err == v1Project.ErrProjectDoesNotExist {
+					return cla_group.NewMoveProjectToClaGroupNotFound().WithPayload(&models.ErrorResponse{
+						Code: "404",
+						Message: fmt.Sprintf("EasyCLA - 404 Not Found - cla_group %s not found",
+							params.ClaGroupID),
+					})
+				}
+				return cla_group.NewMoveProjectToClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
+					Code: "500",
+					Message: fmt.Sprintf("EasyCLA - 500 Internal server error - unable to lookup CLA Group by ID, error = %+v",
+						err),
+				})
+			}
+			// the project must belong to the foundation of the cla group, which is checked by the move
+			if !utils.IsUserAuthorizedForProject(authUser, cg.FoundationSFID) {
+				return cla_group.NewMoveProjectToClaGroupForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to MoveProjectToCLAGroup with Project scope of %s",
+						authUser.UserName, cg.FoundationSFID),
+				})
+			}
+
+			plan, err := service.MoveProject(projectSFID, params.ClaGroupID, params.MoveInput.DryRun, authUser.UserName)
+			if err != nil {
+				log.Warnf("unable to move project: %s to cla group: %s, error: %+v", projectSFID, params.ClaGroupID, err)
+				switch err {
+				case projects_cla_groups.ErrProjectNotAssociatedWithClaGroup:
+					return cla_group.NewMoveProjectToClaGroupNotFound().WithPayload(&models.ErrorResponse{
+						Code:    "404",
+						Message: fmt.Sprintf("EasyCLA - 404 Not Found - project %s is not associated with a cla_group", projectSFID),
+					})
+				case ErrSameCLAGroup, ErrDifferentFoundation:
+					return cla_group.NewMoveProjectToClaGroupBadRequest().WithPayload(&models.ErrorResponse{
+						Code:    "400",
+						Message: fmt.Sprintf("EasyCLA - 400 Bad Request - %s", err.Error()),
+					})
+				}
+				return cla_group.NewMoveProjectToClaGroupInternalServerError().WithPayload(&models.ErrorResponse{
+					Code:    "500",
+					Message: fmt.Sprintf("EasyCLA - 500 Internal server error - error = %s", err.Error()),
+				})
+			}
+			return cla_group.NewMoveProjectToClaGroupOK().WithPayload(plan)
+		})
+}
@@ -0,0 +1,220 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package project_move
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// the tables and indexes holding references to the cla group of a project
+const (
+	signaturesTable        = "signatures"
+	projectsTable          = "projects"
+	repositoriesTable      = "repositories"
+	gerritInstancesTable   = "gerrit-instances"
+	projectsCLAGroupsTable = "projects-cla-groups"
+
+	signatureProjectIndex = "project-signature-index"
+)
+
+// CLAGroupSignature is the part of a signature record needed to check whether a signature of one cla group has an
+// equivalent signature in another cla group
+type CLAGroupSignature struct {
+	SignatureID            string `dynamodbav:"signature_id"`
+	SignatureReferenceID   string `dynamodbav:"signature_reference_id"`
+	SignatureReferenceName string `dynamodbav:"signature_reference_name"`
+	SignatureReferenceType string `dynamodbav:"signature_reference_type"`
+	SignatureUserCompanyID string `dynamodbav:"signature_user_ccla_company_id"`
+}
+
+// Repository reads the signatures of a cla group and repoints the records of a project to another cla group
+type Repository interface {
+	GetCLAGroupSignatures(claGroupID string) ([]*CLAGroupSignature, error)
+
+	MoveRepositoryCLAGroup(repositoryID, fromCLAGroupID, toCLAGroupID string, rootProject bool) error
+	UpdateGerritCLAGroup(gerritID, claGroupID string) error
+	UpdateProjectCLAGroup(projectSFID, claGroupID, claGroupName string) error
+}
+
+type repository struct {
+	stage          string
+	dynamoDBClient *dynamodb.DynamoDB
+}
+
+// NewRepository creates a new project move repository instance
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return repository{
+		stage:          stage,
+		dynamoDBClient: dynamodb.New(awsSession),
+	}
+}
+
+func (repo repository) tableName(table string) string {
+	return fmt.Sprintf("cla-%s-%s", repo.stage, table)
+}
+
+// GetCLAGroupSignatures returns the signed and approved signatures of the cla group
+func (repo repository) GetCLAGroupSignatures(claGroupID string) ([]*CLAGroupSignature, error) {
+	keyCondition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID))
+	filter := expression.Name("signature_signed").Equal(expression.Value(aws.Bool(true))).
+		And(expression.Name("signature_approved").Equal(expression.Value(aws.Bool(true))))
+	projection := expression.NamesList(expression.Name("signature_id"), expression.Name("signature_reference_id"),
+		expression.Name("signature_reference_name"), expression.Name("signature_reference_type"), expression.Name("signature_user_ccla_company_id"))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithFilter(filter).WithProjection(projection).Build()
+	if err != nil {
+		log.Warnf("error building expression for the signatures of cla group: %s, error: %v", claGroupID, err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(repo.tableName(signaturesTable)),
+		IndexName:                 aws.String(signatureProjectIndex),
+	}
+
+	var signatures []*CLAGroupSignature
+	for {
+		results, err := repo.dynamoDBClient.Query(queryInput)
+		if err != nil {
+			log.Warnf("error querying the signatures of cla group: %s, error: %v", claGroupID, err)
+			return nil, err
+		}
+
+		var pageSignatures []*CLAGroupSignature
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &pageSignatures)
+		if err != nil {
+			log.Warnf("error unmarshalling the signatures of cla group: %s, error: %v", claGroupID, err)
+			return nil, err
+		}
+		signatures = append(signatures, pageSignatures...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+	return signatures, nil
+}
+
+// MoveRepositoryCLAGroup points the github repository of the cla group to the other cla group. The repository of a
+// root project is moved from the root_project_repositories_count of the cla group to the other cla group in the same
+// transaction, so the counts stay in line with the repositories when a move fails part way and is run again.
+func (repo repository) MoveRepositoryCLAGroup(repositoryID, fromCLAGroupID, toCLAGroupID string, rootProject bool) error {
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name("repository_project_id"), expression.Value(toCLAGroupID)).
+		Set(expression.Name("date_modified"), expression.Value(now))
+	// only move the repository while it is still associated with the cla group
+	condition := expression.Name("repository_project_id").Equal(expression.Value(fromCLAGroupID))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.Warnf("error building expression for the move of repository: %s, error: %v", repositoryID, err)
+		return err
+	}
+	transactItems := []*dynamodb.TransactWriteItem{
+		{
+			Update: &dynamodb.Update{
+				TableName: aws.String(repo.tableName(repositoriesTable)),
+				Key: map[string]*dynamodb.AttributeValue{
+					"repository_id": {S: aws.String(repositoryID)},
+				},
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+				UpdateExpression:          expr.Update(),
+				ConditionExpression:       expr.Condition(),
+			},
+		},
+	}
+
+	if rootProject {
+		for claGroupID, diff := range map[string]int64{fromCLAGroupID: -1, toCLAGroupID: 1} {
+			countExpr, err := expression.NewBuilder().WithUpdate(
+				expression.Add(expression.Name("root_project_repositories_count"), expression.Value(diff))).Build()
+			if err != nil {
+				log.Warnf("error building expression for the repositories count of cla group: %s, error: %v", claGroupID, err)
+				return err
+			}
+			transactItems = append(transactItems, &dynamodb.TransactWriteItem{
+				Update: &dynamodb.Update{
+					TableName: aws.String(repo.tableName(projectsTable)),
+					Key: map[string]*dynamodb.AttributeValue{
+						"project_id": {S: aws.String(claGroupID)},
+					},
+					ExpressionAttributeNames:  countExpr.Names(),
+					ExpressionAttributeValues: countExpr.Values(),
+					UpdateExpression:          countExpr.Update(),
+				},
+			})
+		}
+	}
+
+	_, err = repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		log.Warnf("unable to move repository: %s from cla group: %s to cla group: %s, error: %v",
+			repositoryID, fromCLAGroupID, toCLAGroupID, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateGerritCLAGroup points the gerrit instance to the cla group
+func (repo repository) UpdateGerritCLAGroup(gerritID, claGroupID string) error {
+	return repo.updateAttributes(gerritInstancesTable, "gerrit_id", gerritID, map[string]string{
+		"project_id": claGroupID,
+	})
+}
+
+// UpdateProjectCLAGroup points the project to the cla group - the record is updated in place, so the repositories
+// count of the project is kept and the CLA service of the project stays enabled
+func (repo repository) UpdateProjectCLAGroup(projectSFID, claGroupID, claGroupName string) error {
+	return repo.updateAttributes(projectsCLAGroupsTable, "project_sfid", projectSFID, map[string]string{
+		"cla_group_id":   claGroupID,
+		"cla_group_name": claGroupName,
+	})
+}
+
+// updateAttributes sets the string attributes and the modified date of the record
+func (repo repository) updateAttributes(table, keyName, keyValue string, values map[string]string) error {
+	_, now := utils.CurrentTime()
+	update := expression.Set(expression.Name("date_modified"), expression.Value(now))
+	for name, value := range values {
+		update = update.Set(expression.Name(name), expression.Value(value))
+	}
+	// only update existing records
+	condition := expression.AttributeExists(expression.Name(keyName))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		log.Warnf("error building expression for the update of %s: %s in table: %s, error: %v", keyName, keyValue, table, err)
+		return err
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		TableName: aws.String(repo.tableName(table)),
+		Key: map[string]*dynamodb.AttributeValue{
+			keyName: {S: aws.String(keyValue)},
+		},
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+	})
+	if err != nil {
+		log.Warnf("unable to update %s: %s in table: %s, error: %v", keyName, keyValue, table, err)
+		return err
+	}
+	return nil
+}
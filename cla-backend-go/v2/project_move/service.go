@@ -0,0 +1,241 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package project_move
+
+import (
+	"errors"
+
+	"github.com/communitybridge/easycla/cla-backend-go/events"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gerrits"
+	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	"github.com/communitybridge/easycla/cla-backend-go/project"
+	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/communitybridge/easycla/cla-backend-go/repositories"
+	v2ProjectService "github.com/communitybridge/easycla/cla-backend-go/v2/project-service"
+	"github.com/sirupsen/logrus"
+)
+
+// the types of the signatures in the move plan
+const (
+	iclaSignatureType = "icla"
+	cclaSignatureType = "ccla"
+	eclaSignatureType = "ecla"
+)
+
+// errors
+var (
+	ErrSameCLAGroup        = errors.New("the project is already associated with the cla group")
+	ErrDifferentFoundation = errors.New("the project and the cla group belong to different foundations")
+)
+
+// Service moves a project from its cla group to another cla group
+type Service interface {
+	MoveProject(projectSFID, claGroupID string, dryRun bool, lfUsername string) (*models.ProjectMovePlan, error)
+}
+
+type service struct {
+	repo                  Repository
+	projectsClaGroupsRepo projects_cla_groups.Repository
+	projectRepo           project.ProjectRepository
+	repositoriesRepo      repositories.Repository
+	gerritsRepo           gerrits.Repository
+	eventService          events.Service
+}
+
+// NewService creates a new project move service
+func NewService(repo Repository, projectsClaGroupsRepo projects_cla_groups.Repository, projectRepo project.ProjectRepository,
+	repositoriesRepo repositories.Repository, gerritsRepo gerrits.Repository, eventService events.Service) Service {
+	return service{
+		repo:                  repo,
+		projectsClaGroupsRepo: projectsClaGroupsRepo,
+		projectRepo:           projectRepo,
+		repositoriesRepo:      repositoriesRepo,
+		gerritsRepo:           gerritsRepo,
+		eventService:          eventService,
+	}
+}
+
+// MoveProject moves the project, its github repositories and its gerrit instances to the cla group and logs the move
+// on both cla groups. The uncovered signatures of the plan are the signatures of the current cla group without an
+// equivalent signature in the cla group - the contributors they cover need to sign the cla group after the move.
+// With dryRun set only the plan is returned. The project association is updated last, so a move which failed part
+// way can be run again. The metrics of the cla groups are recalculated from the association by the metrics job.
+func (s service) MoveProject(projectSFID, claGroupID string, dryRun bool, lfUsername string) (*models.ProjectMovePlan, error) {
+	f := logrus.Fields{"function": "MoveProject", "project_sfid": projectSFID, "cla_group_id": claGroupID, "dry_run": dryRun}
+	plan, err := s.planMove(projectSFID, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+	plan.DryRun = dryRun
+	if dryRun {
+		return plan, nil
+	}
+
+	// the repositories of a root project are counted on the cla group, the repositories of other projects on the
+	// project association which is moved as a whole
+	psc := v2ProjectService.GetClient()
+	projectDetails, err := psc.GetProject(projectSFID)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to load the project, error: %+v", err)
+		return nil, err
+	}
+
+	rootProject := projectDetails.Parent == ""
+	for _, repositoryID := range plan.MovedRepositories {
+		if err := s.repo.MoveRepositoryCLAGroup(repositoryID, plan.FromClaGroupID, plan.ToClaGroupID, rootProject); err != nil {
+			return nil, err
+		}
+	}
+	for _, gerritID := range plan.MovedGerritInstances {
+		if err := s.repo.UpdateGerritCLAGroup(gerritID, plan.ToClaGroupID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.UpdateProjectCLAGroup(projectSFID, plan.ToClaGroupID, plan.ToClaGroupName); err != nil {
+		log.WithFields(f).Warnf("unable to associate the project with the cla group, error: %+v", err)
+		return nil, err
+	}
+	plan.Moved = true
+
+	eventData := &events.CLAGroupProjectMovedEventData{
+		ProjectSFID:              plan.ProjectSfid,
+		ProjectName:              plan.ProjectName,
+		FromCLAGroupID:           plan.FromClaGroupID,
+		FromCLAGroupName:         plan.FromClaGroupName,
+		ToCLAGroupID:             plan.ToClaGroupID,
+		ToCLAGroupName:           plan.ToClaGroupName,
+		RepositoriesCount:        len(plan.MovedRepositories),
+		GerritInstancesCount:     len(plan.MovedGerritInstances),
+		UncoveredSignaturesCount: len(plan.UncoveredSignatures),
+	}
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType:  events.CLAGroupProjectMovedOut,
+		ProjectID:  plan.FromClaGroupID,
+		LfUsername: lfUsername,
+		EventData:  eventData,
+	})
+	s.eventService.LogEvent(&events.LogEventArgs{
+		EventType:  events.CLAGroupProjectMovedIn,
+		ProjectID:  plan.ToClaGroupID,
+		LfUsername: lfUsername,
+		EventData:  eventData,
+	})
+
+	return plan, nil
+}
+
+// planMove returns the records of the project which are moved to the cla group and the signatures of the current
+// cla group which do not cover the contributors of the project after the move
+func (s service) planMove(projectSFID, claGroupID string) (*models.ProjectMovePlan, error) {
+	projectCLAGroup, err := s.projectsClaGroupsRepo.GetClaGroupIDForProject(projectSFID)
+	if err != nil {
+		return nil, err
+	}
+	if projectCLAGroup.ClaGroupID == claGroupID {
+		return nil, ErrSameCLAGroup
+	}
+	claGroup, err := s.projectRepo.GetCLAGroupByID(claGroupID, project.DontLoadRepoDetails)
+	if err != nil {
+		return nil, err
+	}
+	if claGroup.FoundationSFID != projectCLAGroup.FoundationSFID {
+		return nil, ErrDifferentFoundation
+	}
+
+	plan := &models.ProjectMovePlan{
+		ProjectSfid:          projectSFID,
+		ProjectName:          projectCLAGroup.ProjectName,
+		FromClaGroupID:       projectCLAGroup.ClaGroupID,
+		FromClaGroupName:     projectCLAGroup.ClaGroupName,
+		ToClaGroupID:         claGroup.ProjectID,
+		ToClaGroupName:       claGroup.ProjectName,
+		MovedRepositories:    []string{},
+		MovedGerritInstances: []string{},
+		UncoveredSignatures:  []*models.UncoveredSignature{},
+	}
+
+	repos, err := s.repositoriesRepo.ListProjectRepositories("", projectSFID)
+	if err != nil {
+		return nil, err
+	}
+	for _, githubRepo := range repos.List {
+		if githubRepo.RepositoryProjectID == plan.FromClaGroupID {
+			plan.MovedRepositories = append(plan.MovedRepositories, githubRepo.RepositoryID)
+		}
+	}
+
+	gerritList, err := s.gerritsRepo.GetClaGroupGerrits(plan.FromClaGroupID, &projectSFID)
+	if err != nil {
+		return nil, err
+	}
+	for _, gerrit := range gerritList.List {
+		plan.MovedGerritInstances = append(plan.MovedGerritInstances, gerrit.GerritID)
+	}
+
+	plan.UncoveredSignatures, err = s.getUncoveredSignatures(plan.FromClaGroupID, plan.ToClaGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// getUncoveredSignatures returns the signatures of the current cla group without an equivalent signature in the new
+// cla group
+func (s service) getUncoveredSignatures(fromCLAGroupID, toCLAGroupID string) ([]*models.UncoveredSignature, error) {
+	toSignatures, err := s.repo.GetCLAGroupSignatures(toCLAGroupID)
+	if err != nil {
+		return nil, err
+	}
+	fromSignatures, err := s.repo.GetCLAGroupSignatures(fromCLAGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return UncoveredSignatures(fromSignatures, toSignatures), nil
+}
+
+// UncoveredSignatures returns the signatures of the current cla group without an equivalent signature - the same
+// type signed by the same user or company - in the signatures of the new cla group
+func UncoveredSignatures(fromSignatures, toSignatures []*CLAGroupSignature) []*models.UncoveredSignature {
+	covered := make(map[string]bool)
+	for _, sig := range toSignatures {
+		covered[SignatureCoverageKey(sig)] = true
+	}
+
+	uncovered := []*models.UncoveredSignature{}
+	for _, sig := range fromSignatures {
+		if covered[SignatureCoverageKey(sig)] {
+			continue
+		}
+		uncovered = append(uncovered, &models.UncoveredSignature{
+			SignatureID:            sig.SignatureID,
+			SignatureType:          signatureType(sig),
+			SignatureReferenceID:   sig.SignatureReferenceID,
+			SignatureReferenceName: sig.SignatureReferenceName,
+			CompanyID:              sig.SignatureUserCompanyID,
+		})
+	}
+	return uncovered
+}
+
+// signatureType returns the type of the signature - an employee acknowledgement is a user signature referencing the
+// CCLA of a company
+func signatureType(sig *CLAGroupSignature) string {
+	if sig.SignatureReferenceType == "company" {
+		return cclaSignatureType
+	}
+	if sig.SignatureUserCompanyID != "" {
+		return eclaSignatureType
+	}
+	return iclaSignatureType
+}
+
+// SignatureCoverageKey returns the key of the contributors covered by the signature
+func SignatureCoverageKey(sig *CLAGroupSignature) string {
+	key := signatureType(sig) + ":" + sig.SignatureReferenceID
+	if sig.SignatureUserCompanyID != "" {
+		key += ":" + sig.SignatureUserCompanyID
+	}
+	return key
+}
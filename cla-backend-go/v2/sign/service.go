@@ -40,6 +40,7 @@ const (
 var (
 	ErrCCLANotEnabled        = errors.New("corporate license agreement is not enabled with this project")
 	ErrTemplateNotConfigured = errors.New("cla template not configured for this project")
+	ErrCLAGroupArchived      = errors.New("the cla group of this project is archived")
 	ErrNotInOrg              error
 )
 
@@ -165,6 +166,9 @@ func (s *service) RequestCorporateSignature(lfUsername string, authorizationHead
 	if err != nil {
 		return nil, err
 	}
+	if proj.ProjectArchived {
+		return nil, ErrCLAGroupArchived
+	}
 	if !proj.ProjectCCLAEnabled {
 		return nil, ErrCCLANotEnabled
 	}
@@ -0,0 +1,244 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	v1Models "github.com/communitybridge/easycla/cla-backend-go/gen/models"
+	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/models"
+	"github.com/communitybridge/easycla/cla-backend-go/signatures"
+	"github.com/communitybridge/easycla/cla-backend-go/utils"
+)
+
+// approval list CSV columns and import modes
+const (
+	ApprovalListCsvEmailColumn          = "email"
+	ApprovalListCsvDomainColumn         = "domain"
+	ApprovalListCsvGitHubUsernameColumn = "github_username"
+	ApprovalListCsvGitHubOrgColumn      = "github_org"
+
+	ApprovalListImportModeMerge   = "merge"
+	ApprovalListImportModeReplace = "replace"
+)
+
+var approvalListImportModes = []string{
+	ApprovalListImportModeMerge,
+	ApprovalListImportModeReplace,
+}
+
+var approvalListCsvColumns = []string{
+	ApprovalListCsvEmailColumn,
+	ApprovalListCsvDomainColumn,
+	ApprovalListCsvGitHubUsernameColumn,
+	ApprovalListCsvGitHubOrgColumn,
+}
+
+// ApprovalListEntries holds the entries of an approval list CSV document by column - each column of the header row
+// has an entry, even when the column has no values
+type ApprovalListEntries map[string][]string
+
+// approvalListsByColumn returns the approval lists of the signature by CSV column
+func approvalListsByColumn(sig *v1Models.Signature) ApprovalListEntries {
+	if sig == nil {
+		return ApprovalListEntries{}
+	}
+	return ApprovalListEntries{
+		ApprovalListCsvEmailColumn:          sig.EmailApprovalList,
+		ApprovalListCsvDomainColumn:         sig.DomainApprovalList,
+		ApprovalListCsvGitHubUsernameColumn: sig.GithubUsernameApprovalList,
+		ApprovalListCsvGitHubOrgColumn:      sig.GithubOrgApprovalList,
+	}
+}
+
+// BuildApprovalListCsv returns the approval lists of the signature as a CSV document - one column per approval list
+func BuildApprovalListCsv(sig *v1Models.Signature) ([]byte, error) {
+	current := approvalListsByColumn(sig)
+	lists := make([][]string, len(approvalListCsvColumns))
+	for i, column := range approvalListCsvColumns {
+		lists[i] = current[column]
+	}
+
+	var rowCount int
+	for _, list := range lists {
+		if len(list) > rowCount {
+			rowCount = len(list)
+		}
+	}
+
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+	if err := writer.Write(approvalListCsvColumns); err != nil {
+		return nil, err
+	}
+	for i := 0; i < rowCount; i++ {
+		row := make([]string, len(lists))
+		for j, list := range lists {
+			if i < len(list) {
+				row[j] = list[i]
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return b.Bytes(), writer.Error()
+}
+
+// ParseApprovalListCsv parses and validates the approval list CSV document, returns the unique entries by column,
+// the number of data rows and the errors of each invalid row. The values already on the approval lists of the
+// signature are accepted as stored, so that a document exported with BuildApprovalListCsv can always be imported
+// back, even when it has entries saved before the values were validated.
+func ParseApprovalListCsv(reader io.Reader, sig *v1Models.Signature) (ApprovalListEntries, int64, []*models.ApprovalListRowError) {
+	current := approvalListsByColumn(sig)
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, 0, []*models.ApprovalListRowError{{Row: 1, Message: fmt.Sprintf("unable to read the header row - %v", err)}}
+	}
+
+	var rowErrors []*models.ApprovalListRowError
+	columns := make([]string, len(header))
+	for i, name := range header {
+		column := normalizeApprovalListCsvColumn(name)
+		if !utils.StringInSlice(column, approvalListCsvColumns) {
+			rowErrors = append(rowErrors, &models.ApprovalListRowError{
+				Row:     1,
+				Column:  name,
+				Message: fmt.Sprintf("unknown column - supported columns are: %s", strings.Join(approvalListCsvColumns, ", ")),
+			})
+			continue
+		}
+		columns[i] = column
+	}
+	if len(rowErrors) > 0 {
+		return nil, 0, rowErrors
+	}
+
+	entries := ApprovalListEntries{}
+	for _, column := range columns {
+		entries[column] = []string{}
+	}
+	var rowCount int64
+	for row := int64(2); ; row++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, &models.ApprovalListRowError{Row: row, Message: err.Error()})
+			continue
+		}
+		rowCount++
+
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" || i >= len(columns) {
+				continue
+			}
+			if msg, valid := validApprovalListCsvValue(columns[i], value); !valid && !signatures.ContainsFold(current[columns[i]], value) {
+				rowErrors = append(rowErrors, &models.ApprovalListRowError{Row: row, Column: columns[i], Value: value, Message: msg})
+				continue
+			}
+			if !signatures.ContainsFold(entries[columns[i]], value) {
+				entries[columns[i]] = append(entries[columns[i]], value)
+			}
+		}
+	}
+
+	return entries, rowCount, rowErrors
+}
+
+// normalizeApprovalListCsvColumn returns the column name in lower case with underscore separators, so that
+// headers such as "GitHub Username" are accepted
+func normalizeApprovalListCsvColumn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+	switch name {
+	case "email_address":
+		return ApprovalListCsvEmailColumn
+	case "github_organization":
+		return ApprovalListCsvGitHubOrgColumn
+	}
+	return name
+}
+
+// validApprovalListCsvValue validates the value using the same rules as the update approval list API
+func validApprovalListCsvValue(column, value string) (string, bool) {
+	switch column {
+	case ApprovalListCsvEmailColumn:
+		if !utils.ValidEmail(value) {
+			return "invalid email", false
+		}
+		return "", true
+	case ApprovalListCsvDomainColumn:
+		return utils.ValidDomainRule(value)
+	case ApprovalListCsvGitHubUsernameColumn:
+		return utils.ValidGitHubUsername(value)
+	case ApprovalListCsvGitHubOrgColumn:
+		return utils.ValidGitHubOrg(value)
+	}
+	return "unknown column", false
+}
+
+// validApprovalListImportMode returns true if the mode is one of the approval list import modes
+func validApprovalListImportMode(mode string) bool {
+	return utils.StringInSlice(mode, approvalListImportModes)
+}
+
+// BuildApprovalListChanges returns the changes to apply to the approval lists of the signature - in merge mode only
+// the new entries are added, in replace mode the entries missing from the document are removed as well. Only the
+// approval lists with a column in the document are replaced, the other approval lists are left unchanged.
+func BuildApprovalListChanges(sig *v1Models.Signature, entries ApprovalListEntries, mode string) *v1Models.ApprovalList {
+	replace := func(column string) bool {
+		_, present := entries[column]
+		return mode == ApprovalListImportModeReplace && present
+	}
+
+	changes := &v1Models.ApprovalList{}
+	changes.AddEmailApprovalList, changes.RemoveEmailApprovalList =
+		diffApprovalList(sig.EmailApprovalList, entries[ApprovalListCsvEmailColumn], replace(ApprovalListCsvEmailColumn))
+	changes.AddDomainApprovalList, changes.RemoveDomainApprovalList =
+		diffApprovalList(sig.DomainApprovalList, entries[ApprovalListCsvDomainColumn], replace(ApprovalListCsvDomainColumn))
+	changes.AddGithubUsernameApprovalList, changes.RemoveGithubUsernameApprovalList =
+		diffApprovalList(sig.GithubUsernameApprovalList, entries[ApprovalListCsvGitHubUsernameColumn], replace(ApprovalListCsvGitHubUsernameColumn))
+	changes.AddGithubOrgApprovalList, changes.RemoveGithubOrgApprovalList =
+		diffApprovalList(sig.GithubOrgApprovalList, entries[ApprovalListCsvGitHubOrgColumn], replace(ApprovalListCsvGitHubOrgColumn))
+	return changes
+}
+
+// diffApprovalList returns the entries to add to and remove from the current approval list - the entries missing
+// from the imported list are only removed when the approval list is replaced
+func diffApprovalList(current, imported []string, replace bool) ([]string, []string) {
+	var add, remove []string
+	for _, value := range imported {
+		if !signatures.ContainsFold(current, value) {
+			add = append(add, value)
+		}
+	}
+	if replace {
+		for _, value := range current {
+			if !signatures.ContainsFold(imported, value) {
+				remove = append(remove, value)
+			}
+		}
+	}
+	return add, remove
+}
+
+// hasApprovalListChanges returns true if the approval list changes add or remove at least one entry
+func hasApprovalListChanges(changes *v1Models.ApprovalList) bool {
+	return len(changes.AddEmailApprovalList) > 0 || len(changes.RemoveEmailApprovalList) > 0 ||
+		len(changes.AddDomainApprovalList) > 0 || len(changes.RemoveDomainApprovalList) > 0 ||
+		len(changes.AddGithubUsernameApprovalList) > 0 || len(changes.RemoveGithubUsernameApprovalList) > 0 ||
+		len(changes.AddGithubOrgApprovalList) > 0 || len(changes.RemoveGithubOrgApprovalList) > 0
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/communitybridge/easycla/cla-backend-go/approval_list"
 	"github.com/communitybridge/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/go-openapi/runtime"
@@ -35,7 +36,7 @@ import (
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, projectService project.Service, projectRepo project.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2service Service, projectClaGroupsRepo projects_cla_groups.Repository) { //nolint
+func Configure(api *operations.EasyclaAPI, projectService project.Service, projectRepo project.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2service Service, projectClaGroupsRepo projects_cla_groups.Repository, approvalListService approval_list.IService) { //nolint
 
 	// Get Signature
 	api.SignaturesGetSignatureHandler = signatures.GetSignatureHandlerFunc(func(params signatures.GetSignatureParams, authUser *auth.User) middleware.Responder {
@@ -111,11 +112,17 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 			return signatures.NewUpdateApprovalListInternalServerError().WithPayload(errorResponse(err))
 		}
 
-		// Invoke the update v1SignatureService function
-		updatedSig, updateErr := v1SignatureService.UpdateApprovalList(authUser, projectModel, companyModel, params.ClaGroupID, &v1ApprovalList)
+		// Invoke the update v1SignatureService function - in dry run mode, only preview the impact of the update
+		var updatedSig *v1Models.Signature
+		var updateErr error
+		if params.DryRun != nil && *params.DryRun {
+			updatedSig, updateErr = v1SignatureService.PreviewApprovalList(authUser, projectModel, companyModel, params.ClaGroupID, &v1ApprovalList)
+		} else {
+			updatedSig, updateErr = v1SignatureService.UpdateApprovalList(authUser, projectModel, companyModel, params.ClaGroupID, &v1ApprovalList)
+		}
 		if updateErr != nil || updatedSig == nil {
-			if err, ok := err.(*signatureService.ForbiddenError); ok {
-				return signatures.NewUpdateApprovalListForbidden().WithPayload(errorResponse(err))
+			if _, ok := updateErr.(*signatureService.ForbiddenError); ok {
+				return signatures.NewUpdateApprovalListForbidden().WithPayload(errorResponse(updateErr))
 			}
 
 			log.Warnf("unable to update signature approval list using CLA Group ID: %s", params.ClaGroupID)
@@ -132,6 +139,225 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 		return signatures.NewUpdateApprovalListOK().WithPayload(&v2Sig)
 	})
 
+	api.SignaturesDownloadApprovalListAsCSVHandler = signatures.DownloadApprovalListAsCSVHandlerFunc(func(params signatures.DownloadApprovalListAsCSVParams, authUser *auth.User) middleware.Responder {
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Must be in the Project|Organization Scope to see this
+		if !utils.IsUserAuthorizedForProjectOrganization(authUser, params.ProjectSFID, params.CompanySFID) {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to download Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanySFID)
+			log.Warn(msg)
+			return signatures.NewDownloadApprovalListAsCSVForbidden().WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewDownloadApprovalListAsCSVNotFound().WithPayload(errorResponse(compErr))
+		}
+
+		result, err := v2service.GetApprovalListCsv(params.ClaGroupID, companyModel.CompanyID)
+		if err != nil {
+			if err == ErrCclaSignatureNotFound {
+				return signatures.NewDownloadApprovalListAsCSVNotFound().WithPayload(errorResponse(err))
+			}
+			return signatures.NewDownloadApprovalListAsCSVInternalServerError().WithPayload(errorResponse(err))
+		}
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+			rw.Header().Set("Content-Type", "text/csv")
+			rw.WriteHeader(http.StatusOK)
+			_, err := rw.Write(result)
+			if err != nil {
+				log.Warnf("Error writing csv file, error: %v", err)
+			}
+		})
+	})
+
+	api.SignaturesImportApprovalListCSVHandler = signatures.ImportApprovalListCSVHandlerFunc(func(params signatures.ImportApprovalListCSVParams, authUser *auth.User) middleware.Responder {
+		defer func() {
+			if closeErr := params.File.Close(); closeErr != nil {
+				log.Warnf("unable to close the approval list CSV document, error: %+v", closeErr)
+			}
+		}()
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Must be in the Project|Organization Scope to see this
+		if !utils.IsUserAuthorizedForProjectOrganization(authUser, params.ProjectSFID, params.CompanySFID) {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to import Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanySFID)
+			log.Warn(msg)
+			return signatures.NewImportApprovalListCSVForbidden().WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewImportApprovalListCSVNotFound().WithPayload(errorResponse(compErr))
+		}
+
+		projectModel, projErr := projectService.GetCLAGroupByID(params.ClaGroupID)
+		if projErr != nil || projectModel == nil {
+			log.Warnf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			return signatures.NewImportApprovalListCSVNotFound().WithPayload(errorResponse(projErr))
+		}
+
+		result, err := v2service.ImportApprovalListCsv(authUser, projectModel, companyModel, params.File, utils.StringValue(params.Mode))
+		if err != nil {
+			if err == ErrCclaSignatureNotFound {
+				return signatures.NewImportApprovalListCSVNotFound().WithPayload(errorResponse(err))
+			}
+			if _, ok := err.(*signatureService.ForbiddenError); ok {
+				return signatures.NewImportApprovalListCSVForbidden().WithPayload(errorResponse(err))
+			}
+			if _, ok := err.(*signatureService.BadRequestError); ok {
+				return signatures.NewImportApprovalListCSVBadRequest().WithPayload(&models.ApprovalListImportResult{
+					Mode:      utils.StringValue(params.Mode),
+					Message:   err.Error(),
+					RowErrors: []*models.ApprovalListRowError{},
+				})
+			}
+			log.Warnf("unable to import the approval list using CLA Group ID: %s, error: %+v", params.ClaGroupID, err)
+			return signatures.NewImportApprovalListCSVInternalServerError().WithPayload(errorResponse(err))
+		}
+		if len(result.RowErrors) > 0 {
+			return signatures.NewImportApprovalListCSVBadRequest().WithPayload(result)
+		}
+
+		return signatures.NewImportApprovalListCSVOK().WithPayload(result)
+	})
+
+	api.SignaturesBulkProcessApprovalListRequestsHandler = signatures.BulkProcessApprovalListRequestsHandlerFunc(func(params signatures.BulkProcessApprovalListRequestsParams, authUser *auth.User) middleware.Responder {
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+
+		// Must be in the Project|Organization Scope to see this
+		if !utils.IsUserAuthorizedForProjectOrganization(authUser, params.ProjectSFID, params.CompanySFID) {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to process Project Company Approval List requests with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, params.CompanySFID)
+			log.Warn(msg)
+			return signatures.NewBulkProcessApprovalListRequestsForbidden().WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewBulkProcessApprovalListRequestsNotFound().WithPayload(errorResponse(compErr))
+		}
+
+		projectModel, projErr := projectService.GetCLAGroupByID(params.ClaGroupID)
+		if projErr != nil || projectModel == nil {
+			log.Warnf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			return signatures.NewBulkProcessApprovalListRequestsNotFound().WithPayload(errorResponse(projErr))
+		}
+
+		// Convert the v2 input parameters to a v1 model
+		v1Input := v1Models.ApprovalListRequestBulkInput{}
+		err := copier.Copy(&v1Input, params.Body)
+		if err != nil {
+			return signatures.NewBulkProcessApprovalListRequestsInternalServerError().WithPayload(errorResponse(err))
+		}
+
+		result, err := approvalListService.BulkProcessCclaWhitelistRequests(authUser, companyModel, projectModel, &v1Input)
+		if err != nil {
+			switch err {
+			case approval_list.ErrCclaSignatureNotFound:
+				return signatures.NewBulkProcessApprovalListRequestsNotFound().WithPayload(errorResponse(err))
+			case approval_list.ErrNotCLAManager:
+				return signatures.NewBulkProcessApprovalListRequestsForbidden().WithPayload(errorResponse(err))
+			}
+			log.Warnf("unable to process the approval list requests using CLA Group ID: %s, error: %+v", params.ClaGroupID, err)
+			return signatures.NewBulkProcessApprovalListRequestsBadRequest().WithPayload(errorResponse(err))
+		}
+
+		// Record the decision as a single event
+		eventData := &events.CCLAApprovalListRequestsBulkProcessedEventData{
+			Decision: result.Decision,
+			Message:  v1Input.Message,
+		}
+		for _, itemResult := range result.Results {
+			if itemResult.Status == approval_list.BulkStatusFailed {
+				eventData.FailedRequestIDs = append(eventData.FailedRequestIDs, itemResult.RequestID)
+				continue
+			}
+			eventData.ProcessedRequestIDs = append(eventData.ProcessedRequestIDs, itemResult.RequestID)
+
+			// Record the decision of each request like the individual approve and reject requests
+			requestEvent := &events.LogEventArgs{
+				EventType:    events.CCLAApprovalListRequestApproved,
+				ProjectModel: projectModel,
+				CompanyModel: companyModel,
+				LfUsername:   authUser.UserName,
+				EventData:    &events.CCLAApprovalListRequestApprovedEventData{RequestID: itemResult.RequestID},
+			}
+			if itemResult.Status == approval_list.StatusRejected {
+				requestEvent.EventType = events.CCLAApprovalListRequestRejected
+				requestEvent.EventData = &events.CCLAApprovalListRequestRejectedEventData{RequestID: itemResult.RequestID}
+			}
+			eventsService.LogEvent(requestEvent)
+		}
+		eventsService.LogEvent(&events.LogEventArgs{
+			EventType:    events.CCLAApprovalListRequestsBulkProcessed,
+			ProjectModel: projectModel,
+			CompanyModel: companyModel,
+			LfUsername:   authUser.UserName,
+			EventData:    eventData,
+		})
+
+		// Convert the v1 output model to a v2 response model
+		v2Result := models.ApprovalListRequestBulkResult{}
+		err = copier.Copy(&v2Result, result)
+		if err != nil {
+			return signatures.NewBulkProcessApprovalListRequestsInternalServerError().WithPayload(errorResponse(err))
+		}
+
+		return signatures.NewBulkProcessApprovalListRequestsOK().WithPayload(&v2Result)
+	})
+
+	api.SignaturesDetachCorporateContributorHandler = signatures.DetachCorporateContributorHandlerFunc(func(params signatures.DetachCorporateContributorParams, authUser *auth.User) middleware.Responder {
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		if authUser.UserName == "" {
+			msg := fmt.Sprintf("EasyCLA - 403 Forbidden - unknown user is not authorized to detach from the company CCLA for CLA Group ID: %s, company ID: %s",
+				params.ClaGroupID, params.CompanySFID)
+			log.Warn(msg)
+			return signatures.NewDetachCorporateContributorForbidden().WithPayload(&models.ErrorResponse{
+				Code:    "403",
+				Message: msg,
+			})
+		}
+
+		companyModel, compErr := companyService.GetCompanyByExternalID(params.CompanySFID)
+		if compErr != nil || companyModel == nil {
+			log.Warnf("unable to locate company by external company ID: %s", params.CompanySFID)
+			return signatures.NewDetachCorporateContributorNotFound().WithPayload(errorResponse(compErr))
+		}
+
+		projectModel, projErr := projectService.GetCLAGroupByID(params.ClaGroupID)
+		if projErr != nil || projectModel == nil {
+			log.Warnf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			return signatures.NewDetachCorporateContributorNotFound().WithPayload(errorResponse(projErr))
+		}
+
+		err := v1SignatureService.DetachCorporateContributor(authUser, projectModel, companyModel)
+		if err != nil {
+			if _, ok := err.(*signatureService.NotFoundError); ok {
+				return signatures.NewDetachCorporateContributorNotFound().WithPayload(errorResponse(err))
+			}
+			log.Warnf("unable to detach user: %s from company: %s CCLA for CLA Group ID: %s, error: %+v",
+				authUser.UserName, params.CompanySFID, params.ClaGroupID, err)
+			return signatures.NewDetachCorporateContributorInternalServerError().WithPayload(errorResponse(err))
+		}
+
+		return signatures.NewDetachCorporateContributorNoContent()
+	})
+
 	// Retrieve GitHub Approval Entries
 	api.SignaturesGetGitHubOrgWhitelistHandler = signatures.GetGitHubOrgWhitelistHandlerFunc(func(params signatures.GetGitHubOrgWhitelistParams, authUser *auth.User) middleware.Responder {
 		session, err := sessionStore.Get(params.HTTPRequest, github.SessionStoreKey)
@@ -517,6 +743,57 @@ func Configure(api *operations.EasyclaAPI, projectService project.Service, proje
 			return signatures.NewListClaGroupCorporateContributorsOK().WithPayload(result)
 		})
 
+	api.SignaturesListClaGroupOutdatedSignaturesHandler = signatures.ListClaGroupOutdatedSignaturesHandlerFunc(
+		func(params signatures.ListClaGroupOutdatedSignaturesParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			claGroupModel, err := projectService.GetCLAGroupByID(params.ClaGroupID)
+			if err != nil {
+				if err == project.ErrProjectDoesNotExist {
+					return signatures.NewListClaGroupOutdatedSignaturesNotFound().WithPayload(errorResponse(err))
+				}
+				return signatures.NewListClaGroupOutdatedSignaturesInternalServerError().WithPayload(errorResponse(err))
+			}
+			if !utils.IsUserAuthorizedForProject(authUser, claGroupModel.FoundationSFID) {
+				return signatures.NewListClaGroupOutdatedSignaturesForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ListClaGroupOutdatedSignatures with project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+			result, err := v2service.GetClaGroupOutdatedSignatures(claGroupModel, params.SignatureType)
+			if err != nil {
+				return signatures.NewListClaGroupOutdatedSignaturesInternalServerError().WithPayload(errorResponse(err))
+			}
+			return signatures.NewListClaGroupOutdatedSignaturesOK().WithPayload(result)
+		})
+
+	api.SignaturesExplainClaGroupCoverageHandler = signatures.ExplainClaGroupCoverageHandlerFunc(
+		func(params signatures.ExplainClaGroupCoverageParams, authUser *auth.User) middleware.Responder {
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			claGroupModel, err := projectService.GetCLAGroupByID(params.ClaGroupID)
+			if err != nil {
+				if err == project.ErrProjectDoesNotExist {
+					return signatures.NewExplainClaGroupCoverageNotFound().WithPayload(errorResponse(err))
+				}
+				return signatures.NewExplainClaGroupCoverageInternalServerError().WithPayload(errorResponse(err))
+			}
+			if !utils.IsUserAuthorizedForProject(authUser, claGroupModel.FoundationSFID) {
+				return signatures.NewExplainClaGroupCoverageForbidden().WithPayload(&models.ErrorResponse{
+					Code: "403",
+					Message: fmt.Sprintf("EasyCLA - 403 Forbidden - user %s does not have access to ExplainClaGroupCoverage with project scope of %s",
+						authUser.UserName, claGroupModel.FoundationSFID),
+				})
+			}
+			result, err := v2service.ExplainClaGroupCoverage(claGroupModel, params.GithubUsername, params.GithubID, params.Email)
+			if err != nil {
+				if _, ok := err.(*signatureService.BadRequestError); ok {
+					return signatures.NewExplainClaGroupCoverageBadRequest().WithPayload(errorResponse(err))
+				}
+				return signatures.NewExplainClaGroupCoverageInternalServerError().WithPayload(errorResponse(err))
+			}
+			return signatures.NewExplainClaGroupCoverageOK().WithPayload(result)
+		})
+
 	api.SignaturesGetSignatureSignedDocumentHandler = signatures.GetSignatureSignedDocumentHandlerFunc(func(params signatures.GetSignatureSignedDocumentParams, authUser *auth.User) middleware.Responder {
 		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
 
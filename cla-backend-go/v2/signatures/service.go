@@ -4,6 +4,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
 
 	"github.com/aws/aws-sdk-go/aws"
 
@@ -29,6 +34,11 @@ const (
 	ClaSignatureType  = "cla"
 )
 
+// errors
+var (
+	ErrCclaSignatureNotFound = errors.New("corporate signature not found")
+)
+
 type service struct {
 	v1ProjectService      project.Service
 	v1CompanyService      company.IService
@@ -46,6 +56,10 @@ type Service interface {
 	GetSignedDocument(signatureID string) (*models.SignedDocument, error)
 	GetSignedIclaZipPdf(claGroupID string) (*models.URLObject, error)
 	GetSignedCclaZipPdf(claGroupID string) (*models.URLObject, error)
+	GetClaGroupOutdatedSignatures(claGroupModel *v1Models.Project, signatureType *string) (*models.OutdatedSignatureList, error)
+	ExplainClaGroupCoverage(claGroupModel *v1Models.Project, githubUsername, githubID, email *string) (*models.CoverageExplanation, error)
+	GetApprovalListCsv(claGroupID, companyID string) ([]byte, error)
+	ImportApprovalListCsv(authUser *auth.User, claGroupModel *v1Models.Project, companyModel *v1Models.Company, csvReader io.Reader, mode string) (*models.ApprovalListImportResult, error)
 }
 
 // NewService creates instance of v2 signature service
@@ -221,3 +235,142 @@ func (s service) GetClaGroupCorporateContributors(claGroupID string, companySFID
 	}
 	return &resp, nil
 }
+
+func (s service) GetClaGroupOutdatedSignatures(claGroupModel *v1Models.Project, signatureType *string) (*models.OutdatedSignatureList, error) {
+	var sigType string
+	if signatureType != nil {
+		sigType = *signatureType
+	}
+	result, err := s.v1SignatureService.GetClaGroupOutdatedSignatures(claGroupModel, sigType)
+	if err != nil {
+		return nil, err
+	}
+
+	resignOverdue := signatures.IsResignOverdue(claGroupModel, time.Now())
+	resp := &models.OutdatedSignatureList{
+		ClaGroupID:       claGroupModel.ProjectID,
+		IclaMajorVersion: int64(signatures.GetClaGroupDocumentMajorVersion(claGroupModel, signatures.ICLA)),
+		CclaMajorVersion: int64(signatures.GetClaGroupDocumentMajorVersion(claGroupModel, signatures.CCLA)),
+		ResignPolicy:     claGroupModel.ProjectResignPolicy,
+		ResignDeadline:   claGroupModel.ProjectResignDeadline,
+		List:             make([]*models.OutdatedSignature, 0, len(result)),
+	}
+	for _, sig := range result {
+		resp.List = append(resp.List, &models.OutdatedSignature{
+			SignatureID:           sig.SignatureID,
+			SignatureType:         signatures.GetSignatureClass(sig),
+			SignatureMajorVersion: sig.SignatureMajorVersion,
+			SignatureMinorVersion: sig.SignatureMinorVersion,
+			SignatureReferenceID:  sig.SignatureReferenceID,
+			CompanyName:           sig.CompanyName,
+			UserName:              sig.UserName,
+			LfUsername:            sig.UserLFID,
+			GithubUsername:        sig.UserGHUsername,
+			SignedOn:              sig.SignedOn,
+			ResignOverdue:         resignOverdue,
+		})
+	}
+	return resp, nil
+}
+
+// ExplainClaGroupCoverage returns whether the contributor identity is covered by the CLA Group and why
+func (s *service) ExplainClaGroupCoverage(claGroupModel *v1Models.Project, githubUsername, githubID, email *string) (*models.CoverageExplanation, error) {
+	v1Explanation, err := s.v1SignatureService.ExplainCoverage(claGroupModel,
+		utils.StringValue(githubUsername), utils.StringValue(githubID), utils.StringValue(email))
+	if err != nil {
+		return nil, err
+	}
+	var explanation models.CoverageExplanation
+	err = copier.Copy(&explanation, v1Explanation)
+	if err != nil {
+		return nil, err
+	}
+	return &explanation, nil
+}
+
+// GetApprovalListCsv returns the approval lists of the company CCLA for the CLA Group as a CSV document
+func (s *service) GetApprovalListCsv(claGroupID, companyID string) ([]byte, error) {
+	signed, approved := true, true
+	sig, err := s.v1SignatureService.GetProjectCompanySignature(companyID, claGroupID, &signed, &approved, nil, aws.Int64(HugePageSize))
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		return nil, ErrCclaSignatureNotFound
+	}
+	return BuildApprovalListCsv(sig)
+}
+
+// ImportApprovalListCsv merges the entries of the CSV document into the approval lists of the company CCLA, or
+// replaces the approval lists with the entries, using the update approval list service so that the changes are
+// logged and notified the same way - nothing is updated when the document has invalid rows
+func (s *service) ImportApprovalListCsv(authUser *auth.User, claGroupModel *v1Models.Project, companyModel *v1Models.Company, csvReader io.Reader, mode string) (*models.ApprovalListImportResult, error) {
+	f := logrus.Fields{
+		"functionName": "ImportApprovalListCsv",
+		"claGroupID":   claGroupModel.ProjectID,
+		"companyID":    companyModel.CompanyID,
+		"mode":         mode,
+	}
+
+	if mode == "" {
+		mode = ApprovalListImportModeMerge
+	}
+	if !validApprovalListImportMode(mode) {
+		return nil, signatures.NewBadRequestError(fmt.Sprintf("unknown approval list import mode: %s - supported modes are: %s",
+			mode, strings.Join(approvalListImportModes, ", ")))
+	}
+	result := &models.ApprovalListImportResult{
+		Mode:      mode,
+		RowErrors: []*models.ApprovalListRowError{},
+	}
+
+	signed, approved := true, true
+	sig, err := s.v1SignatureService.GetProjectCompanySignature(companyModel.CompanyID, claGroupModel.ProjectID, &signed, &approved, nil, aws.Int64(HugePageSize))
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		return nil, ErrCclaSignatureNotFound
+	}
+
+	entries, rowCount, rowErrors := ParseApprovalListCsv(csvReader, sig)
+	result.RowCount = rowCount
+	if len(rowErrors) > 0 {
+		log.WithFields(f).Debugf("approval list document has %d invalid rows", len(rowErrors))
+		result.RowErrors = rowErrors
+		result.Message = fmt.Sprintf("approval list not updated - the document has %d errors", len(rowErrors))
+		return result, nil
+	}
+
+	changes := BuildApprovalListChanges(sig, entries, mode)
+	result.Changes = &models.ApprovalList{}
+	err = copier.Copy(result.Changes, changes)
+	if err != nil {
+		return nil, err
+	}
+	if !hasApprovalListChanges(changes) {
+		result.Message = "approval list already up to date"
+		result.Signature, err = v2SignatureModel(sig)
+		return result, err
+	}
+
+	updatedSig, err := s.v1SignatureService.UpdateApprovalList(authUser, claGroupModel, companyModel, claGroupModel.ProjectID, changes)
+	if err != nil {
+		log.WithFields(f).Warnf("unable to update the approval list, error: %+v", err)
+		return nil, err
+	}
+
+	result.Message = "approval list updated"
+	result.Signature, err = v2SignatureModel(updatedSig)
+	return result, err
+}
+
+// v2SignatureModel converts the v1 signature model to a v2 signature model
+func v2SignatureModel(sig *v1Models.Signature) (*models.Signature, error) {
+	var v2Sig models.Signature
+	err := copier.Copy(&v2Sig, sig)
+	if err != nil {
+		return nil, err
+	}
+	return &v2Sig, nil
+}
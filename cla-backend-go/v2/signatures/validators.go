@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/signatures"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
@@ -56,16 +57,16 @@ func entriesAreValid(params signatures.UpdateApprovalListParams) (string, bool)
 		}
 	}
 
-	// Ensure the domains are valid
+	// Ensure the domains are valid - wildcard and exclusion rules are described in utils/domain_rules.go
 	for _, domain := range params.Body.AddDomainApprovalList {
-		msg, valid := utils.ValidDomain(domain)
+		msg, valid := utils.ValidDomainRule(domain)
 		if !valid {
 			isValid = false
 			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid add approval list domain %s - %s", domain, msg))
 		}
 	}
 	for _, domain := range params.Body.RemoveDomainApprovalList {
-		msg, valid := utils.ValidDomain(domain)
+		msg, valid := utils.ValidDomainRule(domain)
 		if !valid {
 			isValid = false
 			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid remove approval list domain %s - %s", domain, msg))
@@ -104,5 +105,17 @@ func entriesAreValid(params signatures.UpdateApprovalListParams) (string, bool)
 		}
 	}
 
+	// Ensure the expiry date is valid and in the future
+	if params.Body.ExpiryDate != "" {
+		expiryDate, err := utils.ParseDateTime(params.Body.ExpiryDate)
+		if err != nil {
+			isValid = false
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid approval list expiry date %s - %v", params.Body.ExpiryDate, err))
+		} else if !expiryDate.After(time.Now()) {
+			isValid = false
+			listOfErrors = append(listOfErrors, fmt.Sprintf("invalid approval list expiry date %s - must be in the future", params.Body.ExpiryDate))
+		}
+	}
+
 	return strings.Join(listOfErrors, ", "), isValid
 }
@@ -14,6 +14,8 @@ import (
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/communitybridge/easycla/cla-backend-go/gen/v2/restapi/operations/template"
 	log "github.com/communitybridge/easycla/cla-backend-go/logging"
+	v1Project "github.com/communitybridge/easycla/cla-backend-go/project"
+	v1Signatures "github.com/communitybridge/easycla/cla-backend-go/signatures"
 	v1Template "github.com/communitybridge/easycla/cla-backend-go/template"
 	"github.com/communitybridge/easycla/cla-backend-go/utils"
 	"github.com/go-openapi/runtime"
@@ -22,7 +24,7 @@ import (
 )
 
 // Configure API call
-func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsService v1Events.Service) {
+func Configure(api *operations.EasyclaAPI, service v1Template.Service, projectService v1Project.Service, eventsService v1Events.Service) {
 	// Retrieve a list of available templates
 	api.TemplateGetTemplatesHandler = template.GetTemplatesHandlerFunc(func(params template.GetTemplatesParams, user *auth.User) middleware.Responder {
 
@@ -57,6 +59,10 @@ func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsSer
 			EventData:  &events.CLATemplateCreatedEventData{},
 		})
 
+		if input.NewMajorVersion {
+			processNewMajorVersion(user, params.ClaGroupID, projectService, eventsService)
+		}
+
 		response := &models.TemplatePdfs{}
 		err = copier.Copy(response, pdfUrls)
 		if err != nil {
@@ -86,6 +92,36 @@ func Configure(api *operations.EasyclaAPI, service v1Template.Service, eventsSer
 	})
 }
 
+// processNewMajorVersion logs the new document major version of the CLA Group and, when the CLA Group requires a
+// re-sign, marks the notifications of the outdated signatures as pending - they are sent by the resign lambda
+func processNewMajorVersion(user *auth.User, claGroupID string, projectService v1Project.Service, eventsService v1Events.Service) {
+	claGroupModel, err := projectService.GetCLAGroupByID(claGroupID)
+	if err != nil {
+		log.Warnf("unable to load CLA Group: %s after publishing a new major version, error: %v", claGroupID, err)
+		return
+	}
+
+	eventsService.LogEvent(&events.LogEventArgs{
+		EventType:    events.CLAGroupDocumentMajorVersionUpdated,
+		ProjectModel: claGroupModel,
+		LfUsername:   user.UserName,
+		EventData: &events.CLAGroupDocumentMajorVersionUpdatedEventData{
+			IclaMajorVersion: v1Signatures.GetClaGroupDocumentMajorVersion(claGroupModel, v1Signatures.ICLA),
+			CclaMajorVersion: v1Signatures.GetClaGroupDocumentMajorVersion(claGroupModel, v1Signatures.CCLA),
+			ResignPolicy:     claGroupModel.ProjectResignPolicy,
+			ResignDeadline:   claGroupModel.ProjectResignDeadline,
+		},
+	})
+
+	if claGroupModel.ProjectResignPolicy != v1Signatures.ResignPolicyResign {
+		return
+	}
+	err = projectService.UpdateResignRequestedBy(claGroupID, user.UserName)
+	if err != nil {
+		log.Warnf("unable to queue the re-sign notifications of CLA Group: %s, error: %v", claGroupID, err)
+	}
+}
+
 type codedResponse interface {
 	Code() string
 }
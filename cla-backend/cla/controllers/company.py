@@ -91,7 +91,8 @@ def create_company(auth_user,
 
     manager = cla.controllers.user.get_or_create_user(auth_user)
 
-    for company in get_companies():
+    companies = get_companies()
+    for company in companies:
         if company.get("company_name") == company_name:
             cla.log.error({"error": "Company already exists"})
             response.status = HTTP_409
@@ -100,6 +101,11 @@ def create_company(auth_user,
                             "company_id": company.get("company_id")}
                     }
 
+    # Warn about probable duplicates - the names are fuzzy matched, so the company is created anyway
+    for duplicate in cla.utils.get_probable_duplicate_companies(company_name, companies):
+        cla.log.warning(f'company: {company_name} is a probable duplicate of the existing company: '
+                        f'{duplicate.get("company_name")} ({duplicate.get("company_id")})')
+
     company = Company()
     company.set_company_id(str(uuid.uuid4()))
     company.set_company_name(company_name)
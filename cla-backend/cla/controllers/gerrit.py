@@ -150,6 +150,9 @@ def get_agreement_html(gerrit_id, contract_type):
         cla.log.debug(f'get_agreement_html - {contract_type} - Loaded project record: {str(project)}')
     except DoesNotExist as err:
         return {'errors': {'project_id': str(err)}}
+    if project.get_project_archived():
+        cla.log.warning(f'get_agreement_html - {contract_type} - CLA Group {project.get_project_id()} is archived')
+        return {'errors': {'project_id': f'CLA Group ({project.get_project_id()}) is archived.'}}
 
     # Temporary condition until all CLA Groups are ready for the v2 Contributor Console
     if project.get_version() == 'v2':
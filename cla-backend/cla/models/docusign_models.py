@@ -26,7 +26,7 @@ from cla.utils import get_email_help_content, get_email_sign_off_content
 from cla.models import signing_service_interface, DoesNotExist
 from cla.models.dynamo_models import Signature, User, \
     Project, Company, Gerrit, \
-    Document, Event
+    Document, Event, CONTRIBUTION_POLICY_ICLA_OR_CCLA
 from cla.models.event_types import EventType
 from cla.models.s3_storage import S3Storage
 
@@ -146,6 +146,9 @@ class DocuSign(signing_service_interface.SigningService):
         except DoesNotExist as err:
             cla.log.warning('Individual Signature - project ID NOT found for: {}'.format(request_info))
             return {'errors': {'project_id': str(err)}}
+        if project.get_project_archived():
+            cla.log.warning('Individual Signature - project is archived for: {}'.format(request_info))
+            return {'errors': {'project_id': f'CLA Group ({project_id}) is archived.'}}
 
         # Check for active signature object with this project. If the user has
         # signed the most recent major version, they do not need to sign again.
@@ -277,6 +280,9 @@ class DocuSign(signing_service_interface.SigningService):
         except DoesNotExist as err:
             cla.log.warning('Project ID does NOT found when requesting a signature for: {}'.format(request_info))
             return {'errors': {'project_id': str(err)}}
+        if project.get_project_archived():
+            cla.log.warning('Project is archived when requesting a signature for: {}'.format(request_info))
+            return {'errors': {'project_id': f'CLA Group ({project_id}) is archived.'}}
 
         callback_url = self._generate_individual_signature_callback_url_gerrit(user_id)
         default_cla_values = create_default_individual_values(user)
@@ -371,6 +377,9 @@ class DocuSign(signing_service_interface.SigningService):
         except DoesNotExist:
             cla.log.warning('Project does NOT exist for: {}'.format(request_info))
             return {'errors': {'project_id': f'Project ({project_id}) does not exist.'}}
+        if project.get_project_archived():
+            cla.log.warning('Project is archived for: {}'.format(request_info))
+            return {'errors': {'project_id': f'CLA Group ({project_id}) is archived.'}}
         cla.log.debug(f'Project exists for: {request_info}')
 
         # Ensure the company exists
@@ -402,8 +411,13 @@ class DocuSign(signing_service_interface.SigningService):
             project_id=project_id
         )
         if len(ccla_signatures) < 1:
-            cla.log.warning(f'Company does not have CCLA for: {request_info}')
-            return {'errors': {'missing_ccla': 'Company does not have CCLA with this project'}}
+            # The CCLA of a parent company may cover the employees of the company
+            parent_ccla_signature = cla.utils.get_parent_company_ccla_signature(company, project_id)
+            if parent_ccla_signature is None:
+                cla.log.warning(f'Company does not have CCLA for: {request_info}')
+                return {'errors': {'missing_ccla': 'Company does not have CCLA with this project'}}
+            cla.log.debug(f'Company is covered by the CCLA of a parent company for: {request_info}')
+            ccla_signatures = [parent_ccla_signature]
 
         cla.log.debug(f'Company has {len(ccla_signatures)} CCLAs for: {request_info}')
 
@@ -634,6 +648,11 @@ class DocuSign(signing_service_interface.SigningService):
             contains_pii=True,
         )
 
+        if not gerrit_group_access_allowed(user, project):
+            cla.log.info(f'The contribution policy of the project does not cover the user yet - not adding the user '
+                         f'to the LDAP Groups for: {request_info}')
+            gerrits = []
+
         for gerrit in gerrits:
             # For every Gerrit Instance of this project, add the user to the LDAP Group.
             # this way we are able to keep track of signed signatures when user fails to be added to the LDAP GROUP.
@@ -788,6 +807,9 @@ class DocuSign(signing_service_interface.SigningService):
             cla.log.warning(f'Unable to load project by id: {project_id}. '
                             'Returning an error response')
             return {'errors': {'project_id': str(err)}}
+        if project.get_project_archived():
+            cla.log.warning(f'Project {project_id} is archived. Returning an error response')
+            return {'errors': {'project_id': f'CLA Group ({project_id}) is archived.'}}
 
         # Ensure the company exists
         company = Company()
@@ -1215,6 +1237,10 @@ class DocuSign(signing_service_interface.SigningService):
                 return
 
             gerrits = Gerrit().get_gerrit_by_project_id(signature.get_signature_project_id())
+            if not gerrit_group_access_allowed(user, project):
+                cla.log.info('signed_individual_callback_gerrit - the contribution policy of the project does not '
+                             f'cover user: {user.get_user_id()} yet - not adding the user to the LDAP Groups')
+                gerrits = []
             for gerrit in gerrits:
                 # Get Gerrit Group ID
                 group_id = gerrit.get_group_id_icla()
@@ -1566,6 +1592,17 @@ def update_repository_provider(installation_id, github_repository_id, change_req
     repo_service.update_change_request(installation_id, github_repository_id, change_request_id)
 
 
+def gerrit_group_access_allowed(user: User, project: Project) -> bool:
+    """
+    Helper method to check if the user may be added to the LDAP Groups of the Gerrit instances - the Gerrit instances
+    grant access with the LDAP Group membership, so with a contribution policy other than the default one the user is
+    only added once the signatures of the user cover the user.
+    """
+    if project.get_project_contribution_policy() == CONTRIBUTION_POLICY_ICLA_OR_CCLA:
+        return True
+    return cla.utils.user_signed_project_signature(user, project)
+
+
 def get_org_from_return_url(repo_provider_type, return_url, orgs):
     """
     Helper method to find specific org from list of orgs under same contract group
@@ -8,7 +8,6 @@ Easily access CLA models backed by DynamoDB using pynamodb.
 import base64
 import datetime
 import os
-import re
 import time
 import uuid
 from typing import Optional, List
@@ -907,6 +906,13 @@ class Document(model_interfaces.Document):
         self.add_document_tab(tab)
 
 
+# The CLA Group contribution policies - match the ContributionPolicy constants of the Go backend
+CONTRIBUTION_POLICY_ICLA_OR_CCLA = "icla_or_ccla"
+CONTRIBUTION_POLICY_CCLA_REQUIRED = "ccla_required"
+CONTRIBUTION_POLICY_ICLA_AND_ECLA = "icla_and_ecla"
+CONTRIBUTION_POLICY_RECENT_SIGNATURE = "recent_signature"
+
+
 class ProjectModel(BaseModel):
     """
     Represents a project in the database.
@@ -931,6 +937,23 @@ class ProjectModel(BaseModel):
     project_ccla_requires_icla_signature = BooleanAttribute(default=False)
     foundation_sfid = UnicodeAttribute(null=True)
     root_project_repositories_count = NumberAttribute(null=True)
+    # Re-sign workflow of a new document major version - managed by the Go backend
+    project_resign_policy = UnicodeAttribute(null=True)
+    project_resign_deadline = UnicodeAttribute(null=True)
+    project_resign_requested_by = UnicodeAttribute(null=True)
+    # Template the documents are generated from and its field values - managed by the Go backend
+    project_template_id = UnicodeAttribute(null=True)
+    project_template_meta_fields = MapAttribute(null=True)
+    # Hash of the documents generated from the template - managed by the Go backend
+    project_template_content_hash = UnicodeAttribute(null=True)
+    # Archived CLA Groups - managed by the Go backend
+    project_archived = BooleanAttribute(null=True)
+    project_date_archived = UnicodeAttribute(null=True)
+    project_archived_by = UnicodeAttribute(null=True)
+    project_archived_project_sfids = ListAttribute(null=True)
+    # Contribution policy - managed by the Go backend
+    project_contribution_policy = UnicodeAttribute(null=True)
+    project_contribution_policy_years = NumberAttribute(null=True)
     # Indexes
     project_external_id_index = ExternalProjectIndex()
     project_name_search_index = ProjectNameIndex()
@@ -1025,6 +1048,9 @@ class Project(model_interfaces.Project):  # pylint: disable=too-many-public-meth
         try:
             project_generator = self.model.project_name_lower_search_index.query(project_name.lower())
             for project_model in project_generator:
+                # Archived CLA Groups are hidden from the lookups until they are restored
+                if project_model.project_archived:
+                    continue
                 self.model = project_model
                 return
             # Didn't find a result - throw an error
@@ -1044,6 +1070,32 @@ class Project(model_interfaces.Project):  # pylint: disable=too-many-public-meth
     def get_root_project_repositories_count(self):
         return self.model.root_project_repositories_count
 
+    def get_project_archived(self):
+        return bool(self.model.project_archived)
+
+    def get_project_date_archived(self):
+        return self.model.project_date_archived
+
+    def get_project_archived_by(self):
+        return self.model.project_archived_by
+
+    def get_project_archived_project_sfids(self):
+        return self.model.project_archived_project_sfids or []
+
+    def get_project_contribution_policy(self):
+        return self.model.project_contribution_policy or CONTRIBUTION_POLICY_ICLA_OR_CCLA
+
+    def get_project_contribution_policy_years(self):
+        return int(self.model.project_contribution_policy_years or 0)
+
+    def get_project_template_id(self):
+        return self.model.project_template_id
+
+    def get_project_template_meta_fields(self):
+        if self.model.project_template_meta_fields is None:
+            return {}
+        return self.model.project_template_meta_fields.as_dict()
+
     def get_project_external_id(self):
         return self.model.project_external_id
 
@@ -1246,6 +1298,8 @@ class Project(model_interfaces.Project):  # pylint: disable=too-many-public-meth
         project_generator = self.model.project_external_id_index.query(project_external_id)
         projects = []
         for project_model in project_generator:
+            if project_model.project_archived:
+                continue
             project = Project()
             project.model = project_model
             projects.append(project)
@@ -1281,6 +1335,8 @@ class Project(model_interfaces.Project):  # pylint: disable=too-many-public-meth
             projects = ProjectModel.batch_get(project_ids)
         ret = []
         for project in projects:
+            if project.project_archived:
+                continue
             proj = Project()
             proj.model = project
             ret.append(proj)
@@ -1663,27 +1719,23 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
 
     def preprocess_pattern(self, emails, patterns) -> bool:
         """
-        Helper function that preprocesses given emails against patterns
+        Helper function that matches the domains of the given emails against the domain approval list rules, see
+        cla.utils.match_domain_rules for the rule grammar
 
         :param emails: User emails to be checked
         :type emails: list
-        :return: True if at least one email is matched against pattern else False
+        :param patterns: The domain approval list rules of a CCLA signature
+        :type patterns: list
+        :return: True if at least one email domain is covered by the rules else False
         :rtype: bool
         """
-        for pattern in patterns:
-            if pattern.startswith("*."):
-                pattern = pattern.replace("*.", ".*")
-            elif pattern.startswith("*"):
-                pattern = pattern.replace("*", ".*")
-            elif pattern.startswith("."):
-                pattern = pattern.replace(".", ".*")
-
-            preprocessed_pattern = "^.*@" + pattern + "$"
-            pat = re.compile(preprocessed_pattern)
-            for email in emails:
-                if pat.match(email) is not None:
-                    self.log_debug("found user email in email whitelist pattern")
-                    return True
+        for email in emails:
+            if '@' not in email:
+                continue
+            matched_rule = cla.utils.match_domain_rules(patterns, email.rsplit('@', 1)[1])
+            if matched_rule is not None:
+                self.log_debug(f"found user email domain in domain whitelist rule: {matched_rule}")
+                return True
         return False
 
     # Accepts a Signature object
@@ -1717,11 +1769,8 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
         else:
             cla.log.debug(f"is_whitelisted - no email whitelist match for user: {self}")
 
-        # Secondly, let's check domain whitelist
-        # If a naked domain (e.g. google.com) is provided, we prefix it with '^.*@',
-        # so that sub-domains are not allowed.
-        # If a '*', '*.' or '.' prefix is provided, we replace the prefix with '.*\.',
-        # which will allow subdomains.
+        # Secondly, let's check domain whitelist - a naked domain (e.g. google.com) does not match its sub-domains,
+        # a '*.' prefix matches the sub-domains only and a '!' prefix excludes the domain
         patterns = ccla_signature.get_domain_whitelist()
         cla.log.debug(
             f"is_whitelisted - testing user email domains: {emails} with "
@@ -2265,6 +2314,22 @@ class Signature(model_interfaces.Signature):  # pylint: disable=too-many-public-
     def get_signed_on(self):
         return self.model.signed_on
 
+    def get_signed_on_date(self):
+        """
+        Returns the date the signature was signed as a UTC datetime - the created date for the older signatures without
+        a signed on date, None when neither can be parsed.
+        """
+        if self.model.signed_on:
+            try:
+                signed_on = dateutil.parser.parse(self.model.signed_on)
+                if signed_on.tzinfo is None:
+                    signed_on = signed_on.replace(tzinfo=datetime.timezone.utc)
+                return signed_on
+            except (ValueError, OverflowError):
+                cla.log.warning(f"unable to parse the signed on date: {self.model.signed_on} "
+                                f"of signature: {self.model.signature_id}")
+        return self.model.date_created
+
     def get_signatory_name(self):
         return self.model.signatory_name
 
@@ -2902,6 +2967,23 @@ class ProjectCLAGroup(model_interfaces.ProjectCLAGroup):
         return ret
 
 
+# The maximum number of parent companies above a company - matches MaxCompanyHierarchyDepth of the Go backend
+COMPANY_HIERARCHY_MAX_DEPTH = 10
+
+
+class CompanyDomainModel(MapAttribute):
+    """
+    Represents a domain claimed by a company in the company model.
+    """
+
+    domain = UnicodeAttribute()
+    verification_token = UnicodeAttribute(null=True)
+    verified = BooleanAttribute(default=False)
+    # Not using UTCDateTimeAttribute - the dates are written as strings by the Go backend
+    date_created = UnicodeAttribute(null=True)
+    date_verified = UnicodeAttribute(null=True)
+
+
 class CompanyModel(BaseModel):
     """
     Represents an company in the database.
@@ -2918,8 +3000,13 @@ class CompanyModel(BaseModel):
     company_external_id = UnicodeAttribute(null=True)
     company_manager_id = UnicodeAttribute(null=True)
     company_name = UnicodeAttribute()
+    company_aliases = ListAttribute(null=True)
+    company_former_names = ListAttribute(null=True)
     company_external_id_index = ExternalCompanyIndex()
     company_acl = UnicodeSetAttribute(default=set())
+    company_domains = ListAttribute(of=CompanyDomainModel, null=True)
+    parent_company_id = UnicodeAttribute(null=True)
+    subsidiary_ccla_coverage = BooleanAttribute(null=True)
 
 
 class Company(model_interfaces.Company):  # pylint: disable=too-many-public-methods
@@ -2953,7 +3040,18 @@ class Company(model_interfaces.Company):  # pylint: disable=too-many-public-meth
         )
 
     def to_dict(self):
-        return dict(self.model)
+        company_dict = dict(self.model)
+        # the verification tokens are only returned to the company manager claiming the domain
+        company_dict["company_domains"] = [
+            {
+                "domain": company_domain.domain,
+                "verified": company_domain.verified,
+                "date_created": company_domain.date_created,
+                "date_verified": company_domain.date_verified,
+            }
+            for company_domain in self.get_company_domains()
+        ]
+        return company_dict
 
     def save(self):
         self.model.save()
@@ -2983,6 +3081,56 @@ class Company(model_interfaces.Company):  # pylint: disable=too-many-public-meth
     def get_company_acl(self):
         return self.model.company_acl
 
+    def get_company_aliases(self):
+        return self.model.company_aliases or []
+
+    def get_company_former_names(self):
+        return self.model.company_former_names or []
+
+    def get_company_domains(self):
+        return self.model.company_domains or []
+
+    def get_verified_company_domains(self):
+        return [company_domain.domain for company_domain in self.get_company_domains() if company_domain.verified]
+
+    def get_parent_company_id(self):
+        return self.model.parent_company_id
+
+    def get_subsidiary_ccla_coverage(self):
+        return bool(self.model.subsidiary_ccla_coverage)
+
+    def get_ccla_covering_companies(self):
+        """
+        Returns the parent companies of the company, nearest first, whose CCLAs cover the employees of their
+        subsidiaries.
+
+        :return: The list of parent companies which opted in to cover their subsidiaries.
+        :rtype: [cla.models.dynamo_models.Company]
+        """
+        covering_companies = []
+        visited = {self.get_company_id()}
+        ancestor_id = self.get_parent_company_id()
+        depth = 0
+        while ancestor_id and depth < COMPANY_HIERARCHY_MAX_DEPTH:
+            if ancestor_id in visited:
+                cla.log.warning(f'the hierarchy of company: {self.get_company_id()} contains a cycle at '
+                                f'company: {ancestor_id}')
+                break
+            visited.add(ancestor_id)
+
+            ancestor = Company()
+            try:
+                ancestor.load(ancestor_id)
+            except DoesNotExist:
+                cla.log.warning(f'the parent company: {ancestor_id} of the hierarchy of company: '
+                                f'{self.get_company_id()} does not exist')
+                break
+            if ancestor.get_subsidiary_ccla_coverage():
+                covering_companies.append(ancestor)
+            ancestor_id = ancestor.get_parent_company_id()
+            depth += 1
+        return covering_companies
+
     def set_company_id(self, company_id):
         self.model.company_id = company_id
 
@@ -204,6 +204,9 @@ class GitHub(repository_service_interface.RepositoryService):
             project.load(str(project_id))
         except DoesNotExist as err:
             return {'errors': {'project_id': str(err)}}
+        if project.get_project_archived():
+            cla.log.warning(f'CLA Group {project_id} of repository {repository_id} is archived')
+            return {'errors': {'project_id': f'CLA Group ({project_id}) is archived.'}}
 
         user = self.get_or_create_user(request)
         # Ensure user actually requires a signature for this project.
@@ -347,6 +350,10 @@ class GitHub(repository_service_interface.RepositoryService):
         project_id = repository.get_repository_project_id()
         project = get_project_instance()
         project.load(str(project_id))
+        if project.get_project_archived():
+            cla.log.warning('PR: {}, CLA Group {} of repository {} is archived - returning'.
+                            format(pull_request.number, project_id, github_repository_id))
+            return
 
         # Find users who have signed and who have not signed.
         signed = []
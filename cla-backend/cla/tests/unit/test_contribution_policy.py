@@ -0,0 +1,40 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+"""
+Test the contribution policies against the test vectors shared with the Go backend
+"""
+import json
+import os
+
+import dateutil.parser
+import pytest
+
+from cla import utils
+from cla.models.dynamo_models import Project, Signature
+
+CONTRIBUTION_POLICY_TEST_VECTORS = os.path.join(os.path.dirname(__file__), '..', '..', '..', '..',
+                                                'cla-backend-go', 'tests', 'testdata', 'contribution_policy.json')
+
+with open(CONTRIBUTION_POLICY_TEST_VECTORS, encoding='utf-8') as vectors_file:
+    test_vectors = json.load(vectors_file)
+
+
+def signature_signed_on(signature_id, signed_on):
+    if not signed_on:
+        return None
+    signature = Signature(signature_id=signature_id)
+    signature.set_signed_on(signed_on)
+    return signature
+
+
+@pytest.mark.parametrize("vector", test_vectors["cases"], ids=[v["name"] for v in test_vectors["cases"]])
+def test_contribution_policy_covers(vector):
+    project = Project()
+    project.model.project_contribution_policy = vector["policy"] or None
+    project.model.project_contribution_policy_years = vector["years"]
+    project.model.project_ccla_requires_icla_signature = vector["ccla_requires_icla"]
+    icla = signature_signed_on('icla', vector["icla_signed_on"])
+    ecla = signature_signed_on('ecla', vector["employee_signed_on"])
+    now = dateutil.parser.parse(test_vectors["now"])
+    assert utils.contribution_policy_covers(project, icla, ecla, now) == vector["covered"]
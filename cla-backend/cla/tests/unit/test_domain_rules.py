@@ -0,0 +1,37 @@
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+"""
+Test the domain approval list rules against the test vectors shared with the Go backend
+"""
+import json
+import os
+
+import pytest
+
+from cla import utils
+
+DOMAIN_RULES_TEST_VECTORS = os.path.join(os.path.dirname(__file__), '..', '..', '..', '..',
+                                         'cla-backend-go', 'tests', 'testdata', 'domain_rules.json')
+
+with open(DOMAIN_RULES_TEST_VECTORS, encoding='utf-8') as vectors_file:
+    test_vectors = json.load(vectors_file)
+
+
+@pytest.mark.parametrize("rule", test_vectors["valid_rules"])
+def test_valid_domain_rule(rule):
+    assert utils.parse_domain_rule(rule) is not None
+
+
+@pytest.mark.parametrize("rule", test_vectors["invalid_rules"])
+def test_invalid_domain_rule(rule):
+    with pytest.raises(ValueError):
+        utils.parse_domain_rule(rule)
+
+
+@pytest.mark.parametrize("vector", test_vectors["matches"], ids=[v["name"] for v in test_vectors["matches"]])
+def test_match_domain_rules(vector):
+    matched_rule = utils.match_domain_rules(vector["rules"], vector["domain"])
+    assert (matched_rule is not None) == vector["covered"]
+    if vector["covered"]:
+        assert matched_rule == vector["matched_rule"]
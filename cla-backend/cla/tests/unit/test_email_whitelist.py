@@ -64,6 +64,13 @@ def test_pattern_with_dot_prefix(create_user):
     domain_emails = ["harold@help.bar.com"]
     assert create_user.preprocess_pattern(domain_emails, patterns) == True
 
+
+def test_pattern_with_exclusion_prefix(create_user):
+    """Test given user email against an exclusion pattern """
+    patterns = ["*.bar.com", "!contractors.bar.com"]
+    assert create_user.preprocess_pattern(["harold@help.bar.com"], patterns) == True
+    assert create_user.preprocess_pattern(["harold@contractors.bar.com"], patterns) == False
+
 def test_email_whitelist_fail(create_user):
     """Test email that fails domain and email whitelist checks """
     signature = Signature()
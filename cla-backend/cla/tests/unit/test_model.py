@@ -8,7 +8,7 @@ from unittest.mock import patch, MagicMock
 
 import pytest
 
-from cla.models.dynamo_models import SignatureModel, UserModel
+from cla.models.dynamo_models import SignatureModel, UserModel, CompanyDomainModel, Project, ProjectModel
 from cla.utils import get_user_instance, get_signature_instance, get_company_instance
 from cla import utils
 from cla.tests.unit.data import USER_TABLE_DATA
@@ -27,6 +27,34 @@ def test_company_external_id(company_instance):
     assert "external id: external id" in str(company_instance)
 
 
+def test_company_domains_hide_verification_token(company_instance):
+    company_instance.model.company_domains = [
+        CompanyDomainModel(domain="example.com", verification_token="token-1", verified=True,
+                           date_created="2020-10-01T00:00:00Z", date_verified="2020-10-02T00:00:00Z"),
+        CompanyDomainModel(domain="example.org", verification_token="token-2", verified=False,
+                           date_created="2020-10-01T00:00:00Z"),
+    ]
+    assert company_instance.get_verified_company_domains() == ["example.com"]
+    company_dict = company_instance.to_dict()
+    assert [d["domain"] for d in company_dict["company_domains"]] == ["example.com", "example.org"]
+    assert "token-1" not in str(company_dict)
+
+
+def test_archived_projects_are_hidden():
+    active = ProjectModel(project_id="active", project_external_id="external", project_name="Active")
+    archived = ProjectModel(project_id="archived", project_external_id="external", project_name="Archived",
+                            project_archived=True, project_archived_project_sfids=["sfid"])
+    with patch.object(ProjectModel, "scan", return_value=[active, archived]):
+        assert [p.get_project_id() for p in Project().all()] == ["active"]
+
+    project = Project()
+    project.model = archived
+    assert project.get_project_archived()
+    assert project.get_project_archived_project_sfids() == ["sfid"]
+    project.model = active
+    assert not project.get_project_archived()
+
+
 def test_signature_project_external_id(signature_instance):
     assert "signature project external id: proj_id" in str(signature_instance)
 
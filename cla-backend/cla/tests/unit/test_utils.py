@@ -1,12 +1,13 @@
 # Copyright The Linux Foundation and each contributor to CommunityBridge.
 # SPDX-License-Identifier: MIT
+import datetime
 import logging
 import unittest
 from unittest.mock import Mock, patch
 
 import cla
 from cla import utils
-from cla.models.dynamo_models import Signature, User
+from cla.models.dynamo_models import Signature, User, Project
 
 
 class TestUtils(unittest.TestCase):
@@ -160,6 +161,82 @@ class TestUtils(unittest.TestCase):
         signature.get_github_org_whitelist = Mock(return_value=['foo-org'])
         self.assertTrue(utils.is_whitelisted(signature, github_username='foo'))
 
+    def test_get_parent_company_ccla_signature(self) -> None:
+        """
+        Test the CCLA of the nearest covering parent company with a CCLA covers the employees of the company
+        """
+        signature = Signature()
+        unsigned_parent = Mock()
+        unsigned_parent.get_latest_signature.return_value = None
+        signed_parent = Mock()
+        signed_parent.get_latest_signature.return_value = signature
+        company = Mock()
+        company.get_ccla_covering_companies.return_value = [unsigned_parent, signed_parent]
+        self.assertEqual(utils.get_parent_company_ccla_signature(company, 'project-id'), signature)
+
+        company.get_ccla_covering_companies.return_value = [unsigned_parent]
+        self.assertIsNone(utils.get_parent_company_ccla_signature(company, 'project-id'))
+
+
+    def test_company_name_match_score(self) -> None:
+        """
+        Test the company name match scores match the CompanyNameMatchScore cases of the Go backend
+        """
+        self.assertEqual(utils.normalize_company_name('  Red   Hat LLC '), 'red hat')
+        self.assertEqual(utils.company_name_match_score('acme inc', 'ACME, Inc.'), 1)
+        self.assertGreaterEqual(utils.company_name_match_score('IBM', 'International Business Machines'),
+                                utils.PROBABLE_DUPLICATE_COMPANY_SCORE)
+        self.assertGreaterEqual(utils.company_name_match_score('Micrsoft', 'Microsoft Corporation'),
+                                utils.PROBABLE_DUPLICATE_COMPANY_SCORE)
+        self.assertLess(utils.company_name_match_score('Google', 'Alphabet Inc.'), utils.MIN_COMPANY_SEARCH_SCORE)
+
+    def test_get_probable_duplicate_companies(self) -> None:
+        """
+        Test the aliases and former names of the existing companies are matched as well as the name
+        """
+        alphabet = {'company_id': 'alphabet', 'company_name': 'Alphabet Inc.', 'company_aliases': ['Google LLC']}
+        intel = {'company_id': 'intel', 'company_name': 'Intel', 'company_aliases': None}
+        self.assertEqual(utils.get_probable_duplicate_companies('Google', [alphabet, intel]), [alphabet])
+        self.assertEqual(utils.get_probable_duplicate_companies('Acme', [alphabet, intel]), [])
+
+    def test_contribution_policy_covers(self) -> None:
+        """
+        Test the contribution policies match the EvaluateContributionPolicy cases of the Go backend
+        """
+        now = datetime.datetime(2020, 10, 1, tzinfo=datetime.timezone.utc)
+        icla = Signature(signature_id='icla')
+        icla.set_signed_on('2016-06-01T00:00:00Z')
+        ecla = Signature(signature_id='ecla')
+        ecla.set_signed_on('2019-03-01T00:00:00Z')
+
+        project = Project()
+        self.assertTrue(utils.contribution_policy_covers(project, icla, None, now), 'the default policy accepts an ICLA')
+        self.assertTrue(utils.contribution_policy_covers(project, None, ecla, now),
+                        'the default policy accepts an acknowledged employee signature')
+        self.assertFalse(utils.contribution_policy_covers(project, None, None, now))
+
+        project.model.project_contribution_policy = 'ccla_required'
+        self.assertFalse(utils.contribution_policy_covers(project, icla, None, now),
+                         'an ICLA is not enough when a CCLA is required')
+        self.assertTrue(utils.contribution_policy_covers(project, None, ecla, now))
+        project.model.project_ccla_requires_icla_signature = True
+        self.assertFalse(utils.contribution_policy_covers(project, None, ecla, now),
+                         'the CCLA is not enough when the CLA Group requires an ICLA')
+        self.assertTrue(utils.contribution_policy_covers(project, icla, ecla, now))
+
+        project = Project()
+        project.model.project_contribution_policy = 'icla_and_ecla'
+        self.assertFalse(utils.contribution_policy_covers(project, icla, None, now),
+                         'the employee acknowledgement is missing')
+        self.assertTrue(utils.contribution_policy_covers(project, icla, ecla, now))
+
+        project.model.project_contribution_policy = 'recent_signature'
+        project.model.project_contribution_policy_years = 3
+        self.assertFalse(utils.contribution_policy_covers(project, icla, None, now),
+                         'the ICLA is older than three years')
+        self.assertTrue(utils.contribution_policy_covers(project, icla, ecla, now),
+                        'the employee acknowledgement was signed within three years')
+
 
 if __name__ == '__main__':
     unittest.main()
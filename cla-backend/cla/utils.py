@@ -5,6 +5,7 @@
 Utility functions for the CLA project.
 """
 
+import datetime
 import inspect
 import json
 import os
@@ -13,6 +14,7 @@ from typing import List, Optional
 
 import falcon
 import requests
+from dateutil.relativedelta import relativedelta
 from hug.middleware import SessionMiddleware
 from requests_oauthlib import OAuth2Session
 
@@ -20,7 +22,9 @@ import cla
 from cla.models import DoesNotExist
 from cla.models.dynamo_models import User, Signature, Repository, \
     Company, Project, Document, \
-    GitHubOrg, Gerrit, UserPermissions, Event, CompanyInvite, ProjectCLAGroup, CCLAWhitelistRequest
+    GitHubOrg, Gerrit, UserPermissions, Event, CompanyInvite, ProjectCLAGroup, CCLAWhitelistRequest, \
+    CONTRIBUTION_POLICY_ICLA_OR_CCLA, CONTRIBUTION_POLICY_CCLA_REQUIRED, CONTRIBUTION_POLICY_ICLA_AND_ECLA, \
+    CONTRIBUTION_POLICY_RECENT_SIGNATURE
 from cla.models.event_types import EventType
 
 API_BASE_URL = os.environ.get('CLA_API_BASE', '')
@@ -596,7 +600,7 @@ def user_ccla_check(user: User, project: Project, signature: Signature) -> bool:
 def user_signed_project_signature(user: User, project: Project):
     """
     Helper function to check if a user has signed a project signature tied to a repository.
-    Will consider both ICLA and employee signatures.
+    Will consider both ICLA and employee signatures under the contribution policy of the project.
 
     :param user: The user object to check for.
     :type user: cla.models.model_interfaces.User
@@ -612,75 +616,165 @@ def user_signed_project_signature(user: User, project: Project):
     # Check if we have an ICLA for this user
     cla.log.debug(f'checking to see if user has signed an ICLA, user: {user}, project: {project}')
 
-    signature = user.get_latest_signature(project.get_project_id(), signature_signed=True, signature_approved=True)
-    icla_pass = False
-    if signature is not None:
-        icla_pass = True
-    else:
+    icla_signature = user.get_latest_signature(project.get_project_id(), signature_signed=True,
+                                               signature_approved=True)
+    if icla_signature is None:
         cla.log.debug(f'ICLA signature NOT found for User: {user} on project: {project}')
 
-    # If we passed the ICLA check - good, return true, no need to check CCLA
-    if icla_pass:
+    # If we passed the ICLA check with the default policy - good, return true, no need to check CCLA
+    policy = project.get_project_contribution_policy()
+    if icla_signature is not None and policy == CONTRIBUTION_POLICY_ICLA_OR_CCLA:
         cla.log.debug(f'ICLA signature check passed for User: {user} on project: {project} - skipping CCLA check')
         return True
     else:
-        cla.log.debug(f'ICLA signature check failed for User: {user} on project: {project} - will now check CCLA')
+        cla.log.debug(f'ICLA signature check for User: {user} on project: {project} with contribution policy: '
+                      f'{policy} - will now check CCLA')
+
+    employee_signature = get_user_acknowledged_employee_signature(user, project)
+    if employee_signature is not None:
+        cla.log.debug(f'CCLA signature check passed for User: {user} on project: {project}')
+    else:
+        cla.log.debug(f'CCLA signature check failed for User: {user} on project: {project}')
+
+    if contribution_policy_covers(project, icla_signature, employee_signature):
+        cla.log.debug(f'User: {user} passed the {policy} contribution policy of project: {project}')
+        return True
 
+    cla.log.debug(f'User: {user} failed the {policy} contribution policy of project: {project}')
+    return False
+
+
+def get_user_acknowledged_employee_signature(user: User, project: Project) -> Optional[Signature]:
+    """
+    Helper function to get the employee signature of the user for the company of the user whose CCLA, or the CCLA of
+    its covering parent company, has an approval list which contains the user. The employee signatures of a user who
+    is not on the approval list are disapproved.
+
+    :param user: The user object to check for.
+    :type user: cla.models.model_interfaces.User
+    :param project: The project to check for.
+    :type project: cla.models.model_interfaces.Project
+    :return: The latest signed and approved employee signature of the user, None if the user is not covered by a CCLA.
+    :rtype: cla.models.model_interfaces.Signature or None
+    """
     # Check if we have an CCLA for this user
     company_id = user.get_user_company_id()
+    if company_id is None:
+        cla.log.debug(f'User: {user} is NOT associated with a company - unable to check for a CCLA.')
+        return None
 
-    ccla_pass = False
-    if company_id is not None:
-        # Get employee signature
-        employee_signature = user.get_latest_signature(project.get_project_id(
-        ), company_id=company_id, signature_signed=True, signature_approved=True)
-        if employee_signature is not None:
-            company = get_company_instance()
-            company.load(company_id)
-            # Get CCLA signature of company to access whitelist
-            cla.log.debug('checking to see if users company has signed an CCLA, '
-                          f'user: {user}, project_id: {project}, company_id: {company_id}')
-            signature = company.get_latest_signature(
-                project.get_project_id(), signature_signed=True, signature_approved=True)
-
-            # Don't check the version for employee signatures.
-            if signature is not None:
-                # Verify if user has been whitelisted: https://github.com/communitybridge/easycla/issues/332
-                if user.is_whitelisted(signature):
-                    ccla_pass = True
-                else:
-                    # Set user signatures approved = false due to user failing whitelist checks
-                    cla.log.debug('user not whitelisted- marking signature approved = false for '
-                                  f'user: {user}, project_id: {project}, company_id: {company_id}')
-                    user_signatures = user.get_user_signatures(
-                        project_id=project.get_project_id(), company_id=company_id, signature_approved=True,
-                        signature_signed=True
-                    )
-                    for signature in user_signatures:
-                        signature.set_signature_approved(False)
-                        signature.save()
-                        Event.create_event(
-                            event_type=EventType.EmployeeSignatureDisapproved,
-                            event_project_id=project.get_project_id(),
-                            event_company_id=company.get_company_id(),
-                            event_user_id=user.get_user_id(),
-                            event_data=(f'employee signature of user {user.get_user_name()} '
-                                        f'disapproved for project {project.get_project_name()} '
-                                        f'and company {company.get_company_name()}'),
-                            contains_pii=True,
-                        )
+    # Get employee signature
+    employee_signature = user.get_latest_signature(project.get_project_id(
+    ), company_id=company_id, signature_signed=True, signature_approved=True)
+    if employee_signature is None:
+        return None
 
-    else:
-        cla.log.debug(f'User: {user} is NOT associated with a company - unable to check for a CCLA.')
+    company = get_company_instance()
+    company.load(company_id)
+    # Get CCLA signature of company to access whitelist
+    cla.log.debug('checking to see if users company has signed an CCLA, '
+                  f'user: {user}, project_id: {project}, company_id: {company_id}')
+    signature = company.get_latest_signature(
+        project.get_project_id(), signature_signed=True, signature_approved=True)
+    if signature is None:
+        # The CCLA of a parent company may cover the employees of the company
+        signature = get_parent_company_ccla_signature(company, project.get_project_id())
+
+    # Don't check the version for employee signatures.
+    if signature is None:
+        return None
 
-    if ccla_pass:
-        cla.log.debug(f'CCLA signature check passed for User: {user} on project: {project}')
+    # Verify if user has been whitelisted: https://github.com/communitybridge/easycla/issues/332
+    if user.is_whitelisted(signature):
+        return employee_signature
+
+    # Set user signatures approved = false due to user failing whitelist checks
+    cla.log.debug('user not whitelisted- marking signature approved = false for '
+                  f'user: {user}, project_id: {project}, company_id: {company_id}')
+    user_signatures = user.get_user_signatures(
+        project_id=project.get_project_id(), company_id=company_id, signature_approved=True,
+        signature_signed=True
+    )
+    for signature in user_signatures:
+        signature.set_signature_approved(False)
+        signature.save()
+        Event.create_event(
+            event_type=EventType.EmployeeSignatureDisapproved,
+            event_project_id=project.get_project_id(),
+            event_company_id=company.get_company_id(),
+            event_user_id=user.get_user_id(),
+            event_data=(f'employee signature of user {user.get_user_name()} '
+                        f'disapproved for project {project.get_project_name()} '
+                        f'and company {company.get_company_name()}'),
+            contains_pii=True,
+        )
+    return None
+
+
+def contribution_policy_covers(project: Project, icla_signature: Optional[Signature],
+                               employee_signature: Optional[Signature], now: datetime.datetime = None) -> bool:
+    """
+    Helper function to check if the signatures of a user cover the user under the contribution policy of the
+    project. The rules are those of EvaluateContributionPolicy of the Go backend and both are checked against the
+    test vectors in cla-backend-go/tests/testdata/contribution_policy.json - unlike the Go backend, the caller checks
+    the employee signature is for the company of the user and covered by a CCLA approval list.
+
+    :param project: The project to check for.
+    :type project: cla.models.model_interfaces.Project
+    :param icla_signature: The signed and approved ICLA of the user, if any.
+    :type icla_signature: cla.models.model_interfaces.Signature or None
+    :param employee_signature: The employee signature of the user acknowledged by an approval list of a CCLA, if any.
+    :type employee_signature: cla.models.model_interfaces.Signature or None
+    :param now: The time the recent_signature policy is evaluated at, defaults to the current time.
+    :type now: datetime.datetime
+    :return: Whether or not the signatures cover the user.
+    :rtype: boolean
+    """
+    policy = project.get_project_contribution_policy()
+    if policy == CONTRIBUTION_POLICY_CCLA_REQUIRED:
+        if employee_signature is None:
+            return False
+        return icla_signature is not None or not project.get_project_ccla_requires_icla_signature()
+
+    if policy == CONTRIBUTION_POLICY_ICLA_AND_ECLA:
+        return icla_signature is not None and employee_signature is not None
+
+    if policy == CONTRIBUTION_POLICY_RECENT_SIGNATURE:
+        if now is None:
+            now = datetime.datetime.now(datetime.timezone.utc)
+        cutoff = now - relativedelta(years=project.get_project_contribution_policy_years())
+        for signature in (icla_signature, employee_signature):
+            if signature is None:
+                continue
+            signed_on = signature.get_signed_on_date()
+            if signed_on is not None and signed_on > cutoff:
+                return True
+        return False
+
+    if icla_signature is not None:
         return True
-    else:
-        cla.log.debug(f'CCLA signature check failed for User: {user} on project: {project}')
+    return employee_signature is not None and not project.get_project_ccla_requires_icla_signature()
 
-    cla.log.debug(f'User: {user} failed both ICLA and CCLA checks')
-    return False
+
+def get_parent_company_ccla_signature(company: Company, project_id: str) -> Optional[Signature]:
+    """
+    Helper function to get the CCLA of the nearest parent company whose CCLA covers the employees of the company.
+
+    :param company: The company object to check for.
+    :type company: cla.models.model_interfaces.Company
+    :param project_id: The ID of the project to check for.
+    :type project_id: string
+    :return: The latest signed and approved CCLA of the nearest covering parent company, None if the company has no
+        parent company which opted in to cover its subsidiaries with a CCLA for the project.
+    :rtype: cla.models.model_interfaces.Signature or None
+    """
+    for parent_company in company.get_ccla_covering_companies():
+        signature = parent_company.get_latest_signature(project_id, signature_signed=True, signature_approved=True)
+        if signature is not None:
+            cla.log.debug(f'the CCLA of parent company: {parent_company.get_company_name()} covers the employees of '
+                          f'company: {company.get_company_name()} for project: {project_id}')
+            return signature
+    return None
 
 
 def get_redirect_uri(repository_service, installation_id, github_repository_id, change_request_id):
@@ -1302,6 +1396,162 @@ def update_github_username(github_user: dict, user: User):
             user.set_user_github_username(github_user['login'])
 
 
+# Domain approval list rules - the same grammar as cla-backend-go/utils/domain_rules.go:
+#
+#   example.com                - matches example.com only
+#   *.example.com              - matches example.com and any subdomain of example.com
+#   .example.com               - the same as *.example.com
+#   *example.com               - matches any domain ending with example.com, such as myexample.com
+#   !contractors.example.com   - excludes contractors.example.com
+#   !*.contractors.example.com - excludes contractors.example.com and any subdomain of contractors.example.com
+#
+# A domain is covered when at least one inclusion rule matches and no exclusion rule matches, regardless of the
+# order of the rules. Domains are compared case insensitively, a trailing period is ignored and internationalized
+# domain names are compared in their ASCII (punycode) form.
+DOMAIN_RULE_WILDCARD_PREFIX = '*.'
+DOMAIN_RULE_LEGACY_WILDCARD_PREFIX = '.'
+DOMAIN_RULE_SUFFIX_PREFIX = '*'
+DOMAIN_RULE_EXCLUSION_PREFIX = '!'
+
+
+def normalize_domain(domain: str) -> str:
+    """
+    Returns the lower case ASCII form of the domain without the trailing period.
+
+    :param domain: the domain to normalize
+    :raises ValueError: if the domain is empty or is not a valid host name
+    """
+    domain = domain.strip()
+    if domain.endswith('.'):
+        domain = domain[:-1]
+    if not domain:
+        raise ValueError('domain is empty')
+
+    try:
+        ascii_domain = domain.encode('idna').decode('ascii').lower()
+    except UnicodeError as err:
+        raise ValueError(f'invalid internationalized domain name: {err}')
+
+    for label in ascii_domain.split('.'):
+        if any(not (c.isascii() and (c.isalnum() or c == '-')) for c in label):
+            raise ValueError(f'label {label} contains an invalid character')
+        if label.startswith('-') or label.endswith('-'):
+            raise ValueError(f'label {label} begins or ends with a hyphen')
+    return ascii_domain
+
+
+def valid_domain(domain: str) -> Optional[str]:
+    """
+    Tests the specified domain, returns None if the domain is valid or a message describing the problem otherwise.
+
+    :param domain: the domain to test
+    """
+    domain = domain.strip()
+    if not domain:
+        return 'domain is empty'
+    if len(domain) > 255:
+        return f'domain name length is {len(domain)}, can\'t exceed 255'
+
+    labels = domain.split('.')
+    for label in labels[:-1]:
+        if not label:
+            return 'label can\'t begin with a period'
+        if len(label) > 63:
+            return f'byte length of label {label} is {len(label)}, can\'t exceed 63'
+        if label.startswith('-') or label.endswith('-'):
+            return f'label {label} begins or ends with a hyphen'
+    for c in domain:
+        if not (c.isascii() and (c.isalnum() or c in '-.')):
+            return f'invalid character {c}'
+
+    top_level_domain = labels[-1]
+    if not top_level_domain:
+        return 'missing top level domain, domain can\'t end with a period'
+    if len(top_level_domain) > 63:
+        return f'byte length of top level domain {top_level_domain} is {len(top_level_domain)}, can\'t exceed 63'
+    if top_level_domain.startswith('-') or top_level_domain.endswith('-'):
+        return f'top level domain {top_level_domain} begins or ends with a hyphen'
+    if top_level_domain[0].isdigit():
+        return f'top level domain {top_level_domain} begins with a digit'
+    return None
+
+
+def parse_domain_rule(rule: str) -> dict:
+    """
+    Parses the domain approval list rule.
+
+    :param rule: the domain approval list rule
+    :return: a dict with the normalized domain and the wildcard, suffix and exclude flags of the rule
+    :raises ValueError: if the rule is invalid
+    """
+    value = rule.strip()
+    exclude = value.startswith(DOMAIN_RULE_EXCLUSION_PREFIX)
+    if exclude:
+        value = value[len(DOMAIN_RULE_EXCLUSION_PREFIX):].strip()
+    wildcard, suffix = False, False
+    if value.startswith(DOMAIN_RULE_WILDCARD_PREFIX):
+        wildcard = True
+        value = value[len(DOMAIN_RULE_WILDCARD_PREFIX):]
+    elif value.startswith(DOMAIN_RULE_LEGACY_WILDCARD_PREFIX):
+        wildcard = True
+        value = value[len(DOMAIN_RULE_LEGACY_WILDCARD_PREFIX):]
+    elif value.startswith(DOMAIN_RULE_SUFFIX_PREFIX):
+        suffix = True
+        value = value[len(DOMAIN_RULE_SUFFIX_PREFIX):]
+    if '*' in value:
+        raise ValueError('wildcard is only supported as the first label, e.g. *.example.com')
+
+    domain = normalize_domain(value)
+    msg = valid_domain(domain)
+    if msg is not None:
+        raise ValueError(msg)
+    return {'domain': domain, 'wildcard': wildcard, 'suffix': suffix, 'exclude': exclude}
+
+
+def match_domain_rules(rules: List[str], domain: str) -> Optional[str]:
+    """
+    Returns the first inclusion rule which matches the domain, or None when no inclusion rule matches or an
+    exclusion rule matches the domain.
+
+    :param rules: the domain approval list rules of a CCLA signature
+    :param domain: the email domain of the contributor
+    """
+    domain = domain.strip()
+    if not domain:
+        return None
+    try:
+        normalized_domain = normalize_domain(domain)
+    except ValueError:
+        normalized_domain = None
+
+    matched_rule = None
+    for rule in rules:
+        try:
+            if normalized_domain is None:
+                raise ValueError('domain is not a valid host name')
+            domain_rule = parse_domain_rule(rule)
+        except ValueError:
+            # entries saved before the rules were validated are compared as plain domains
+            if matched_rule is None and rule.strip().lower() == domain.lower():
+                matched_rule = rule
+            continue
+
+        if domain_rule['suffix']:
+            matches = normalized_domain.endswith(domain_rule['domain'])
+        elif domain_rule['wildcard']:
+            matches = (normalized_domain == domain_rule['domain'] or
+                       normalized_domain.endswith('.' + domain_rule['domain']))
+        else:
+            matches = normalized_domain == domain_rule['domain']
+        if not matches:
+            continue
+        if domain_rule['exclude']:
+            return None
+        if matched_rule is None:
+            matched_rule = rule
+    return matched_rule
+
+
 def is_whitelisted(ccla_signature: Signature, email=None, github_username=None, github_id=None):
     """
     Given either email, github username or github id a check is made against ccla signature to
@@ -1458,3 +1708,109 @@ def get_email_sign_off_content() -> str:
         <p>Thanks,</p>
         <p>EasyCLA support team</p>
     """
+
+
+# Company name matching - the same scoring as cla-backend-go/utils/company_name_match.go. The relevance score of a
+# company name match is a value between 0 and 1.
+MIN_COMPANY_SEARCH_SCORE = 0.6
+PROBABLE_DUPLICATE_COMPANY_SCORE = 0.85
+COMPANY_NAME_PREFIX_SCORE = 0.9
+COMPANY_NAME_ACRONYM_SCORE = 0.9
+COMPANY_NAME_CONTAINS_SCORE = 0.8
+
+# the legal entity suffixes ignored when comparing company names
+COMPANY_NAME_SUFFIXES = {'inc', 'incorporated', 'llc', 'ltd', 'limited', 'corp', 'corporation', 'co', 'company',
+                         'gmbh', 'ag', 'sa', 'plc', 'bv', 'srl', 'pty', 'lp', 'llp'}
+# the words skipped when building the acronym of a company name
+COMPANY_NAME_STOP_WORDS = {'of', 'and', 'the', 'for'}
+
+
+def normalize_company_name(name: str) -> str:
+    """
+    Returns the lower case company name without punctuation and legal entity suffixes, e.g. "Acme, Inc." becomes "acme"
+
+    :param name: the company name
+    """
+    words = ''.join(c if c.isalpha() or c.isdecimal() else ' ' for c in name.lower()).split()
+    while len(words) > 1 and words[-1] in COMPANY_NAME_SUFFIXES:
+        words = words[:-1]
+    return ' '.join(words)
+
+
+def _company_name_acronym(name: str) -> str:
+    words = name.split()
+    if len(words) < 2:
+        return ''
+    return ''.join(word[0] for word in words if word not in COMPANY_NAME_STOP_WORDS)
+
+
+def _trigrams(value: str) -> set:
+    padded = '  ' + value + ' '
+    return {padded[i:i + 3] for i in range(len(padded) - 2)}
+
+
+def _trigram_similarity(a: str, b: str) -> float:
+    trigrams_a, trigrams_b = _trigrams(a), _trigrams(b)
+    if not trigrams_a or not trigrams_b:
+        return 0
+    return 2 * len(trigrams_a & trigrams_b) / (len(trigrams_a) + len(trigrams_b))
+
+
+def _edit_similarity(a: str, b: str) -> float:
+    longest = max(len(a), len(b))
+    if longest == 0:
+        return 1
+    previous = list(range(len(b) + 1))
+    for i in range(1, len(a) + 1):
+        current = [i] + [0] * len(b)
+        for j in range(1, len(b) + 1):
+            cost = 0 if a[i - 1] == b[j - 1] else 1
+            current[j] = min(previous[j] + 1, current[j - 1] + 1, previous[j - 1] + cost)
+        previous = current
+    return 1 - previous[len(b)] / longest
+
+
+def company_name_match_score(search_term: str, company_name: str) -> float:
+    """
+    Returns the relevance score of the company name for the search term - 1 is an exact match of the normalized names,
+    0 is no similarity at all.
+
+    :param search_term: the searched company name
+    :param company_name: the name of an existing company
+    """
+    term = normalize_company_name(search_term)
+    name = normalize_company_name(company_name)
+    if not term or not name:
+        return 0
+    if term == name:
+        return 1
+
+    score = max(_trigram_similarity(term, name), _edit_similarity(term, name))
+
+    # short search terms match too many names by prefix or contains
+    if len(term) >= 3:
+        if name.startswith(term):
+            score = max(score, COMPANY_NAME_PREFIX_SCORE)
+        elif term in name:
+            score = max(score, COMPANY_NAME_CONTAINS_SCORE)
+
+    if len(term) >= 2 and (term == _company_name_acronym(name) or name == _company_name_acronym(term)):
+        score = max(score, COMPANY_NAME_ACRONYM_SCORE)
+    return score
+
+
+def get_probable_duplicate_companies(company_name: str, companies: List[dict]) -> List[dict]:
+    """
+    Returns the companies whose name, aliases or former names are similar enough to the company name to probably be
+    the same company.
+
+    :param company_name: the name of the company to create
+    :param companies: the existing companies in dict format
+    """
+    duplicates = []
+    for company in companies:
+        names = [company.get('company_name') or ''] + list(company.get('company_aliases') or []) + \
+                list(company.get('company_former_names') or [])
+        if max(company_name_match_score(company_name, name) for name in names) >= PROBABLE_DUPLICATE_COMPANY_SCORE:
+            duplicates.append(company)
+    return duplicates
@@ -57,6 +57,9 @@ const gerritInstancesTable = buildGerritInstancesTable(importResources);
 const userPermissionsTable = buildUserPermissionsTable(importResources);
 const companyInvitesTable = buildCompanyInvitesTable(importResources);
 const claManagerRequestsTable = buildCLAManagerRequestsTable(importResources);
+const claManagerDesigneesTable = buildCLAManagerDesigneesTable(importResources);
+const notificationPreferencesTable = buildNotificationPreferencesTable(importResources);
+const notificationQueueTable = buildNotificationQueueTable(importResources);
 const storeTable = buildStoreTable(importResources);
 const sessionStoreTable = buildSessionStoreTable(importResources);
 const eventsTable = buildEventsTable(importResources);
@@ -665,6 +668,87 @@ function buildCLAManagerRequestsTable(importResources: boolean): aws.dynamodb.Ta
   );
 }
 
+/**
+ * CLA Manager Designees Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildCLAManagerDesigneesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-cla-manager-designees',
+    {
+      name: 'cla-' + stage + '-cla-manager-designees',
+      attributes: [
+        { name: 'designee_id', type: 'S' },
+      ],
+      hashKey: 'designee_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: 1,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-cla-manager-designees' } : {},
+  );
+}
+
+/**
+ * Notification Preferences Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildNotificationPreferencesTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-notification-preferences',
+    {
+      name: 'cla-' + stage + '-notification-preferences',
+      attributes: [
+        { name: 'user_email', type: 'S' },
+      ],
+      hashKey: 'user_email',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: 1,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-notification-preferences' } : {},
+  );
+}
+
+/**
+ * Notification Queue Table
+ *
+ * @param importResources flag to indicate if we should import the resources
+ * into our stack from the provider (rather than creating it for the first
+ * time).
+ */
+function buildNotificationQueueTable(importResources: boolean): aws.dynamodb.Table {
+  return new aws.dynamodb.Table(
+    'cla-' + stage + '-notification-queue',
+    {
+      name: 'cla-' + stage + '-notification-queue',
+      attributes: [
+        { name: 'notification_id', type: 'S' },
+      ],
+      hashKey: 'notification_id',
+      readCapacity: defaultReadCapacity,
+      writeCapacity: 1,
+      pointInTimeRecovery: {
+        enabled: pointInTimeRecoveryEnabled,
+      },
+      tags: defaultTags,
+    },
+    importResources ? { import: 'cla-' + stage + '-notification-queue' } : {},
+  );
+}
+
 /**
  * Store Table
  *
@@ -996,6 +1080,12 @@ export const companyInvitesTableName = companyInvitesTable.name;
 export const companyInvitesTableARN = companyInvitesTable.arn;
 export const claManagerRequestsTableName = claManagerRequestsTable.name;
 export const claManagerRequestsTableARN = claManagerRequestsTable.arn;
+export const claManagerDesigneesTableName = claManagerDesigneesTable.name;
+export const claManagerDesigneesTableARN = claManagerDesigneesTable.arn;
+export const notificationPreferencesTableName = notificationPreferencesTable.name;
+export const notificationPreferencesTableARN = notificationPreferencesTable.arn;
+export const notificationQueueTableName = notificationQueueTable.name;
+export const notificationQueueTableARN = notificationQueueTable.arn;
 export const storeTableName = storeTable.name;
 export const storeTableARN = storeTable.arn;
 export const sessionStoreTableName = sessionStoreTable.name;